@@ -0,0 +1,302 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// AccessRule is a condition in an Access policy or group, such as "the user's
+// email is in example.com". Build them with the Access functions, e.g.
+// AccessEmailDomain("example.com").
+//
+// In JSON a rule is an object with a single key naming its type. Rules of
+// types we have no function for may be built directly, e.g.
+// AccessRule{"login_method": map[string]string{"id": id}}.
+type AccessRule map[string]interface{}
+
+// AccessGroup is a reusable set of Access rules. Policies include, exclude,
+// or require groups as they would individual rules.
+type AccessGroup struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+
+	// A user is in the group if they match any Include rule, all Require
+	// rules, and no Exclude rule.
+	Include []AccessRule `json:"include"`
+	Exclude []AccessRule `json:"exclude,omitempty"`
+	Require []AccessRule `json:"require,omitempty"`
+
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// Type is the type of the rule, e.g. email.
+func (r AccessRule) Type() string {
+	for k := range r {
+		return k
+	}
+	return ""
+}
+
+// AccessEveryone matches everyone.
+func AccessEveryone() AccessRule {
+	return AccessRule{"everyone": struct{}{}}
+}
+
+// AccessEmail matches a user with an email address.
+func AccessEmail(email string) AccessRule {
+	return AccessRule{"email": map[string]string{"email": email}}
+}
+
+// AccessEmailDomain matches users with email addresses in a domain, e.g.
+// example.com.
+func AccessEmailDomain(domain string) AccessRule {
+	return AccessRule{"email_domain": map[string]string{"domain": domain}}
+}
+
+// AccessIPRange matches requests from an IP range in CIDR notation, e.g.
+// 192.0.2.0/24.
+func AccessIPRange(cidr string) AccessRule {
+	return AccessRule{"ip": map[string]string{"ip": cidr}}
+}
+
+// AccessServiceToken matches requests using a service token.
+func AccessServiceToken(tokenID string) AccessRule {
+	return AccessRule{"service_token": map[string]string{"token_id": tokenID}}
+}
+
+// AccessAnyServiceToken matches requests using any of the account's service
+// tokens.
+func AccessAnyServiceToken() AccessRule {
+	return AccessRule{"any_valid_service_token": struct{}{}}
+}
+
+// AccessGroupRule matches members of an Access group.
+func AccessGroupRule(groupID string) AccessRule {
+	return AccessRule{"group": map[string]string{"id": groupID}}
+}
+
+// AccessAzureADGroup matches members of an Azure AD (Entra ID) group.
+// identityProviderID is the Access identity provider it comes from.
+func AccessAzureADGroup(groupID, identityProviderID string) AccessRule {
+	return AccessRule{"azureAD": map[string]string{
+		"id":                   groupID,
+		"identity_provider_id": identityProviderID,
+	}}
+}
+
+// AccessOktaGroup matches members of an Okta group.
+func AccessOktaGroup(name, identityProviderID string) AccessRule {
+	return AccessRule{"okta": map[string]string{
+		"name":                 name,
+		"identity_provider_id": identityProviderID,
+	}}
+}
+
+// AccessGoogleWorkspaceGroup matches members of a Google Workspace group,
+// identified by its email address.
+func AccessGoogleWorkspaceGroup(email, identityProviderID string) AccessRule {
+	return AccessRule{"gsuite": map[string]string{
+		"email":                email,
+		"identity_provider_id": identityProviderID,
+	}}
+}
+
+// AccessGitHubOrganization matches members of a GitHub organization.
+func AccessGitHubOrganization(name, identityProviderID string) AccessRule {
+	return AccessRule{"github-organization": map[string]string{
+		"name":                 name,
+		"identity_provider_id": identityProviderID,
+	}}
+}
+
+// AccessSAMLAttribute matches users whose SAML assertion has an attribute
+// with a value.
+func AccessSAMLAttribute(name, value, identityProviderID string) AccessRule {
+	return AccessRule{"saml": map[string]string{
+		"attribute_name":       name,
+		"attribute_value":      value,
+		"identity_provider_id": identityProviderID,
+	}}
+}
+
+// Check the rules we build before sending them. Others we pass as is.
+func validateAccessRules(rules []AccessRule) error {
+	for _, rule := range rules {
+		if len(rule) != 1 {
+			return fmt.Errorf("an Access rule must have exactly one type: %v", rule)
+		}
+
+		values, ok := rule[rule.Type()].(map[string]string)
+		if !ok {
+			continue
+		}
+
+		switch rule.Type() {
+		case "email":
+			if !strings.Contains(values["email"], "@") {
+				return fmt.Errorf("invalid email: %q", values["email"])
+			}
+		case "email_domain":
+			if len(values["domain"]) == 0 || strings.Contains(values["domain"], "@") {
+				return fmt.Errorf("invalid email domain: %q", values["domain"])
+			}
+		case "ip":
+			_, _, err := net.ParseCIDR(values["ip"])
+			if err != nil {
+				return fmt.Errorf("invalid IP range: %q: use CIDR notation",
+					values["ip"])
+			}
+		}
+
+		for k, v := range values {
+			if len(v) == 0 {
+				return fmt.Errorf("Access %s rule has no %s", rule.Type(), k)
+			}
+		}
+	}
+
+	return nil
+}
+
+func accessGroupsPath(accountID string) string {
+	return accountPrefix(accountID) + "/access/groups"
+}
+
+// ListAccessGroups lists an account's Access groups.
+func (c Client) ListAccessGroups(accountID string) ([]AccessGroup, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var groups []AccessGroup
+	err = c.apiRequest("GET", accessGroupsPath(accountID), nil, nil, &groups)
+	if err != nil {
+		return nil, fmt.Errorf("list Access groups error: %w", err)
+	}
+
+	return groups, nil
+}
+
+// GetAccessGroup retrieves an Access group.
+func (c Client) GetAccessGroup(accountID, groupID string) (AccessGroup,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return AccessGroup{}, err
+	}
+	if len(accountID) == 0 || len(groupID) == 0 {
+		return AccessGroup{}, fmt.Errorf(
+			"you must provide an account ID and group ID")
+	}
+
+	var group AccessGroup
+	err = c.apiRequest("GET", accessGroupsPath(accountID)+"/"+
+		url.QueryEscape(groupID), nil, nil, &group)
+	if err != nil {
+		return AccessGroup{}, fmt.Errorf("get Access group error: %w", err)
+	}
+
+	return group, nil
+}
+
+// CreateAccessGroup creates an Access group.
+func (c Client) CreateAccessGroup(accountID string,
+	group AccessGroup) (AccessGroup, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return AccessGroup{}, err
+	}
+	if len(accountID) == 0 {
+		return AccessGroup{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	err = validateAccessGroup(group)
+	if err != nil {
+		return AccessGroup{}, err
+	}
+
+	group.ID = ""
+
+	var created AccessGroup
+	err = c.apiRequest("POST", accessGroupsPath(accountID), nil, group,
+		&created)
+	if err != nil {
+		return AccessGroup{}, fmt.Errorf("create Access group error: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateAccessGroup replaces an Access group. Its ID must be set. Policies
+// using the group see the change right away.
+func (c Client) UpdateAccessGroup(accountID string,
+	group AccessGroup) (AccessGroup, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return AccessGroup{}, err
+	}
+	if len(accountID) == 0 || len(group.ID) == 0 {
+		return AccessGroup{}, fmt.Errorf(
+			"you must provide an account ID and group ID")
+	}
+
+	err = validateAccessGroup(group)
+	if err != nil {
+		return AccessGroup{}, err
+	}
+
+	var updated AccessGroup
+	err = c.apiRequest("PUT", accessGroupsPath(accountID)+"/"+
+		url.QueryEscape(group.ID), nil, group, &updated)
+	if err != nil {
+		return AccessGroup{}, fmt.Errorf("update Access group error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteAccessGroup deletes an Access group. Policies must not use it.
+func (c Client) DeleteAccessGroup(accountID, groupID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(groupID) == 0 {
+		return fmt.Errorf("you must provide an account ID and group ID")
+	}
+
+	err = c.apiRequest("DELETE", accessGroupsPath(accountID)+"/"+
+		url.QueryEscape(groupID), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete Access group error: %w", err)
+	}
+
+	return nil
+}
+
+func validateAccessGroup(group AccessGroup) error {
+	if len(group.Name) == 0 {
+		return fmt.Errorf("you must provide a group name")
+	}
+
+	if len(group.Include) == 0 {
+		return fmt.Errorf("an Access group needs at least one include rule")
+	}
+
+	for _, rules := range [][]AccessRule{group.Include, group.Exclude,
+		group.Require} {
+		err := validateAccessRules(rules)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Access application types.
+const (
+	AccessAppSelfHosted  = "self_hosted"
+	AccessAppSaaS        = "saas"
+	AccessAppSSH         = "ssh"
+	AccessAppVNC         = "vnc"
+	AccessAppBookmark    = "bookmark"
+	AccessAppWARP        = "warp"
+	AccessAppAppLauncher = "app_launcher"
+)
+
+// AccessApplication is an application Access protects or, for bookmarks,
+// links to from the App Launcher.
+type AccessApplication struct {
+	ID   string `json:"id,omitempty"`
+	AUD  string `json:"aud,omitempty"`
+	Name string `json:"name"`
+
+	// Type is one of the AccessApp constants.
+	Type string `json:"type"`
+
+	// Domain is the application's hostname and path, e.g.
+	// app.example.com/admin. For bookmarks it is the URL to link to.
+	Domain string `json:"domain,omitempty"`
+
+	// SessionDuration is how long a login lasts, e.g. 24h. Blank for the
+	// default. Bookmarks have no sessions.
+	SessionDuration string `json:"session_duration,omitempty"`
+
+	AppLauncherVisible bool   `json:"app_launcher_visible"`
+	LogoURL            string `json:"logo_url,omitempty"`
+
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// AccessSSHCA is a certificate authority issuing short-lived SSH
+// certificates for an Access application. SSH servers that trust its public
+// key let Access users in without long-lived keys.
+type AccessSSHCA struct {
+	ID        string `json:"id"`
+	AUD       string `json:"aud"`
+	PublicKey string `json:"public_key"`
+}
+
+func accessAppsPath(accountID string) string {
+	return accountPrefix(accountID) + "/access/apps"
+}
+
+// ListAccessApplications lists an account's Access applications.
+func (c Client) ListAccessApplications(accountID string) ([]AccessApplication,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var apps []AccessApplication
+	err = c.apiRequest("GET", accessAppsPath(accountID), nil, nil, &apps)
+	if err != nil {
+		return nil, fmt.Errorf("list Access applications error: %w", err)
+	}
+
+	return apps, nil
+}
+
+// CreateAccessApplication creates an Access application. Name and Type are
+// required. Use AddAccessBookmark for bookmarks.
+func (c Client) CreateAccessApplication(accountID string,
+	app AccessApplication) (AccessApplication, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return AccessApplication{}, err
+	}
+	if len(accountID) == 0 {
+		return AccessApplication{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	if len(app.Name) == 0 || len(app.Type) == 0 {
+		return AccessApplication{}, fmt.Errorf(
+			"an Access application needs a name and type")
+	}
+
+	app.ID = ""
+	app.AUD = ""
+
+	var created AccessApplication
+	err = c.apiRequest("POST", accessAppsPath(accountID), nil, app, &created)
+	if err != nil {
+		return AccessApplication{}, fmt.Errorf(
+			"create Access application error: %w", err)
+	}
+
+	return created, nil
+}
+
+// AddAccessBookmark adds a bookmark application: a link to a URL shown in
+// the App Launcher. Access does not protect the URL.
+func (c Client) AddAccessBookmark(accountID, name,
+	bookmarkURL string) (AccessApplication, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return AccessApplication{}, err
+	}
+	if !strings.HasPrefix(bookmarkURL, "http://") &&
+		!strings.HasPrefix(bookmarkURL, "https://") {
+		return AccessApplication{}, fmt.Errorf(
+			"a bookmark needs an http or https URL, not %q", bookmarkURL)
+	}
+
+	return c.CreateAccessApplication(accountID, AccessApplication{
+		Name:               name,
+		Type:               AccessAppBookmark,
+		Domain:             bookmarkURL,
+		AppLauncherVisible: true,
+	})
+}
+
+// DeleteAccessApplication deletes an Access application.
+func (c Client) DeleteAccessApplication(accountID, appID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(appID) == 0 {
+		return fmt.Errorf("you must provide an account ID and application ID")
+	}
+
+	err = c.apiRequest("DELETE", accessAppsPath(accountID)+"/"+
+		url.QueryEscape(appID), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete Access application error: %w", err)
+	}
+
+	return nil
+}
+
+// ListAccessSSHCAs lists the short-lived certificate CAs of an account's
+// Access applications.
+func (c Client) ListAccessSSHCAs(accountID string) ([]AccessSSHCA, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var cas []AccessSSHCA
+	err = c.apiRequest("GET", accessAppsPath(accountID)+"/ca", nil, nil, &cas)
+	if err != nil {
+		return nil, fmt.Errorf("list Access SSH CAs error: %w", err)
+	}
+
+	return cas, nil
+}
+
+// GetAccessSSHCA retrieves the short-lived certificate CA of an Access
+// application.
+func (c Client) GetAccessSSHCA(accountID, appID string) (AccessSSHCA, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return AccessSSHCA{}, err
+	}
+	return c.accessSSHCARequest("GET", accountID, appID, "get")
+}
+
+// CreateAccessSSHCA creates a short-lived certificate CA for an Access
+// application, such as one of type ssh. Add its public key to the SSH
+// server's TrustedUserCAKeys.
+func (c Client) CreateAccessSSHCA(accountID, appID string) (AccessSSHCA,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return AccessSSHCA{}, err
+	}
+	return c.accessSSHCARequest("POST", accountID, appID, "create")
+}
+
+// DeleteAccessSSHCA deletes the short-lived certificate CA of an Access
+// application. Certificates it issued stop working once they expire.
+func (c Client) DeleteAccessSSHCA(accountID, appID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	_, err = c.accessSSHCARequest("DELETE", accountID, appID, "delete")
+	return err
+}
+
+func (c Client) accessSSHCARequest(method, accountID, appID,
+	operation string) (AccessSSHCA, error) {
+	if len(accountID) == 0 || len(appID) == 0 {
+		return AccessSSHCA{}, fmt.Errorf(
+			"you must provide an account ID and application ID")
+	}
+
+	var ca AccessSSHCA
+	err := c.apiRequest(method, accessAppsPath(accountID)+"/"+
+		url.QueryEscape(appID)+"/ca", nil, nil, &ca)
+	if err != nil {
+		return AccessSSHCA{}, fmt.Errorf("%s Access SSH CA error: %w", operation,
+			err)
+	}
+
+	return ca, nil
+}
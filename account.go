@@ -0,0 +1,103 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+)
+
+// Account is a Cloudflare account.
+type Account struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+
+	// Type is standard or enterprise.
+	Type      string `json:"type"`
+	CreatedOn string `json:"created_on"`
+}
+
+// WithAccount sets the account to use with account level endpoints when
+// given a blank account ID. See Client.Account and DefaultAccountID.
+func WithAccount(accountID string) Option {
+	return func(c *Client) error {
+		if len(accountID) == 0 {
+			return fmt.Errorf("account ID may not be blank")
+		}
+		c.accountID = accountID
+		return nil
+	}
+}
+
+// AccountID returns the client's account, as set by WithAccount. It is
+// blank if there is none.
+func (c Client) AccountID() string {
+	return c.accountID
+}
+
+// ListAccounts retrieves the accounts the credentials may use.
+func (c Client) ListAccounts() ([]Account, error) {
+	var accounts []Account
+	var err error
+	paginate(context.Background(), c, "accounts", nil, 50, "list accounts",
+		func(account Account, e error) bool {
+			if e != nil {
+				err = e
+				return false
+			}
+			accounts = append(accounts, account)
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// DefaultAccountID returns the account to use: the client's account if it
+// has one (see WithAccount), or otherwise the only account the credentials
+// may use. It is an error if they may use several, as we can't choose.
+//
+// We remember the account we find as for ZoneIDByName.
+func (c Client) DefaultAccountID() (string, error) {
+	if len(c.accountID) > 0 {
+		return c.accountID, nil
+	}
+
+	const key = "account:default"
+
+	if id, ok := c.cache.get(key); ok {
+		return id.(string), nil
+	}
+
+	accounts, err := c.ListAccounts()
+	if err != nil {
+		return "", err
+	}
+
+	if len(accounts) != 1 {
+		return "", fmt.Errorf(
+			"the credentials may use %d accounts. Choose one with WithAccount",
+			len(accounts))
+	}
+
+	c.cache.set(key, accounts[0].ID)
+
+	return accounts[0].ID, nil
+}
+
+// account returns accountID, or if it is blank the account DefaultAccountID
+// gives. Methods taking an account ID call this first, so callers may leave
+// it blank. If there is no default we say why.
+func (c Client) account(accountID string) (string, error) {
+	if len(accountID) > 0 {
+		return accountID, nil
+	}
+
+	id, err := c.DefaultAccountID()
+	if err != nil {
+		return "", fmt.Errorf(
+			"no account ID given and unable to determine the default: %w", err)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,112 @@
+package cloudflare
+
+// AccountHandle makes requests about a single account. Get one with
+// Client.Account.
+//
+// It saves passing the account ID to every call:
+//
+//	a := client.Account(accountID)
+//	buckets, err := a.ListR2Buckets()
+//
+// It covers R2, Workers versions, deployments, and cron triggers, Gateway,
+// Access, and rulesets. For the rest of the account level endpoints call the
+// Client methods directly. They use the client's account when given a blank
+// account ID.
+type AccountHandle struct {
+	client Client
+	id     string
+}
+
+// Account returns a handle for making requests about the account with the
+// given ID. If accountID is blank we use the client's account (see
+// WithAccount). It makes no request itself.
+//
+// This also switches accounts for a single call:
+//
+//	groups, err := client.Account(otherAccountID).ListAccessGroups()
+func (c Client) Account(accountID string) AccountHandle {
+	if len(accountID) == 0 {
+		accountID = c.accountID
+	}
+	return AccountHandle{client: c, id: accountID}
+}
+
+// ID returns the account's ID.
+func (a AccountHandle) ID() string {
+	return a.id
+}
+
+// ListR2Buckets lists the account's R2 buckets.
+func (a AccountHandle) ListR2Buckets() ([]R2Bucket, error) {
+	return a.client.ListR2Buckets(a.id)
+}
+
+// CreateR2Bucket creates an R2 bucket in the account.
+func (a AccountHandle) CreateR2Bucket(name string) (R2Bucket, error) {
+	return a.client.CreateR2Bucket(a.id, name)
+}
+
+// DeleteR2Bucket deletes one of the account's R2 buckets.
+func (a AccountHandle) DeleteR2Bucket(name string) error {
+	return a.client.DeleteR2Bucket(a.id, name)
+}
+
+// ListWorkerVersions lists the versions of one of the account's Workers.
+func (a AccountHandle) ListWorkerVersions(script string) ([]WorkerVersion,
+	error) {
+	return a.client.ListWorkerVersions(a.id, script)
+}
+
+// UploadWorkerVersion uploads a new version of one of the account's
+// Workers. See Client.UploadWorkerVersion.
+func (a AccountHandle) UploadWorkerVersion(script string,
+	upload WorkerVersionUpload) (WorkerVersion, error) {
+	return a.client.UploadWorkerVersion(a.id, script, upload)
+}
+
+// ListWorkerDeployments lists the deployments of one of the account's
+// Workers.
+func (a AccountHandle) ListWorkerDeployments(
+	script string) ([]WorkerDeployment, error) {
+	return a.client.ListWorkerDeployments(a.id, script)
+}
+
+// RollbackWorker deploys an earlier version of one of the account's
+// Workers. See Client.RollbackWorker.
+func (a AccountHandle) RollbackWorker(script, versionID,
+	message string) (WorkerDeployment, error) {
+	return a.client.RollbackWorker(a.id, script, versionID, message)
+}
+
+// GetWorkerCronTriggers retrieves the schedules one of the account's
+// Workers runs on.
+func (a AccountHandle) GetWorkerCronTriggers(
+	script string) ([]WorkerCronTrigger, error) {
+	return a.client.GetWorkerCronTriggers(a.id, script)
+}
+
+// ListGatewayLists lists the account's Gateway lists.
+func (a AccountHandle) ListGatewayLists() ([]GatewayList, error) {
+	return a.client.ListGatewayLists(a.id)
+}
+
+// ListGatewayRules lists the account's Gateway rules.
+func (a AccountHandle) ListGatewayRules() ([]GatewayRule, error) {
+	return a.client.ListGatewayRules(a.id)
+}
+
+// ListAccessGroups lists the account's Access groups.
+func (a AccountHandle) ListAccessGroups() ([]AccessGroup, error) {
+	return a.client.ListAccessGroups(a.id)
+}
+
+// ListAccessApplications lists the account's Access applications.
+func (a AccountHandle) ListAccessApplications() ([]AccessApplication,
+	error) {
+	return a.client.ListAccessApplications(a.id)
+}
+
+// ListRulesets lists the account's rulesets.
+func (a AccountHandle) ListRulesets() ([]Ruleset, error) {
+	return a.client.ListAccountRulesets(a.id)
+}
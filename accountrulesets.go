@@ -0,0 +1,283 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// PhaseHTTPRequestFirewallCustom is the phase holding WAF custom rules.
+const PhaseHTTPRequestFirewallCustom = "http_request_firewall_custom"
+
+// Ruleset kinds.
+const (
+	RulesetKindCustom  = "custom"
+	RulesetKindRoot    = "root"
+	RulesetKindZone    = "zone"
+	RulesetKindManaged = "managed"
+)
+
+// ListAccountRulesets lists an account's rulesets. The rulesets do not
+// include their rules. Use GetAccountRuleset for those.
+func (c Client) ListAccountRulesets(accountID string) ([]Ruleset, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var rulesets []Ruleset
+	err = c.apiRequest("GET", accountPrefix(accountID)+"/rulesets", nil, nil,
+		&rulesets)
+	if err != nil {
+		return nil, fmt.Errorf("list account rulesets error: %w", err)
+	}
+
+	return rulesets, nil
+}
+
+// GetAccountRuleset retrieves an account ruleset along with its rules.
+func (c Client) GetAccountRuleset(accountID, rulesetID string) (Ruleset,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return Ruleset{}, err
+	}
+	if len(accountID) == 0 || len(rulesetID) == 0 {
+		return Ruleset{}, fmt.Errorf("you must provide an account ID and ruleset ID")
+	}
+
+	var ruleset Ruleset
+	err = c.apiRequest("GET", accountPrefix(accountID)+"/rulesets/"+
+		url.QueryEscape(rulesetID), nil, nil, &ruleset)
+	if err != nil {
+		return Ruleset{}, fmt.Errorf("get account ruleset error: %w", err)
+	}
+
+	return ruleset, nil
+}
+
+// CreateAccountRuleset creates a ruleset in an account.
+//
+// Name and Phase are required. Kind defaults to custom, which is what you
+// want for rules to deploy to zones with DeployAccountRuleset.
+func (c Client) CreateAccountRuleset(accountID string,
+	ruleset Ruleset) (Ruleset, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return Ruleset{}, err
+	}
+	if len(accountID) == 0 {
+		return Ruleset{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	if len(ruleset.Name) == 0 || len(ruleset.Phase) == 0 {
+		return Ruleset{}, fmt.Errorf("you must provide a ruleset name and phase")
+	}
+
+	for _, rule := range ruleset.Rules {
+		err := validateRule(rule)
+		if err != nil {
+			return Ruleset{}, err
+		}
+	}
+
+	if len(ruleset.Kind) == 0 {
+		ruleset.Kind = RulesetKindCustom
+	}
+	if ruleset.Rules == nil {
+		ruleset.Rules = []RulesetRule{}
+	}
+
+	var created Ruleset
+	err = c.apiRequest("POST", accountPrefix(accountID)+"/rulesets", nil,
+		ruleset, &created)
+	if err != nil {
+		return Ruleset{}, fmt.Errorf("create account ruleset error: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateAccountRuleset replaces the rules and description of an account
+// ruleset. Zones the ruleset is deployed to pick up the change right away.
+func (c Client) UpdateAccountRuleset(accountID, rulesetID, description string,
+	rules []RulesetRule) (Ruleset, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return Ruleset{}, err
+	}
+	if len(accountID) == 0 || len(rulesetID) == 0 {
+		return Ruleset{}, fmt.Errorf("you must provide an account ID and ruleset ID")
+	}
+
+	for _, rule := range rules {
+		err := validateRule(rule)
+		if err != nil {
+			return Ruleset{}, err
+		}
+	}
+
+	if rules == nil {
+		rules = []RulesetRule{}
+	}
+
+	payload := Ruleset{Description: description, Rules: rules}
+
+	var updated Ruleset
+	err = c.apiRequest("PUT", accountPrefix(accountID)+"/rulesets/"+
+		url.QueryEscape(rulesetID), nil, payload, &updated)
+	if err != nil {
+		return Ruleset{}, fmt.Errorf("update account ruleset error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteAccountRuleset deletes an account ruleset. It must not be deployed.
+// Use UndeployAccountRuleset first if it is.
+func (c Client) DeleteAccountRuleset(accountID, rulesetID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(rulesetID) == 0 {
+		return fmt.Errorf("you must provide an account ID and ruleset ID")
+	}
+
+	err = c.apiRequest("DELETE", accountPrefix(accountID)+"/rulesets/"+
+		url.QueryEscape(rulesetID), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete account ruleset error: %w", err)
+	}
+
+	return nil
+}
+
+// GetAccountEntrypointRuleset retrieves the account's entry point ruleset
+// for a phase.
+func (c Client) GetAccountEntrypointRuleset(accountID, phase string) (Ruleset,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return Ruleset{}, err
+	}
+	if len(accountID) == 0 {
+		return Ruleset{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	return c.getEntrypointRuleset(accountPrefix(accountID), phase)
+}
+
+// UpdateAccountEntrypointRuleset replaces all rules in the account's entry
+// point ruleset for a phase, creating the ruleset if necessary.
+func (c Client) UpdateAccountEntrypointRuleset(accountID, phase string,
+	rules []RulesetRule) (Ruleset, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return Ruleset{}, err
+	}
+	if len(accountID) == 0 {
+		return Ruleset{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	return c.updateEntrypointRuleset(accountPrefix(accountID), phase, rules)
+}
+
+// DeployAccountRuleset deploys an account's custom ruleset to zones. It
+// adds a rule executing the ruleset to the account's
+// http_request_firewall_custom entry point.
+//
+// zones are the names of the zones to deploy to. If there are none we deploy
+// to every zone in the account. Only Enterprise zones run account rulesets,
+// so the rule always limits itself to them.
+//
+// If the ruleset is already deployed we change which zones it applies to
+// rather than deploying it twice. We return the entry point as updated.
+func (c Client) DeployAccountRuleset(accountID, rulesetID string,
+	zones []string) (Ruleset, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return Ruleset{}, err
+	}
+	if len(accountID) == 0 || len(rulesetID) == 0 {
+		return Ruleset{}, fmt.Errorf("you must provide an account ID and ruleset ID")
+	}
+
+	expression := ExprEquals(FieldZonePlan, "ENT")
+	if len(zones) > 0 {
+		expression = ExprAnd(ExprIn(FieldZoneName, zones...), expression)
+	}
+
+	rule := RulesetRule{
+		Action:           "execute",
+		ActionParameters: &RulesetActionParameters{ID: rulesetID},
+		Expression:       expression,
+		Description:      "Deploy ruleset " + rulesetID,
+		Enabled:          true,
+	}
+
+	prefix := accountPrefix(accountID)
+
+	entrypoint, err := c.getEntrypointRuleset(prefix,
+		PhaseHTTPRequestFirewallCustom)
+	if err != nil {
+		if !IsNotFound(err) {
+			return Ruleset{}, err
+		}
+		return c.updateEntrypointRuleset(prefix, PhaseHTTPRequestFirewallCustom,
+			[]RulesetRule{rule})
+	}
+
+	existing, ok := findExecuteRule(entrypoint, rulesetID)
+	if !ok {
+		return c.addPhaseRule(prefix, PhaseHTTPRequestFirewallCustom, rule)
+	}
+
+	rule.ID = existing.ID
+	return c.updateRulesetRule(prefix, entrypoint.ID, rule)
+}
+
+// UndeployAccountRuleset removes the rule DeployAccountRuleset added. It is
+// not an error if the ruleset is not deployed.
+func (c Client) UndeployAccountRuleset(accountID, rulesetID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(rulesetID) == 0 {
+		return fmt.Errorf("you must provide an account ID and ruleset ID")
+	}
+
+	prefix := accountPrefix(accountID)
+
+	entrypoint, err := c.getEntrypointRuleset(prefix,
+		PhaseHTTPRequestFirewallCustom)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	existing, ok := findExecuteRule(entrypoint, rulesetID)
+	if !ok {
+		return nil
+	}
+
+	return c.deleteRulesetRule(prefix, entrypoint.ID, existing.ID)
+}
+
+// Find the rule in an entry point that executes a ruleset.
+func findExecuteRule(entrypoint Ruleset, rulesetID string) (RulesetRule,
+	bool) {
+	for _, rule := range entrypoint.Rules {
+		if rule.Action == "execute" && rule.ActionParameters != nil &&
+			rule.ActionParameters.ID == rulesetID {
+			return rule, true
+		}
+	}
+
+	return RulesetRule{}, false
+}
@@ -0,0 +1,237 @@
+// Package acme solves ACME DNS-01 challenges using Cloudflare DNS.
+//
+// Provider has the Present and CleanUp methods certificate tools such as lego
+// expect of a DNS provider:
+//
+//	provider := acme.NewProvider(client)
+//	err := provider.Present("example.com", token, keyAuth)
+//	...
+//	err = provider.CleanUp("example.com", token, keyAuth)
+//
+// Present creates the _acme-challenge TXT record and waits until the zone's
+// nameservers serve it.
+package acme
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/horgh/cloudflare"
+	"github.com/miekg/dns"
+)
+
+// Defaults for Provider's settings.
+const (
+	DefaultTTL                = 120
+	DefaultPropagationTimeout = 2 * time.Minute
+	DefaultPollingInterval    = 2 * time.Second
+)
+
+// Provider creates and removes challenge records.
+type Provider struct {
+	// TTL is the TTL of the records we create.
+	TTL int
+
+	// PropagationTimeout is how long Present waits for the record to be served.
+	// 0 means not to wait.
+	PropagationTimeout time.Duration
+
+	// PollingInterval is how often Present checks whether the record is
+	// served.
+	PollingInterval time.Duration
+
+	client cloudflare.Client
+
+	mutex sync.Mutex
+	// records holds the records we created so we can remove them. The key is
+	// the record's name and content.
+	records map[string]cloudflare.DNSRecord
+}
+
+// NewProvider creates a Provider using the client.
+func NewProvider(client cloudflare.Client) *Provider {
+	return &Provider{
+		TTL:                DefaultTTL,
+		PropagationTimeout: DefaultPropagationTimeout,
+		PollingInterval:    DefaultPollingInterval,
+		client:             client,
+		records:            map[string]cloudflare.DNSRecord{},
+	}
+}
+
+// Present creates the TXT record for the challenge and waits for the zone's
+// nameservers to serve it.
+func (p *Provider) Present(domain, token, keyAuth string) error {
+	name, value := ChallengeRecord(domain, keyAuth)
+
+	zoneName, zoneID, err := p.findZone(name)
+	if err != nil {
+		return err
+	}
+
+	record, err := p.client.CreateDNSRecord(cloudflare.DNSRecord{
+		ZoneID:  zoneID,
+		Type:    cloudflare.RecordTypeTXT,
+		Name:    name,
+		Content: value,
+		TTL:     p.TTL,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create challenge record: %w", err)
+	}
+
+	p.mutex.Lock()
+	p.records[name+" "+value] = record
+	p.mutex.Unlock()
+
+	if p.PropagationTimeout <= 0 {
+		return nil
+	}
+
+	return waitForRecord(zoneName, name, value, p.PropagationTimeout,
+		p.PollingInterval)
+}
+
+// CleanUp removes the TXT record for the challenge.
+//
+// If we did not create the record (say, Present ran in another process) we
+// find it by its name and content.
+func (p *Provider) CleanUp(domain, token, keyAuth string) error {
+	name, value := ChallengeRecord(domain, keyAuth)
+
+	p.mutex.Lock()
+	record, ok := p.records[name+" "+value]
+	delete(p.records, name+" "+value)
+	p.mutex.Unlock()
+
+	if ok {
+		err := p.client.DeleteDNSRecord(record)
+		if err != nil {
+			return fmt.Errorf("unable to delete challenge record: %w", err)
+		}
+		return nil
+	}
+
+	_, zoneID, err := p.findZone(name)
+	if err != nil {
+		return err
+	}
+
+	records, err := p.client.ListAllDNSRecords(zoneID, cloudflare.RecordTypeTXT,
+		name)
+	if err != nil {
+		return fmt.Errorf("unable to list DNS records: %w", err)
+	}
+
+	for _, record := range records {
+		if record.Name != name || record.Content != value {
+			continue
+		}
+		err := p.client.DeleteDNSRecord(record)
+		if err != nil {
+			return fmt.Errorf("unable to delete challenge record: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Timeout reports how long to wait for propagation and how often to check.
+// lego calls this to decide how long to wait itself.
+func (p *Provider) Timeout() (time.Duration, time.Duration) {
+	return p.PropagationTimeout, p.PollingInterval
+}
+
+// ChallengeRecord returns the name and content of the TXT record for a
+// challenge. The content is the base64url encoded SHA-256 digest of the key
+// authorization (RFC 8555 section 8.4).
+func ChallengeRecord(domain, keyAuth string) (string, string) {
+	domain = strings.TrimPrefix(strings.TrimSuffix(domain, "."), "*.")
+	digest := sha256.Sum256([]byte(keyAuth))
+	return "_acme-challenge." + domain,
+		base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+// Find the zone the name is in by trying each of its parent domains. We
+// return the zone's name and ID.
+func (p *Provider) findZone(name string) (string, string, error) {
+	labels := strings.Split(name, ".")
+
+	// A zone has at least two labels.
+	for i := 1; i < len(labels)-1; i++ {
+		zoneName := strings.Join(labels[i:], ".")
+		zoneID, err := p.client.ZoneIDByName(zoneName)
+		if err == nil {
+			return zoneName, zoneID, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("no zone found for %s", name)
+}
+
+// Wait until each of the zone's nameservers serves the TXT record with the
+// value.
+func waitForRecord(zoneName, name, value string, timeout,
+	interval time.Duration) error {
+	nameservers, err := net.LookupNS(zoneName)
+	if err != nil {
+		return fmt.Errorf("unable to look up nameservers: %w", err)
+	}
+	if len(nameservers) == 0 {
+		return fmt.Errorf("no nameservers found for %s", zoneName)
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		served := true
+		for _, ns := range nameservers {
+			found, err := hasTXT(ns.Host, name, value)
+			if err != nil || !found {
+				served = false
+				break
+			}
+		}
+
+		if served {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf(
+				"challenge record not served by all nameservers after %s", timeout)
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+// Ask the nameserver whether it serves the TXT record with the value.
+func hasTXT(nameserver, name, value string) (bool, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(name), dns.TypeTXT)
+	msg.RecursionDesired = false
+
+	in, err := dns.Exchange(msg, net.JoinHostPort(
+		strings.TrimSuffix(nameserver, "."), "53"))
+	if err != nil {
+		return false, fmt.Errorf("unable to perform lookup: %w", err)
+	}
+
+	for _, record := range in.Answer {
+		txt, ok := record.(*dns.TXT)
+		if !ok {
+			continue
+		}
+		if strings.Join(txt.Txt, "") == value {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
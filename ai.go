@@ -0,0 +1,224 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// AIGateway is an AI Gateway. It sits in front of AI providers (such as
+// Workers AI or OpenAI) to log, cache, and rate limit requests to them.
+type AIGateway struct {
+	// ID is the gateway's name, used in its URL.
+	ID string `json:"id"`
+
+	CollectLogs bool `json:"collect_logs"`
+
+	// CacheTTL is how many seconds to cache responses. Zero to not cache.
+	CacheTTL                int  `json:"cache_ttl"`
+	CacheInvalidateOnUpdate bool `json:"cache_invalidate_on_update"`
+
+	// RateLimitingLimit requests are allowed per RateLimitingInterval
+	// seconds. Zero for no limit. RateLimitingTechnique is fixed or
+	// sliding.
+	RateLimitingInterval  int    `json:"rate_limiting_interval"`
+	RateLimitingLimit     int    `json:"rate_limiting_limit"`
+	RateLimitingTechnique string `json:"rate_limiting_technique"`
+
+	CreatedAt  string `json:"created_at,omitempty"`
+	ModifiedAt string `json:"modified_at,omitempty"`
+}
+
+// AIGatewayLog is a request made through an AI Gateway.
+type AIGatewayLog struct {
+	ID         string  `json:"id"`
+	CreatedAt  string  `json:"created_at"`
+	Provider   string  `json:"provider"`
+	Model      string  `json:"model"`
+	Path       string  `json:"path"`
+	Success    bool    `json:"success"`
+	Cached     bool    `json:"cached"`
+	StatusCode int     `json:"status_code"`
+	TokensIn   int     `json:"tokens_in"`
+	TokensOut  int     `json:"tokens_out"`
+	Duration   int     `json:"duration"`
+	Cost       float64 `json:"cost"`
+}
+
+// AIGatewayLogOptions filters the logs ListAIGatewayLogs retrieves.
+type AIGatewayLogOptions struct {
+	// Search matches text in requests and responses. Blank for all.
+	Search string
+
+	// Success and Cached may be nil to not filter on them.
+	Success *bool
+	Cached  *bool
+
+	// Limit is the most logs to return. Zero means 100.
+	Limit int
+}
+
+func aiGatewayPath(accountID string) string {
+	return accountPrefix(accountID) + "/ai-gateway/gateways"
+}
+
+// ListAIGateways lists an account's AI Gateways.
+func (c Client) ListAIGateways(accountID string) ([]AIGateway, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var gateways []AIGateway
+	paginate(context.Background(), c, aiGatewayPath(accountID), nil, 50,
+		"list AI Gateways", func(gateway AIGateway, e error) bool {
+			if e != nil {
+				err = e
+				return false
+			}
+			gateways = append(gateways, gateway)
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return gateways, nil
+}
+
+// CreateAIGateway creates an AI Gateway. Its ID is required.
+func (c Client) CreateAIGateway(accountID string,
+	gateway AIGateway) (AIGateway, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return AIGateway{}, err
+	}
+	if len(accountID) == 0 || len(gateway.ID) == 0 {
+		return AIGateway{}, fmt.Errorf(
+			"you must provide an account ID and gateway ID")
+	}
+
+	if len(gateway.RateLimitingTechnique) == 0 {
+		gateway.RateLimitingTechnique = "fixed"
+	}
+	gateway.CreatedAt = ""
+	gateway.ModifiedAt = ""
+
+	var created AIGateway
+	err = c.apiRequest("POST", aiGatewayPath(accountID), nil, gateway,
+		&created)
+	if err != nil {
+		return AIGateway{}, fmt.Errorf("create AI Gateway error: %w", err)
+	}
+
+	return created, nil
+}
+
+// DeleteAIGateway deletes an AI Gateway and its logs.
+func (c Client) DeleteAIGateway(accountID, gatewayID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(gatewayID) == 0 {
+		return fmt.Errorf("you must provide an account ID and gateway ID")
+	}
+
+	err = c.apiRequest("DELETE", aiGatewayPath(accountID)+"/"+
+		url.QueryEscape(gatewayID), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete AI Gateway error: %w", err)
+	}
+
+	return nil
+}
+
+// ListAIGatewayLogs retrieves the requests made through an AI Gateway,
+// newest first. The gateway must collect logs.
+func (c Client) ListAIGatewayLogs(accountID, gatewayID string,
+	opts AIGatewayLogOptions) ([]AIGatewayLog, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 || len(gatewayID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID and gateway ID")
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	values := url.Values{}
+	values.Set("order_by", "created_at")
+	values.Set("order_by_direction", "desc")
+	if len(opts.Search) > 0 {
+		values.Set("search", opts.Search)
+	}
+	if opts.Success != nil {
+		values.Set("success", strconv.FormatBool(*opts.Success))
+	}
+	if opts.Cached != nil {
+		values.Set("cached", strconv.FormatBool(*opts.Cached))
+	}
+
+	var logs []AIGatewayLog
+	paginate(context.Background(), c, aiGatewayPath(accountID)+"/"+
+		url.QueryEscape(gatewayID)+"/logs", values, min(limit, 50),
+		"list AI Gateway logs", func(log AIGatewayLog, e error) bool {
+			if e != nil {
+				err = e
+				return false
+			}
+			logs = append(logs, log)
+			return len(logs) < limit
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// RunAIModel runs a Workers AI model, such as @cf/meta/llama-3.1-8b-instruct.
+//
+// input is the model's input, encoded to JSON, e.g.
+// map[string]string{"prompt": "Hello"}. Each model documents its own input
+// and output. We decode the output into result.
+//
+// This is a thin wrapper. It does not support streaming output.
+func (c Client) RunAIModel(accountID, model string, input,
+	result interface{}) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(model) == 0 {
+		return fmt.Errorf("you must provide an account ID and model")
+	}
+
+	if !strings.HasPrefix(model, "@") {
+		return fmt.Errorf("invalid model: %s: models look like @cf/<author>/<name>",
+			model)
+	}
+
+	// The model name's slashes are part of the path.
+	var pieces []string
+	for _, piece := range strings.Split(model, "/") {
+		pieces = append(pieces, url.PathEscape(piece))
+	}
+
+	err = c.apiRequest("POST", accountPrefix(accountID)+"/ai/run/"+
+		strings.Join(pieces, "/"), nil, input, result)
+	if err != nil {
+		return fmt.Errorf("run AI model error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package cloudflare
+
+import (
+	"fmt"
+	"time"
+)
+
+// Dimensions for ZoneHTTPAnalytics(). They decide the size of each bucket.
+const (
+	// AnalyticsHourly gives a bucket per hour.
+	AnalyticsHourly = "datetime"
+
+	// AnalyticsDaily gives a bucket per day.
+	AnalyticsDaily = "date"
+)
+
+// HTTPAnalyticsBucket holds a zone's HTTP traffic for a period of time.
+type HTTPAnalyticsBucket struct {
+	// Start is when the bucket starts.
+	Start time.Time `json:"start"`
+
+	Requests       int64 `json:"requests"`
+	CachedRequests int64 `json:"cached_requests"`
+	Bytes          int64 `json:"bytes"`
+	CachedBytes    int64 `json:"cached_bytes"`
+	Threats        int64 `json:"threats"`
+	PageViews      int64 `json:"page_views"`
+	Uniques        int64 `json:"uniques"`
+}
+
+// ZoneHTTPAnalytics retrieves a zone's HTTP traffic between since and until.
+//
+// dimension is AnalyticsHourly or AnalyticsDaily. Note the API limits how far
+// back you can query depending on your plan.
+//
+// We return the buckets in order of time.
+func (c Client) ZoneHTTPAnalytics(zoneID string, since, until time.Time,
+	dimension string) ([]HTTPAnalyticsBucket, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	if !since.Before(until) {
+		return nil, fmt.Errorf("since must be before until")
+	}
+
+	var dataset, timeType, filter, sinceValue, untilValue string
+	switch dimension {
+	case AnalyticsHourly:
+		dataset = "httpRequests1hGroups"
+		timeType = "Time"
+		filter = "{datetime_geq: $since, datetime_lt: $until}"
+		sinceValue = since.UTC().Format(time.RFC3339)
+		untilValue = until.UTC().Format(time.RFC3339)
+	case AnalyticsDaily:
+		dataset = "httpRequests1dGroups"
+		timeType = "Date"
+		filter = "{date_geq: $since, date_lt: $until}"
+		sinceValue = since.UTC().Format("2006-01-02")
+		untilValue = until.UTC().Format("2006-01-02")
+	default:
+		return nil, fmt.Errorf("invalid dimension: %s", dimension)
+	}
+
+	query := fmt.Sprintf(`query($zoneTag: string, $since: %s, $until: %s) {
+  viewer {
+    zones(filter: {zoneTag: $zoneTag}) {
+      groups: %s(limit: 10000, filter: %s, orderBy: [%s_ASC]) {
+        dimensions { %s }
+        sum { requests cachedRequests bytes cachedBytes threats pageViews }
+        uniq { uniques }
+      }
+    }
+  }
+}`, timeType, timeType, dataset, filter, dimension, dimension)
+
+	variables := map[string]interface{}{
+		"zoneTag": zoneID,
+		"since":   sinceValue,
+		"until":   untilValue,
+	}
+
+	var result struct {
+		Viewer struct {
+			Zones []struct {
+				Groups []struct {
+					Dimensions map[string]string `json:"dimensions"`
+					Sum        struct {
+						Requests       int64 `json:"requests"`
+						CachedRequests int64 `json:"cachedRequests"`
+						Bytes          int64 `json:"bytes"`
+						CachedBytes    int64 `json:"cachedBytes"`
+						Threats        int64 `json:"threats"`
+						PageViews      int64 `json:"pageViews"`
+					} `json:"sum"`
+					Uniq struct {
+						Uniques int64 `json:"uniques"`
+					} `json:"uniq"`
+				} `json:"groups"`
+			} `json:"zones"`
+		} `json:"viewer"`
+	}
+
+	err := c.graphqlRequest(query, variables, &result)
+	if err != nil {
+		return nil, fmt.Errorf("zone HTTP analytics error: %w", err)
+	}
+
+	if len(result.Viewer.Zones) == 0 {
+		return nil, fmt.Errorf("zone not found: %s", zoneID)
+	}
+
+	var buckets []HTTPAnalyticsBucket
+	for _, group := range result.Viewer.Zones[0].Groups {
+		layout := time.RFC3339
+		if dimension == AnalyticsDaily {
+			layout = "2006-01-02"
+		}
+
+		start, err := time.Parse(layout, group.Dimensions[dimension])
+		if err != nil {
+			return nil, fmt.Errorf("invalid time: %s: %w",
+				group.Dimensions[dimension], err)
+		}
+
+		buckets = append(buckets, HTTPAnalyticsBucket{
+			Start:          start,
+			Requests:       group.Sum.Requests,
+			CachedRequests: group.Sum.CachedRequests,
+			Bytes:          group.Sum.Bytes,
+			CachedBytes:    group.Sum.CachedBytes,
+			Threats:        group.Sum.Threats,
+			PageViews:      group.Sum.PageViews,
+			Uniques:        group.Uniq.Uniques,
+		})
+	}
+
+	return buckets, nil
+}
@@ -0,0 +1,236 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Authenticated Origin Pulls (AOP) has Cloudflare present a client
+// certificate when connecting to the origin, so the origin can refuse
+// connections that don't come through Cloudflare.
+//
+// It can use one certificate for the whole zone, or certificates per
+// hostname.
+
+// OriginPullCertificate holds a client certificate Cloudflare presents to the
+// origin.
+type OriginPullCertificate struct {
+	ID           string `json:"id"`
+	Certificate  string `json:"certificate"`
+	Issuer       string `json:"issuer"`
+	Signature    string `json:"signature"`
+	SerialNumber string `json:"serial_number,omitempty"`
+	Status       string `json:"status"`
+	UploadedOn   string `json:"uploaded_on"`
+	ExpiresOn    string `json:"expires_on"`
+}
+
+// OriginPullHostname associates a hostname with a client certificate.
+type OriginPullHostname struct {
+	Hostname string `json:"hostname"`
+	CertID   string `json:"cert_id"`
+
+	// Enabled is a pointer as the API treats absent as leaving it unchanged.
+	// Use nil to do that.
+	Enabled *bool `json:"enabled"`
+
+	Status string `json:"status,omitempty"`
+}
+
+// ExpiresTime parses the certificate's ExpiresOn time.
+func (c OriginPullCertificate) ExpiresTime() (time.Time, error) {
+	return parseTime(c.ExpiresOn)
+}
+
+func aopPath(zoneID string) string {
+	return zonePrefix(zoneID) + "/origin_tls_client_auth"
+}
+
+// GetOriginPullEnabled retrieves whether zone-wide Authenticated Origin Pulls
+// is on.
+func (c Client) GetOriginPullEnabled(zoneID string) (bool, error) {
+	if len(zoneID) == 0 {
+		return false, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var settings struct {
+		Enabled bool `json:"enabled"`
+	}
+	err := c.apiRequest("GET", aopPath(zoneID)+"/settings", nil, nil,
+		&settings)
+	if err != nil {
+		return false, fmt.Errorf("get origin pull settings error: %w", err)
+	}
+
+	return settings.Enabled, nil
+}
+
+// SetOriginPullEnabled turns zone-wide Authenticated Origin Pulls on or off.
+//
+// Turn it on only once the origin accepts the certificate, or requests to the
+// origin will fail.
+func (c Client) SetOriginPullEnabled(zoneID string, enabled bool) error {
+	if len(zoneID) == 0 {
+		return fmt.Errorf("you must provide a zone ID")
+	}
+
+	payload := struct {
+		Enabled bool `json:"enabled"`
+	}{enabled}
+
+	err := c.apiRequest("PUT", aopPath(zoneID)+"/settings", nil, payload, nil)
+	if err != nil {
+		return fmt.Errorf("set origin pull settings error: %w", err)
+	}
+
+	return nil
+}
+
+// ListOriginPullCertificates retrieves the zone-wide client certificates.
+func (c Client) ListOriginPullCertificates(zoneID string) (
+	[]OriginPullCertificate, error) {
+	return c.listOriginPullCertificates(zoneID, aopPath(zoneID))
+}
+
+// UploadOriginPullCertificate uploads a zone-wide client certificate.
+// certificate and privateKey are PEM encoded.
+func (c Client) UploadOriginPullCertificate(zoneID, certificate,
+	privateKey string) (OriginPullCertificate, error) {
+	return c.uploadOriginPullCertificate(zoneID, aopPath(zoneID), certificate,
+		privateKey)
+}
+
+// DeleteOriginPullCertificate deletes a zone-wide client certificate.
+func (c Client) DeleteOriginPullCertificate(zoneID, certID string) error {
+	return c.deleteOriginPullCertificate(zoneID, aopPath(zoneID), certID)
+}
+
+// ListHostnameOriginPullCertificates retrieves the per-hostname client
+// certificates.
+func (c Client) ListHostnameOriginPullCertificates(zoneID string) (
+	[]OriginPullCertificate, error) {
+	return c.listOriginPullCertificates(zoneID,
+		aopPath(zoneID)+"/hostnames/certificates")
+}
+
+// UploadHostnameOriginPullCertificate uploads a client certificate for use
+// with particular hostnames. Associate it with hostnames using
+// SetHostnameOriginPulls.
+func (c Client) UploadHostnameOriginPullCertificate(zoneID, certificate,
+	privateKey string) (OriginPullCertificate, error) {
+	return c.uploadOriginPullCertificate(zoneID,
+		aopPath(zoneID)+"/hostnames/certificates", certificate, privateKey)
+}
+
+// DeleteHostnameOriginPullCertificate deletes a per-hostname client
+// certificate. It must not be associated with any hostnames.
+func (c Client) DeleteHostnameOriginPullCertificate(zoneID,
+	certID string) error {
+	return c.deleteOriginPullCertificate(zoneID,
+		aopPath(zoneID)+"/hostnames/certificates", certID)
+}
+
+// GetHostnameOriginPull retrieves the origin pull configuration of a
+// hostname.
+func (c Client) GetHostnameOriginPull(zoneID, hostname string) (
+	OriginPullHostname, error) {
+	if len(zoneID) == 0 || len(hostname) == 0 {
+		return OriginPullHostname{}, fmt.Errorf(
+			"you must provide a zone ID and hostname")
+	}
+
+	var config OriginPullHostname
+	err := c.apiRequest("GET", aopPath(zoneID)+"/hostnames/"+
+		url.QueryEscape(hostname), nil, nil, &config)
+	if err != nil {
+		return OriginPullHostname{}, fmt.Errorf(
+			"get hostname origin pull error: %w", err)
+	}
+
+	return config, nil
+}
+
+// SetHostnameOriginPulls associates hostnames with client certificates and
+// turns per-hostname Authenticated Origin Pulls on or off for them.
+func (c Client) SetHostnameOriginPulls(zoneID string,
+	configs []OriginPullHostname) ([]OriginPullHostname, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	for _, config := range configs {
+		if len(config.Hostname) == 0 {
+			return nil, fmt.Errorf("each hostname configuration needs a hostname")
+		}
+	}
+
+	payload := struct {
+		Config []OriginPullHostname `json:"config"`
+	}{configs}
+
+	var updated []OriginPullHostname
+	err := c.apiRequest("PUT", aopPath(zoneID)+"/hostnames", nil, payload,
+		&updated)
+	if err != nil {
+		return nil, fmt.Errorf("set hostname origin pulls error: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (c Client) listOriginPullCertificates(zoneID, path string) (
+	[]OriginPullCertificate, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var certs []OriginPullCertificate
+	err := c.apiRequest("GET", path, nil, nil, &certs)
+	if err != nil {
+		return nil, fmt.Errorf("list origin pull certificates error: %w", err)
+	}
+
+	return certs, nil
+}
+
+func (c Client) uploadOriginPullCertificate(zoneID, path, certificate,
+	privateKey string) (OriginPullCertificate, error) {
+	if len(zoneID) == 0 {
+		return OriginPullCertificate{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	if len(certificate) == 0 || len(privateKey) == 0 {
+		return OriginPullCertificate{}, fmt.Errorf(
+			"you must provide a certificate and private key")
+	}
+
+	payload := struct {
+		Certificate string `json:"certificate"`
+		PrivateKey  string `json:"private_key"`
+	}{certificate, privateKey}
+
+	var cert OriginPullCertificate
+	err := c.apiRequest("POST", path, nil, payload, &cert)
+	if err != nil {
+		return OriginPullCertificate{}, fmt.Errorf(
+			"upload origin pull certificate error: %w", err)
+	}
+
+	return cert, nil
+}
+
+func (c Client) deleteOriginPullCertificate(zoneID, path,
+	certID string) error {
+	if len(zoneID) == 0 || len(certID) == 0 {
+		return fmt.Errorf("you must provide a zone ID and certificate ID")
+	}
+
+	err := c.apiRequest("DELETE", path+"/"+url.QueryEscape(certID), nil, nil,
+		nil)
+	if err != nil {
+		return fmt.Errorf("delete origin pull certificate error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// RatePlanDetails describes a rate plan available to a zone, including its
+// components.
+type RatePlanDetails struct {
+	ID         string              `json:"id"`
+	Name       string              `json:"name"`
+	Currency   string              `json:"currency"`
+	Duration   int                 `json:"duration"`
+	Frequency  string              `json:"frequency"`
+	Components []RatePlanComponent `json:"components"`
+}
+
+// RatePlanComponent is a part of a rate plan, such as page rules.
+type RatePlanComponent struct {
+	Name      string  `json:"name"`
+	Default   int     `json:"default"`
+	UnitPrice float64 `json:"unit_price"`
+}
+
+// BillingProfile holds an account's billing details.
+type BillingProfile struct {
+	ID              string `json:"id"`
+	FirstName       string `json:"first_name"`
+	LastName        string `json:"last_name"`
+	Company         string `json:"company"`
+	Address         string `json:"address"`
+	Address2        string `json:"address2"`
+	City            string `json:"city"`
+	State           string `json:"state"`
+	Zipcode         string `json:"zipcode"`
+	Country         string `json:"country"`
+	Telephone       string `json:"telephone"`
+	PaymentGateway  string `json:"payment_gateway"`
+	PaymentEmail    string `json:"payment_email"`
+	CardNumber      string `json:"card_number"`
+	CardExpiryYear  int    `json:"card_expiry_year"`
+	CardExpiryMonth int    `json:"card_expiry_month"`
+	VAT             string `json:"vat"`
+	CreatedOn       string `json:"created_on"`
+	EditedOn        string `json:"edited_on"`
+}
+
+// BillingHistoryEntry is a charge or credit.
+type BillingHistoryEntry struct {
+	ID          string  `json:"id"`
+	Type        string  `json:"type"`
+	Action      string  `json:"action"`
+	Description string  `json:"description"`
+	OccurredAt  string  `json:"occurred_at"`
+	Amount      float64 `json:"amount"`
+	Currency    string  `json:"currency"`
+	Zone        struct {
+		Name string `json:"name"`
+	} `json:"zone"`
+}
+
+// ListAvailableRatePlans retrieves the rate plans available to a zone along
+// with their components and prices. See also ListAvailablePlans.
+func (c Client) ListAvailableRatePlans(zoneID string) ([]RatePlanDetails,
+	error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var plans []RatePlanDetails
+	err := c.apiRequest("GET", zonePrefix(zoneID)+"/available_rate_plans", nil,
+		nil, &plans)
+	if err != nil {
+		return nil, fmt.Errorf("list available rate plans error: %w", err)
+	}
+
+	return plans, nil
+}
+
+// ListAccountSubscriptions retrieves an account's subscriptions, such as
+// zone plans and add-ons.
+func (c Client) ListAccountSubscriptions(accountID string) (
+	[]ZoneSubscription, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var subs []ZoneSubscription
+	err = c.apiRequest("GET", accountPrefix(accountID)+"/subscriptions", nil,
+		nil, &subs)
+	if err != nil {
+		return nil, fmt.Errorf("list account subscriptions error: %w", err)
+	}
+
+	return subs, nil
+}
+
+// GetBillingProfile retrieves an account's billing profile.
+func (c Client) GetBillingProfile(accountID string) (BillingProfile, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return BillingProfile{}, err
+	}
+	if len(accountID) == 0 {
+		return BillingProfile{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	var profile BillingProfile
+	err = c.apiRequest("GET", accountPrefix(accountID)+"/billing/profile", nil,
+		nil, &profile)
+	if err != nil {
+		return BillingProfile{}, fmt.Errorf("get billing profile error: %w", err)
+	}
+
+	return profile, nil
+}
+
+// ListBillingHistory retrieves the user's billing history, most recent first.
+func (c Client) ListBillingHistory() ([]BillingHistoryEntry, error) {
+	perPage := 50
+	allEntries := []BillingHistoryEntry{}
+
+	for page := 1; ; page++ {
+		values := url.Values{}
+		values.Set("page", strconv.Itoa(page))
+		values.Set("per_page", strconv.Itoa(perPage))
+		values.Set("order", "occurred_at")
+
+		var entries []BillingHistoryEntry
+		err := c.apiRequest("GET", "user/billing/history", values, nil,
+			&entries)
+		if err != nil {
+			return nil, fmt.Errorf("list billing history error: %w", err)
+		}
+
+		allEntries = append(allEntries, entries...)
+
+		if len(entries) < perPage {
+			return allEntries, nil
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Package bulk runs many Cloudflare API operations concurrently.
+//
+// Operations run on a bounded pool of workers. Failures are collected and
+// reported together rather than stopping at the first one.
+package bulk
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/horgh/cloudflare"
+)
+
+// DefaultWorkers is how many operations run at once if not specified.
+const DefaultWorkers = 8
+
+// Op is a single operation.
+type Op struct {
+	// Name describes the operation in results and errors, e.g. the zone or
+	// record it is acting on.
+	Name string
+
+	// Run performs the operation.
+	Run func(client cloudflare.Client) error
+}
+
+// Result is the outcome of an operation.
+type Result struct {
+	Name string
+	Err  error
+}
+
+// Errors holds the results of the operations that failed.
+type Errors []Result
+
+func (e Errors) Error() string {
+	var msgs []string
+	for _, result := range e {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", result.Name, result.Err))
+	}
+	return fmt.Sprintf("%d operations failed: %s", len(e),
+		strings.Join(msgs, "; "))
+}
+
+// Runner runs operations with a client.
+type Runner struct {
+	client  cloudflare.Client
+	workers int
+}
+
+// NewRunner creates a Runner using at most workers concurrent operations.
+//
+// If the client has no rate limiter we give it one with the default limits.
+// Otherwise many workers would quickly exceed Cloudflare's rate limit.
+func NewRunner(client cloudflare.Client, workers int) Runner {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+
+	if client.RateLimiter == nil {
+		client.RateLimiter = cloudflare.NewRateLimiter(
+			cloudflare.DefaultRequestsPerSecond, cloudflare.DefaultBurst)
+	}
+
+	return Runner{client: client, workers: workers}
+}
+
+// Run runs the operations and returns the result of each, in the same order.
+//
+// If any fail, the error is an Errors holding those that failed.
+func (r Runner) Run(ops []Op) ([]Result, error) {
+	results := make([]Result, len(ops))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for i := 0; i < r.workers && i < len(ops); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				results[j] = Result{Name: ops[j].Name, Err: ops[j].Run(r.client)}
+			}
+		}()
+	}
+
+	for i := range ops {
+		jobs <- i
+	}
+	close(jobs)
+
+	wg.Wait()
+
+	var failed Errors
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+
+	if len(failed) > 0 {
+		return results, failed
+	}
+
+	return results, nil
+}
+
+// UpdateDNSRecords updates each record. See Client.UpdateDNSRecord().
+func (r Runner) UpdateDNSRecords(records []cloudflare.DNSRecord) ([]Result,
+	error) {
+	var ops []Op
+	for _, record := range records {
+		ops = append(ops, Op{
+			Name: fmt.Sprintf("%s %s", record.Type, record.Name),
+			Run: func(client cloudflare.Client) error {
+				return client.UpdateDNSRecord(record)
+			},
+		})
+	}
+
+	return r.Run(ops)
+}
+
+// PurgeAllFiles purges everything cached for each zone.
+func (r Runner) PurgeAllFiles(zones []cloudflare.Zone) ([]Result, error) {
+	var ops []Op
+	for _, zone := range zones {
+		ops = append(ops, Op{
+			Name: zone.Name,
+			Run: func(client cloudflare.Client) error {
+				return client.PurgeAllFiles(zone.ID)
+			},
+		})
+	}
+
+	return r.Run(ops)
+}
+
+// ListDNSRecords lists every record of each zone. We return the records by
+// zone ID.
+func (r Runner) ListDNSRecords(zones []cloudflare.Zone) (
+	map[string][]cloudflare.DNSRecord, error) {
+	records := map[string][]cloudflare.DNSRecord{}
+	var mutex sync.Mutex
+
+	var ops []Op
+	for _, zone := range zones {
+		ops = append(ops, Op{
+			Name: zone.Name,
+			Run: func(client cloudflare.Client) error {
+				zoneRecords, err := client.ListAllDNSRecords(zone.ID, "", "")
+				if err != nil {
+					return err
+				}
+
+				mutex.Lock()
+				defer mutex.Unlock()
+				records[zone.ID] = zoneRecords
+				return nil
+			},
+		})
+	}
+
+	_, err := r.Run(ops)
+	return records, err
+}
@@ -0,0 +1,518 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+)
+
+// IPPrefix is an IP prefix brought to Cloudflare (BYOIP).
+type IPPrefix struct {
+	ID          string `json:"id"`
+	CIDR        string `json:"cidr"`
+	ASN         int    `json:"asn"`
+	Description string `json:"description"`
+
+	// Approved is whether Cloudflare approved the prefix, e.g. V for
+	// verified.
+	Approved string `json:"approved"`
+
+	// Advertised is whether Cloudflare announces the prefix over BGP. See
+	// GetPrefixAdvertisement for more current information.
+	Advertised           bool   `json:"advertised"`
+	AdvertisedModifiedAt string `json:"advertised_modified_at"`
+
+	OnDemandEnabled bool   `json:"on_demand_enabled"`
+	OnDemandLocked  bool   `json:"on_demand_locked"`
+	CreatedAt       string `json:"created_at"`
+	ModifiedAt      string `json:"modified_at"`
+}
+
+// PrefixDelegation lets another account use part of a prefix.
+type PrefixDelegation struct {
+	ID                 string `json:"id,omitempty"`
+	CIDR               string `json:"cidr"`
+	DelegatedAccountID string `json:"delegated_account_id"`
+	ParentPrefixID     string `json:"parent_prefix_id,omitempty"`
+	CreatedAt          string `json:"created_at,omitempty"`
+	ModifiedAt         string `json:"modified_at,omitempty"`
+}
+
+// PrefixAdvertisement holds whether a prefix is announced over BGP.
+type PrefixAdvertisement struct {
+	Advertised           bool   `json:"advertised"`
+	AdvertisedModifiedAt string `json:"advertised_modified_at,omitempty"`
+}
+
+// AddressMap maps IPs, such as from a BYOIP prefix, to zones or accounts.
+// Hostnames on those zones are then reachable at those IPs.
+type AddressMap struct {
+	ID          string `json:"id,omitempty"`
+	Description string `json:"description,omitempty"`
+	Enabled     bool   `json:"enabled"`
+
+	// DefaultSNI is the hostname to use for TLS handshakes without SNI.
+	DefaultSNI string `json:"default_sni,omitempty"`
+
+	// CanDelete and CanModifyIPs are whether we may delete the map and
+	// change its IPs. Some maps are managed by Cloudflare.
+	CanDelete    bool `json:"can_delete,omitempty"`
+	CanModifyIPs bool `json:"can_modify_ips,omitempty"`
+
+	IPs         []AddressMapIP         `json:"ips,omitempty"`
+	Memberships []AddressMapMembership `json:"memberships,omitempty"`
+	CreatedAt   string                 `json:"created_at,omitempty"`
+	ModifiedAt  string                 `json:"modified_at,omitempty"`
+}
+
+// AddressMapIP is an IP in an address map.
+type AddressMapIP struct {
+	IP        string `json:"ip"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// AddressMapMembership is a zone or account in an address map.
+type AddressMapMembership struct {
+	// Kind is zone or account.
+	Kind       string `json:"kind"`
+	Identifier string `json:"identifier"`
+	CanDelete  bool   `json:"can_delete,omitempty"`
+	CreatedAt  string `json:"created_at,omitempty"`
+}
+
+func prefixesPath(accountID string) string {
+	return accountPrefix(accountID) + "/addressing/prefixes"
+}
+
+func prefixPath(accountID, prefixID string) string {
+	return prefixesPath(accountID) + "/" + url.QueryEscape(prefixID)
+}
+
+func addressMapsPath(accountID string) string {
+	return accountPrefix(accountID) + "/addressing/address_maps"
+}
+
+func addressMapPath(accountID, mapID string) string {
+	return addressMapsPath(accountID) + "/" + url.QueryEscape(mapID)
+}
+
+// ListIPPrefixes lists an account's BYOIP prefixes.
+func (c Client) ListIPPrefixes(accountID string) ([]IPPrefix, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var prefixes []IPPrefix
+	err = c.apiRequest("GET", prefixesPath(accountID), nil, nil, &prefixes)
+	if err != nil {
+		return nil, fmt.Errorf("list IP prefixes error: %w", err)
+	}
+
+	return prefixes, nil
+}
+
+// GetIPPrefix retrieves a BYOIP prefix.
+func (c Client) GetIPPrefix(accountID, prefixID string) (IPPrefix, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return IPPrefix{}, err
+	}
+	if len(accountID) == 0 || len(prefixID) == 0 {
+		return IPPrefix{}, fmt.Errorf(
+			"you must provide an account ID and prefix ID")
+	}
+
+	var prefix IPPrefix
+	err = c.apiRequest("GET", prefixPath(accountID, prefixID), nil, nil,
+		&prefix)
+	if err != nil {
+		return IPPrefix{}, fmt.Errorf("get IP prefix error: %w", err)
+	}
+
+	return prefix, nil
+}
+
+// ListPrefixDelegations lists the delegations of a prefix.
+func (c Client) ListPrefixDelegations(accountID,
+	prefixID string) ([]PrefixDelegation, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 || len(prefixID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID and prefix ID")
+	}
+
+	var delegations []PrefixDelegation
+	err = c.apiRequest("GET", prefixPath(accountID, prefixID)+"/delegations",
+		nil, nil, &delegations)
+	if err != nil {
+		return nil, fmt.Errorf("list prefix delegations error: %w", err)
+	}
+
+	return delegations, nil
+}
+
+// CreatePrefixDelegation delegates cidr, which must be within the prefix, to
+// another account.
+func (c Client) CreatePrefixDelegation(accountID, prefixID, cidr,
+	delegatedAccountID string) (PrefixDelegation, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return PrefixDelegation{}, err
+	}
+	if len(accountID) == 0 || len(prefixID) == 0 {
+		return PrefixDelegation{}, fmt.Errorf(
+			"you must provide an account ID and prefix ID")
+	}
+
+	if len(delegatedAccountID) == 0 {
+		return PrefixDelegation{}, fmt.Errorf(
+			"you must provide an account to delegate to")
+	}
+
+	if _, _, err := net.ParseCIDR(cidr); err != nil {
+		return PrefixDelegation{}, fmt.Errorf("invalid CIDR: %s", cidr)
+	}
+
+	payload := PrefixDelegation{
+		CIDR:               cidr,
+		DelegatedAccountID: delegatedAccountID,
+	}
+
+	var delegation PrefixDelegation
+	err = c.apiRequest("POST", prefixPath(accountID, prefixID)+"/delegations",
+		nil, payload, &delegation)
+	if err != nil {
+		return PrefixDelegation{}, fmt.Errorf(
+			"create prefix delegation error: %w", err)
+	}
+
+	return delegation, nil
+}
+
+// DeletePrefixDelegation removes a delegation of a prefix.
+func (c Client) DeletePrefixDelegation(accountID, prefixID,
+	delegationID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(prefixID) == 0 || len(delegationID) == 0 {
+		return fmt.Errorf(
+			"you must provide an account ID, prefix ID, and delegation ID")
+	}
+
+	err = c.apiRequest("DELETE", prefixPath(accountID, prefixID)+
+		"/delegations/"+url.QueryEscape(delegationID), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete prefix delegation error: %w", err)
+	}
+
+	return nil
+}
+
+// GetPrefixAdvertisement retrieves whether Cloudflare announces a prefix
+// over BGP.
+func (c Client) GetPrefixAdvertisement(accountID,
+	prefixID string) (PrefixAdvertisement, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return PrefixAdvertisement{}, err
+	}
+	if len(accountID) == 0 || len(prefixID) == 0 {
+		return PrefixAdvertisement{}, fmt.Errorf(
+			"you must provide an account ID and prefix ID")
+	}
+
+	var status PrefixAdvertisement
+	err = c.apiRequest("GET", prefixPath(accountID, prefixID)+"/bgp/status",
+		nil, nil, &status)
+	if err != nil {
+		return PrefixAdvertisement{}, fmt.Errorf(
+			"get prefix advertisement error: %w", err)
+	}
+
+	return status, nil
+}
+
+// AdvertisePrefix starts announcing a prefix over BGP so traffic to it comes
+// to Cloudflare.
+//
+// Routers take a few minutes to converge after a change.
+func (c Client) AdvertisePrefix(accountID,
+	prefixID string) (PrefixAdvertisement, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return PrefixAdvertisement{}, err
+	}
+	return c.setPrefixAdvertisement(accountID, prefixID, true)
+}
+
+// WithdrawPrefix stops announcing a prefix over BGP, e.g. to fail over to
+// another network announcing it.
+func (c Client) WithdrawPrefix(accountID,
+	prefixID string) (PrefixAdvertisement, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return PrefixAdvertisement{}, err
+	}
+	return c.setPrefixAdvertisement(accountID, prefixID, false)
+}
+
+func (c Client) setPrefixAdvertisement(accountID, prefixID string,
+	advertised bool) (PrefixAdvertisement, error) {
+	if len(accountID) == 0 || len(prefixID) == 0 {
+		return PrefixAdvertisement{}, fmt.Errorf(
+			"you must provide an account ID and prefix ID")
+	}
+
+	payload := PrefixAdvertisement{Advertised: advertised}
+
+	var status PrefixAdvertisement
+	err := c.apiRequest("PATCH", prefixPath(accountID, prefixID)+"/bgp/status",
+		nil, payload, &status)
+	if err != nil {
+		return PrefixAdvertisement{}, fmt.Errorf(
+			"set prefix advertisement error: %w", err)
+	}
+
+	return status, nil
+}
+
+// ListAddressMaps lists an account's address maps.
+func (c Client) ListAddressMaps(accountID string) ([]AddressMap, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var maps []AddressMap
+	err = c.apiRequest("GET", addressMapsPath(accountID), nil, nil, &maps)
+	if err != nil {
+		return nil, fmt.Errorf("list address maps error: %w", err)
+	}
+
+	return maps, nil
+}
+
+// GetAddressMap retrieves an address map along with its IPs and
+// memberships.
+func (c Client) GetAddressMap(accountID, mapID string) (AddressMap, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return AddressMap{}, err
+	}
+	if len(accountID) == 0 || len(mapID) == 0 {
+		return AddressMap{}, fmt.Errorf(
+			"you must provide an account ID and address map ID")
+	}
+
+	var addressMap AddressMap
+	err = c.apiRequest("GET", addressMapPath(accountID, mapID), nil, nil,
+		&addressMap)
+	if err != nil {
+		return AddressMap{}, fmt.Errorf("get address map error: %w", err)
+	}
+
+	return addressMap, nil
+}
+
+// CreateAddressMap creates an address map. It may include IPs and
+// memberships.
+func (c Client) CreateAddressMap(accountID string,
+	addressMap AddressMap) (AddressMap, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return AddressMap{}, err
+	}
+	if len(accountID) == 0 {
+		return AddressMap{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	for _, ip := range addressMap.IPs {
+		if net.ParseIP(ip.IP) == nil {
+			return AddressMap{}, fmt.Errorf("invalid IP: %s", ip.IP)
+		}
+	}
+
+	payload := struct {
+		Description string                 `json:"description,omitempty"`
+		Enabled     bool                   `json:"enabled"`
+		IPs         []string               `json:"ips,omitempty"`
+		Memberships []AddressMapMembership `json:"memberships,omitempty"`
+	}{
+		Description: addressMap.Description,
+		Enabled:     addressMap.Enabled,
+		Memberships: addressMap.Memberships,
+	}
+	for _, ip := range addressMap.IPs {
+		payload.IPs = append(payload.IPs, ip.IP)
+	}
+
+	var created AddressMap
+	err = c.apiRequest("POST", addressMapsPath(accountID), nil, payload,
+		&created)
+	if err != nil {
+		return AddressMap{}, fmt.Errorf("create address map error: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateAddressMap changes an address map's description, default SNI, and
+// whether it is enabled. Its ID must be set. Change its IPs and memberships
+// with the other AddressMap functions.
+func (c Client) UpdateAddressMap(accountID string,
+	addressMap AddressMap) (AddressMap, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return AddressMap{}, err
+	}
+	if len(accountID) == 0 || len(addressMap.ID) == 0 {
+		return AddressMap{}, fmt.Errorf(
+			"you must provide an account ID and address map ID")
+	}
+
+	payload := struct {
+		Description string `json:"description"`
+		Enabled     bool   `json:"enabled"`
+		DefaultSNI  string `json:"default_sni,omitempty"`
+	}{
+		Description: addressMap.Description,
+		Enabled:     addressMap.Enabled,
+		DefaultSNI:  addressMap.DefaultSNI,
+	}
+
+	var updated AddressMap
+	err = c.apiRequest("PATCH", addressMapPath(accountID, addressMap.ID), nil,
+		payload, &updated)
+	if err != nil {
+		return AddressMap{}, fmt.Errorf("update address map error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteAddressMap deletes an address map.
+func (c Client) DeleteAddressMap(accountID, mapID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(mapID) == 0 {
+		return fmt.Errorf("you must provide an account ID and address map ID")
+	}
+
+	err = c.apiRequest("DELETE", addressMapPath(accountID, mapID), nil, nil,
+		nil)
+	if err != nil {
+		return fmt.Errorf("delete address map error: %w", err)
+	}
+
+	return nil
+}
+
+// AddAddressMapIP adds an IP to an address map. It must be in one of the
+// account's prefixes.
+func (c Client) AddAddressMapIP(accountID, mapID, ip string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	return c.changeAddressMapIP("PUT", accountID, mapID, ip)
+}
+
+// RemoveAddressMapIP removes an IP from an address map.
+func (c Client) RemoveAddressMapIP(accountID, mapID, ip string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	return c.changeAddressMapIP("DELETE", accountID, mapID, ip)
+}
+
+func (c Client) changeAddressMapIP(method, accountID, mapID,
+	ip string) error {
+	if len(accountID) == 0 || len(mapID) == 0 {
+		return fmt.Errorf("you must provide an account ID and address map ID")
+	}
+
+	if net.ParseIP(ip) == nil {
+		return fmt.Errorf("invalid IP: %s", ip)
+	}
+
+	err := c.apiRequest(method, addressMapPath(accountID, mapID)+"/ips/"+
+		url.QueryEscape(ip), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("change address map IP error: %w", err)
+	}
+
+	return nil
+}
+
+// AddAddressMapZone adds a zone to an address map.
+func (c Client) AddAddressMapZone(accountID, mapID, zoneID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	return c.changeAddressMapMembership("PUT", accountID, mapID, "zones",
+		zoneID)
+}
+
+// RemoveAddressMapZone removes a zone from an address map.
+func (c Client) RemoveAddressMapZone(accountID, mapID, zoneID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	return c.changeAddressMapMembership("DELETE", accountID, mapID, "zones",
+		zoneID)
+}
+
+// AddAddressMapAccount adds an account, such as the one owning the map, to
+// an address map. This covers all of the account's zones.
+func (c Client) AddAddressMapAccount(accountID, mapID,
+	memberAccountID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	return c.changeAddressMapMembership("PUT", accountID, mapID, "accounts",
+		memberAccountID)
+}
+
+// RemoveAddressMapAccount removes an account from an address map.
+func (c Client) RemoveAddressMapAccount(accountID, mapID,
+	memberAccountID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	return c.changeAddressMapMembership("DELETE", accountID, mapID,
+		"accounts", memberAccountID)
+}
+
+func (c Client) changeAddressMapMembership(method, accountID, mapID, kind,
+	id string) error {
+	if len(accountID) == 0 || len(mapID) == 0 || len(id) == 0 {
+		return fmt.Errorf(
+			"you must provide an account ID, address map ID, and member ID")
+	}
+
+	err := c.apiRequest(method, addressMapPath(accountID, mapID)+"/"+kind+"/"+
+		url.QueryEscape(id), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("change address map membership error: %w", err)
+	}
+
+	return nil
+}
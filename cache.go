@@ -0,0 +1,146 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// CacheReserveClear holds the state of clearing a zone's Cache Reserve.
+type CacheReserveClear struct {
+	// State is In-progress or Completed.
+	State   string `json:"state"`
+	StartTS string `json:"start_ts"`
+	EndTS   string `json:"end_ts,omitempty"`
+}
+
+// How often we check whether clearing Cache Reserve has finished.
+const cacheReservePollInterval = 15 * time.Second
+
+// GetCacheReserve retrieves whether Cache Reserve is on for a zone.
+func (c Client) GetCacheReserve(zoneID string) (bool, error) {
+	return c.getCacheSetting(zoneID, "cache_reserve")
+}
+
+// SetCacheReserve turns Cache Reserve on or off for a zone. Cache Reserve
+// keeps cached content in R2 so it stays cached longer.
+func (c Client) SetCacheReserve(zoneID string, enabled bool) error {
+	return c.setCacheSetting(zoneID, "cache_reserve", enabled)
+}
+
+// GetRegionalTieredCache retrieves whether Regional Tiered Cache is on for a
+// zone.
+func (c Client) GetRegionalTieredCache(zoneID string) (bool, error) {
+	return c.getCacheSetting(zoneID, "regional_tiered_cache")
+}
+
+// SetRegionalTieredCache turns Regional Tiered Cache on or off for a zone. It
+// adds a regional tier between the lower and upper tiers of Tiered Cache.
+func (c Client) SetRegionalTieredCache(zoneID string, enabled bool) error {
+	return c.setCacheSetting(zoneID, "regional_tiered_cache", enabled)
+}
+
+// ClearCacheReserve starts removing all content from a zone's Cache Reserve.
+// Cache Reserve must be off first.
+//
+// Clearing takes a while. If wait is non-zero we wait up to that long for it
+// to complete.
+func (c Client) ClearCacheReserve(zoneID string,
+	wait time.Duration) (CacheReserveClear, error) {
+	if len(zoneID) == 0 {
+		return CacheReserveClear{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var status CacheReserveClear
+	err := c.apiRequest("POST", zonePrefix(zoneID)+"/cache/cache_reserve_clear",
+		nil, struct{}{}, &status)
+	if err != nil {
+		return CacheReserveClear{}, fmt.Errorf(
+			"clear cache reserve error: %w", err)
+	}
+
+	if wait <= 0 {
+		return status, nil
+	}
+
+	deadline := time.Now().Add(wait)
+
+	for status.State != "Completed" {
+		if time.Now().Add(cacheReservePollInterval).After(deadline) {
+			return status, fmt.Errorf(
+				"clearing Cache Reserve did not complete within %s", wait)
+		}
+
+		time.Sleep(cacheReservePollInterval)
+
+		status, err = c.GetCacheReserveClear(zoneID)
+		if err != nil {
+			return status, err
+		}
+	}
+
+	return status, nil
+}
+
+// GetCacheReserveClear retrieves the state of the most recent clearing of a
+// zone's Cache Reserve.
+func (c Client) GetCacheReserveClear(zoneID string) (CacheReserveClear,
+	error) {
+	if len(zoneID) == 0 {
+		return CacheReserveClear{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var status CacheReserveClear
+	err := c.apiRequest("GET", zonePrefix(zoneID)+"/cache/cache_reserve_clear",
+		nil, nil, &status)
+	if err != nil {
+		return CacheReserveClear{}, fmt.Errorf(
+			"get cache reserve clear error: %w", err)
+	}
+
+	return status, nil
+}
+
+// Cache settings live under zones/<id>/cache rather than with the other zone
+// settings, but look the same.
+func (c Client) getCacheSetting(zoneID, name string) (bool, error) {
+	if len(zoneID) == 0 {
+		return false, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var setting ZoneSetting
+	err := c.apiRequest("GET", zonePrefix(zoneID)+"/cache/"+name, nil, nil,
+		&setting)
+	if err != nil {
+		return false, fmt.Errorf("get %s error: %w", name, err)
+	}
+
+	var value string
+	err = json.Unmarshal(setting.Value, &value)
+	if err != nil {
+		return false, fmt.Errorf("%s is not a string: %w", name, err)
+	}
+
+	return value == "on", nil
+}
+
+func (c Client) setCacheSetting(zoneID, name string, enabled bool) error {
+	if len(zoneID) == 0 {
+		return fmt.Errorf("you must provide a zone ID")
+	}
+
+	value := "off"
+	if enabled {
+		value = "on"
+	}
+
+	payload := map[string]string{"value": value}
+
+	err := c.apiRequest("PATCH", zonePrefix(zoneID)+"/cache/"+name, nil,
+		payload, nil)
+	if err != nil {
+		return fmt.Errorf("update %s error: %w", name, err)
+	}
+
+	return nil
+}
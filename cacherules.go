@@ -0,0 +1,176 @@
+package cloudflare
+
+import (
+	"fmt"
+	"time"
+)
+
+// PhaseHTTPRequestCacheSettings is the phase holding Cache Rules.
+const PhaseHTTPRequestCacheSettings = "http_request_cache_settings"
+
+// CacheTTL sets how long to cache for. Used in Cache Rules.
+type CacheTTL struct {
+	// Mode is one of the CacheTTL* constants.
+	Mode string `json:"mode"`
+
+	// Default is the TTL in seconds when overriding the origin.
+	Default int `json:"default,omitempty"`
+}
+
+// Modes for CacheTTL.
+const (
+	CacheTTLRespectOrigin  = "respect_origin"
+	CacheTTLOverrideOrigin = "override_origin"
+
+	// Edge TTL only.
+	CacheTTLBypassByDefault = "bypass_by_default"
+
+	// Browser TTL only.
+	CacheTTLBypass = "bypass"
+)
+
+// CacheKey customizes the cache key. Used in Cache Rules.
+type CacheKey struct {
+	CacheDeceptionArmor     bool            `json:"cache_deception_armor,omitempty"`
+	IgnoreQueryStringsOrder bool            `json:"ignore_query_strings_order,omitempty"`
+	CustomKey               *CacheCustomKey `json:"custom_key,omitempty"`
+}
+
+// CacheCustomKey selects what parts of the request go into the cache key.
+type CacheCustomKey struct {
+	QueryString *CacheKeyQueryString `json:"query_string,omitempty"`
+	Header      *CacheKeyHeader      `json:"header,omitempty"`
+}
+
+// CacheKeyQueryString chooses which query string parameters are in the cache
+// key. Set only one of Include or Exclude.
+type CacheKeyQueryString struct {
+	Include *CacheKeyList `json:"include,omitempty"`
+	Exclude *CacheKeyList `json:"exclude,omitempty"`
+}
+
+// CacheKeyList is either all items or a list of them.
+type CacheKeyList struct {
+	All  bool     `json:"all,omitempty"`
+	List []string `json:"list,omitempty"`
+}
+
+// CacheKeyHeader chooses which headers are in the cache key.
+type CacheKeyHeader struct {
+	Include       []string `json:"include,omitempty"`
+	CheckPresence []string `json:"check_presence,omitempty"`
+}
+
+// CacheRuleOptions describes what a Cache Rule does.
+type CacheRuleOptions struct {
+	// Bypass makes matching requests skip the cache. If set, the remaining
+	// options are ignored.
+	Bypass bool
+
+	// EdgeTTL is how long Cloudflare caches responses. Zero means to respect
+	// the origin's headers.
+	EdgeTTL time.Duration
+
+	// BrowserTTL is how long browsers cache responses. Zero means to respect
+	// the origin's headers.
+	BrowserTTL time.Duration
+
+	// IgnoreQueryStringOrder treats query strings with the same parameters in
+	// a different order as the same.
+	IgnoreQueryStringOrder bool
+
+	// QueryStringInclude and QueryStringExclude choose which query string
+	// parameters are in the cache key. Give only one. "*" means all of them.
+	QueryStringInclude []string
+	QueryStringExclude []string
+
+	// Headers are request headers to include in the cache key.
+	Headers []string
+
+	// CacheDeceptionArmor protects against web cache deception attacks.
+	CacheDeceptionArmor bool
+}
+
+// CacheRule builds a Cache Rule. Add it to the
+// http_request_cache_settings phase, such as with AddZonePhaseRule.
+//
+// Cache Rules replace caching done with Page Rules.
+func CacheRule(expression, description string,
+	opts CacheRuleOptions) (RulesetRule, error) {
+	rule := RulesetRule{
+		Action:      "set_cache_settings",
+		Expression:  expression,
+		Description: description,
+		Enabled:     true,
+	}
+
+	cache := !opts.Bypass
+	params := &RulesetActionParameters{Cache: &cache}
+	rule.ActionParameters = params
+
+	if opts.Bypass {
+		return rule, validateRule(rule)
+	}
+
+	if opts.EdgeTTL < 0 || opts.BrowserTTL < 0 {
+		return RulesetRule{}, fmt.Errorf("TTLs may not be negative")
+	}
+
+	if opts.EdgeTTL > 0 {
+		params.EdgeTTL = &CacheTTL{
+			Mode:    CacheTTLOverrideOrigin,
+			Default: int(opts.EdgeTTL.Seconds()),
+		}
+	}
+
+	if opts.BrowserTTL > 0 {
+		params.BrowserTTL = &CacheTTL{
+			Mode:    CacheTTLOverrideOrigin,
+			Default: int(opts.BrowserTTL.Seconds()),
+		}
+	}
+
+	if len(opts.QueryStringInclude) > 0 && len(opts.QueryStringExclude) > 0 {
+		return RulesetRule{}, fmt.Errorf(
+			"you may only include or exclude query string parameters, not both")
+	}
+
+	key := &CacheKey{
+		CacheDeceptionArmor:     opts.CacheDeceptionArmor,
+		IgnoreQueryStringsOrder: opts.IgnoreQueryStringOrder,
+	}
+
+	customKey := &CacheCustomKey{}
+	if len(opts.QueryStringInclude) > 0 {
+		customKey.QueryString = &CacheKeyQueryString{
+			Include: cacheKeyList(opts.QueryStringInclude),
+		}
+	}
+	if len(opts.QueryStringExclude) > 0 {
+		customKey.QueryString = &CacheKeyQueryString{
+			Exclude: cacheKeyList(opts.QueryStringExclude),
+		}
+	}
+	if len(opts.Headers) > 0 {
+		customKey.Header = &CacheKeyHeader{Include: opts.Headers}
+	}
+	if customKey.QueryString != nil || customKey.Header != nil {
+		key.CustomKey = customKey
+	}
+
+	if key.CacheDeceptionArmor || key.IgnoreQueryStringsOrder ||
+		key.CustomKey != nil {
+		params.CacheKey = key
+	}
+
+	return rule, validateRule(rule)
+}
+
+func cacheKeyList(items []string) *CacheKeyList {
+	for _, item := range items {
+		if item == "*" {
+			return &CacheKeyList{All: true}
+		}
+	}
+	return &CacheKeyList{List: items}
+}
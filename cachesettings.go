@@ -0,0 +1,186 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Cache levels. These decide how the query string affects caching.
+const (
+	// CacheLevelBasic caches only requests without a query string.
+	CacheLevelBasic = "basic"
+
+	// CacheLevelSimplified ignores the query string.
+	CacheLevelSimplified = "simplified"
+
+	// CacheLevelAggressive caches each query string separately. This is
+	// called Standard in the dashboard.
+	CacheLevelAggressive = "aggressive"
+)
+
+// CacheSettings holds a zone's cache related settings.
+type CacheSettings struct {
+	// BrowserCacheTTL is how many seconds browsers should cache files. Zero
+	// means to respect the origin's headers.
+	BrowserCacheTTL int
+
+	// CacheLevel is one of the CacheLevel constants.
+	CacheLevel string
+
+	// SortQueryString is whether query strings with the same parameters in
+	// different orders are cached as one. Enterprise only.
+	SortQueryString bool
+
+	// AlwaysOnline is whether to serve pages from the Internet Archive when
+	// the origin is down.
+	AlwaysOnline bool
+
+	// EarlyHints is whether to send 103 Early Hints responses.
+	EarlyHints bool
+
+	// CrawlerHints is whether to tell search engines when content changes.
+	CrawlerHints bool
+}
+
+// CacheSettingsUpdate holds changes to a zone's cache settings. Fields left
+// nil are unchanged.
+type CacheSettingsUpdate struct {
+	BrowserCacheTTL *int
+	CacheLevel      *string
+	SortQueryString *bool
+	AlwaysOnline    *bool
+	EarlyHints      *bool
+	CrawlerHints    *bool
+}
+
+// GetCacheSettings retrieves a zone's cache settings.
+func (c Client) GetCacheSettings(zoneID string) (CacheSettings, error) {
+	settings, err := c.ListZoneSettings(zoneID)
+	if err != nil {
+		return CacheSettings{}, err
+	}
+
+	var cs CacheSettings
+	for _, setting := range settings {
+		var err error
+		switch setting.ID {
+		case SettingBrowserCacheTTL:
+			err = json.Unmarshal(setting.Value, &cs.BrowserCacheTTL)
+		case SettingCacheLevel:
+			err = json.Unmarshal(setting.Value, &cs.CacheLevel)
+		case SettingSortQueryStringForCache:
+			cs.SortQueryString, err = onOffValue(setting.Value)
+		case SettingAlwaysOnline:
+			cs.AlwaysOnline, err = onOffValue(setting.Value)
+		case SettingEarlyHints:
+			cs.EarlyHints, err = onOffValue(setting.Value)
+		}
+		if err != nil {
+			return CacheSettings{}, fmt.Errorf("invalid value for setting %s: %w",
+				setting.ID, err)
+		}
+	}
+
+	cs.CrawlerHints, err = c.getCrawlerHints(zoneID)
+	if err != nil {
+		return CacheSettings{}, err
+	}
+
+	return cs, nil
+}
+
+// UpdateCacheSettings changes a zone's cache settings. We return them as
+// updated.
+func (c Client) UpdateCacheSettings(zoneID string,
+	update CacheSettingsUpdate) (CacheSettings, error) {
+	if len(zoneID) == 0 {
+		return CacheSettings{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	items := map[string]interface{}{}
+	if update.BrowserCacheTTL != nil {
+		if *update.BrowserCacheTTL < 0 {
+			return CacheSettings{}, fmt.Errorf("browser cache TTL may not be negative")
+		}
+		items[SettingBrowserCacheTTL] = *update.BrowserCacheTTL
+	}
+	if update.CacheLevel != nil {
+		switch *update.CacheLevel {
+		case CacheLevelBasic, CacheLevelSimplified, CacheLevelAggressive:
+		default:
+			return CacheSettings{}, fmt.Errorf("invalid cache level: %s",
+				*update.CacheLevel)
+		}
+		items[SettingCacheLevel] = *update.CacheLevel
+	}
+	if update.SortQueryString != nil {
+		items[SettingSortQueryStringForCache] = onOff(*update.SortQueryString)
+	}
+	if update.AlwaysOnline != nil {
+		items[SettingAlwaysOnline] = onOff(*update.AlwaysOnline)
+	}
+	if update.EarlyHints != nil {
+		items[SettingEarlyHints] = onOff(*update.EarlyHints)
+	}
+
+	if len(items) > 0 {
+		_, err := c.UpdateZoneSettings(zoneID, items)
+		if err != nil {
+			return CacheSettings{}, err
+		}
+	}
+
+	if update.CrawlerHints != nil {
+		err := c.setCrawlerHints(zoneID, *update.CrawlerHints)
+		if err != nil {
+			return CacheSettings{}, err
+		}
+	}
+
+	return c.GetCacheSettings(zoneID)
+}
+
+// Crawler Hints is a cache feature flag rather than a zone setting.
+const crawlerHintsFeature = "crawlhints_enabled"
+
+func (c Client) getCrawlerHints(zoneID string) (bool, error) {
+	var flags map[string]bool
+	err := c.apiRequest("GET", zonePrefix(zoneID)+"/flags/products/cache", nil,
+		nil, &flags)
+	if err != nil {
+		return false, fmt.Errorf("get crawler hints error: %w", err)
+	}
+
+	return flags[crawlerHintsFeature], nil
+}
+
+func (c Client) setCrawlerHints(zoneID string, enabled bool) error {
+	payload := struct {
+		Feature string `json:"feature"`
+		Value   bool   `json:"value"`
+	}{crawlerHintsFeature, enabled}
+
+	err := c.apiRequest("PUT", zonePrefix(zoneID)+"/flags/products/cache/changes",
+		nil, payload, nil)
+	if err != nil {
+		return fmt.Errorf("set crawler hints error: %w", err)
+	}
+
+	return nil
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
+
+func onOffValue(value json.RawMessage) (bool, error) {
+	var s string
+	err := json.Unmarshal(value, &s)
+	if err != nil {
+		return false, err
+	}
+	return s == "on", nil
+}
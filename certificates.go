@@ -0,0 +1,186 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CustomCertificate holds a custom SSL certificate uploaded to a zone.
+type CustomCertificate struct {
+	ID           string   `json:"id"`
+	ZoneID       string   `json:"zone_id"`
+	Hosts        []string `json:"hosts"`
+	Issuer       string   `json:"issuer"`
+	Signature    string   `json:"signature"`
+	Status       string   `json:"status"`
+	BundleMethod string   `json:"bundle_method"`
+	Type         string   `json:"type"`
+	Priority     int      `json:"priority"`
+	UploadedOn   string   `json:"uploaded_on"`
+	ModifiedOn   string   `json:"modified_on"`
+	ExpiresOn    string   `json:"expires_on"`
+}
+
+// CustomCertificateUpload holds a certificate to upload.
+type CustomCertificateUpload struct {
+	// Certificate and PrivateKey are PEM encoded. Certificate may include
+	// intermediates.
+	Certificate string `json:"certificate"`
+	PrivateKey  string `json:"private_key"`
+
+	// BundleMethod is ubiquitous (the default), optimal, or force.
+	BundleMethod string `json:"bundle_method,omitempty"`
+
+	// Type is sni_custom (the default) or legacy_custom.
+	Type string `json:"type,omitempty"`
+}
+
+// ExpiresTime parses the certificate's ExpiresOn time.
+func (c CustomCertificate) ExpiresTime() (time.Time, error) {
+	return parseTime(c.ExpiresOn)
+}
+
+// ListCustomCertificates retrieves every custom certificate of a zone.
+func (c Client) ListCustomCertificates(zoneID string) ([]CustomCertificate,
+	error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	perPage := 50
+	allCerts := []CustomCertificate{}
+
+	for page := 1; ; page++ {
+		values := url.Values{}
+		values.Set("page", strconv.Itoa(page))
+		values.Set("per_page", strconv.Itoa(perPage))
+
+		var certs []CustomCertificate
+		err := c.apiRequest("GET", zonePrefix(zoneID)+"/custom_certificates",
+			values, nil, &certs)
+		if err != nil {
+			return nil, fmt.Errorf("list custom certificates error: %w", err)
+		}
+
+		allCerts = append(allCerts, certs...)
+
+		if len(certs) < perPage {
+			return allCerts, nil
+		}
+	}
+}
+
+// UploadCustomCertificate uploads a certificate and its private key to a zone.
+func (c Client) UploadCustomCertificate(zoneID string,
+	upload CustomCertificateUpload) (CustomCertificate, error) {
+	if len(zoneID) == 0 {
+		return CustomCertificate{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	if len(upload.Certificate) == 0 || len(upload.PrivateKey) == 0 {
+		return CustomCertificate{}, fmt.Errorf(
+			"you must provide a certificate and private key")
+	}
+
+	var cert CustomCertificate
+	err := c.apiRequest("POST", zonePrefix(zoneID)+"/custom_certificates", nil,
+		upload, &cert)
+	if err != nil {
+		return CustomCertificate{}, fmt.Errorf(
+			"upload custom certificate error: %w", err)
+	}
+
+	return cert, nil
+}
+
+// GetCustomCertificate retrieves a custom certificate.
+func (c Client) GetCustomCertificate(zoneID,
+	certificateID string) (CustomCertificate, error) {
+	if len(zoneID) == 0 || len(certificateID) == 0 {
+		return CustomCertificate{}, fmt.Errorf(
+			"you must provide a zone ID and certificate ID")
+	}
+
+	var cert CustomCertificate
+	err := c.apiRequest("GET", zonePrefix(zoneID)+"/custom_certificates/"+
+		url.QueryEscape(certificateID), nil, nil, &cert)
+	if err != nil {
+		return CustomCertificate{}, fmt.Errorf(
+			"get custom certificate error: %w", err)
+	}
+
+	return cert, nil
+}
+
+// UpdateCustomCertificate replaces a custom certificate, such as when renewing
+// it. The certificate keeps its ID and priority.
+//
+// Type may not be changed and is ignored.
+func (c Client) UpdateCustomCertificate(zoneID, certificateID string,
+	upload CustomCertificateUpload) (CustomCertificate, error) {
+	if len(zoneID) == 0 || len(certificateID) == 0 {
+		return CustomCertificate{}, fmt.Errorf(
+			"you must provide a zone ID and certificate ID")
+	}
+
+	upload.Type = ""
+
+	var cert CustomCertificate
+	err := c.apiRequest("PATCH", zonePrefix(zoneID)+"/custom_certificates/"+
+		url.QueryEscape(certificateID), nil, upload, &cert)
+	if err != nil {
+		return CustomCertificate{}, fmt.Errorf(
+			"update custom certificate error: %w", err)
+	}
+
+	return cert, nil
+}
+
+// PrioritizeCustomCertificates sets the order Cloudflare prefers certificates
+// when several match a hostname. priorities maps certificate IDs to priority.
+// Higher priorities are preferred.
+func (c Client) PrioritizeCustomCertificates(zoneID string,
+	priorities map[string]int) ([]CustomCertificate, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	type certPriority struct {
+		ID       string `json:"id"`
+		Priority int    `json:"priority"`
+	}
+
+	payload := struct {
+		Certificates []certPriority `json:"certificates"`
+	}{}
+	for id, priority := range priorities {
+		payload.Certificates = append(payload.Certificates,
+			certPriority{ID: id, Priority: priority})
+	}
+
+	var certs []CustomCertificate
+	err := c.apiRequest("PUT", zonePrefix(zoneID)+
+		"/custom_certificates/prioritize", nil, payload, &certs)
+	if err != nil {
+		return nil, fmt.Errorf("prioritize custom certificates error: %w", err)
+	}
+
+	return certs, nil
+}
+
+// DeleteCustomCertificate deletes a custom certificate.
+func (c Client) DeleteCustomCertificate(zoneID, certificateID string) error {
+	if len(zoneID) == 0 || len(certificateID) == 0 {
+		return fmt.Errorf("you must provide a zone ID and certificate ID")
+	}
+
+	err := c.apiRequest("DELETE", zonePrefix(zoneID)+"/custom_certificates/"+
+		url.QueryEscape(certificateID), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete custom certificate error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client certificates are issued by a Cloudflare managed CA for clients of
+// mutual TLS protected APIs. Cloudflare checks them at the edge, such as
+// with an mTLS rule.
+
+// Client certificate statuses.
+const (
+	ClientCertificateActive              = "active"
+	ClientCertificatePendingReactivation = "pending_reactivation"
+	ClientCertificatePendingRevocation   = "pending_revocation"
+	ClientCertificateRevoked             = "revoked"
+)
+
+// ClientCertificate is a certificate issued to an mTLS client.
+type ClientCertificate struct {
+	ID                   string                     `json:"id"`
+	Certificate          string                     `json:"certificate"`
+	CertificateAuthority ClientCertificateAuthority `json:"certificate_authority"`
+	CommonName           string                     `json:"common_name"`
+	Country              string                     `json:"country"`
+	CSR                  string                     `json:"csr"`
+	ExpiresOn            string                     `json:"expires_on"`
+	FingerprintSHA256    string                     `json:"fingerprint_sha256"`
+	IssuedOn             string                     `json:"issued_on"`
+	Location             string                     `json:"location"`
+	Organization         string                     `json:"organization"`
+	OrganizationalUnit   string                     `json:"organizational_unit"`
+	SerialNumber         string                     `json:"serial_number"`
+	Signature            string                     `json:"signature"`
+	SKI                  string                     `json:"ski"`
+	State                string                     `json:"state"`
+	Status               string                     `json:"status"`
+	ValidityDays         int                        `json:"validity_days"`
+}
+
+// ClientCertificateAuthority identifies the CA that issued a client
+// certificate.
+type ClientCertificateAuthority struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ExpiresTime parses the certificate's ExpiresOn time.
+func (c ClientCertificate) ExpiresTime() (time.Time, error) {
+	return parseTime(c.ExpiresOn)
+}
+
+func clientCertificatePath(zoneID string) string {
+	return zonePrefix(zoneID) + "/client_certificates"
+}
+
+// CreateClientCertificate issues a client certificate for a PEM encoded CSR.
+// validityDays is how long it is valid for. Zero means 3650 days.
+func (c Client) CreateClientCertificate(zoneID, csr string,
+	validityDays int) (ClientCertificate, error) {
+	if len(zoneID) == 0 {
+		return ClientCertificate{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	if !strings.Contains(csr, "BEGIN CERTIFICATE REQUEST") {
+		return ClientCertificate{}, fmt.Errorf(
+			"the CSR must be PEM encoded (BEGIN CERTIFICATE REQUEST)")
+	}
+
+	if validityDays < 0 {
+		return ClientCertificate{}, fmt.Errorf("validity may not be negative")
+	}
+	if validityDays == 0 {
+		validityDays = 3650
+	}
+
+	payload := struct {
+		CSR          string `json:"csr"`
+		ValidityDays int    `json:"validity_days"`
+	}{csr, validityDays}
+
+	var cert ClientCertificate
+	err := c.apiRequest("POST", clientCertificatePath(zoneID), nil, payload,
+		&cert)
+	if err != nil {
+		return ClientCertificate{}, fmt.Errorf(
+			"create client certificate error: %w", err)
+	}
+
+	return cert, nil
+}
+
+// ListClientCertificates lists a zone's client certificates. status may be
+// one of the ClientCertificate constants, or blank for all.
+func (c Client) ListClientCertificates(zoneID,
+	status string) ([]ClientCertificate, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	values := url.Values{}
+	if len(status) > 0 {
+		values.Set("status", status)
+	}
+
+	var certs []ClientCertificate
+	var err error
+	paginate(context.Background(), c, clientCertificatePath(zoneID), values,
+		50, "list client certificates",
+		func(cert ClientCertificate, e error) bool {
+			if e != nil {
+				err = e
+				return false
+			}
+			certs = append(certs, cert)
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return certs, nil
+}
+
+// GetClientCertificate retrieves a client certificate.
+func (c Client) GetClientCertificate(zoneID, certID string) (ClientCertificate,
+	error) {
+	if len(zoneID) == 0 || len(certID) == 0 {
+		return ClientCertificate{}, fmt.Errorf(
+			"you must provide a zone ID and certificate ID")
+	}
+
+	var cert ClientCertificate
+	err := c.apiRequest("GET", clientCertificatePath(zoneID)+"/"+
+		url.QueryEscape(certID), nil, nil, &cert)
+	if err != nil {
+		return ClientCertificate{}, fmt.Errorf(
+			"get client certificate error: %w", err)
+	}
+
+	return cert, nil
+}
+
+// RevokeClientCertificate revokes a client certificate. Revocation takes a
+// short time; the certificate is pending_revocation until then.
+func (c Client) RevokeClientCertificate(zoneID,
+	certID string) (ClientCertificate, error) {
+	return c.changeClientCertificate("DELETE", zoneID, certID, "revoke")
+}
+
+// RestoreClientCertificate undoes revoking a client certificate, making it
+// active again.
+func (c Client) RestoreClientCertificate(zoneID,
+	certID string) (ClientCertificate, error) {
+	return c.changeClientCertificate("PATCH", zoneID, certID, "restore")
+}
+
+func (c Client) changeClientCertificate(method, zoneID, certID,
+	operation string) (ClientCertificate, error) {
+	if len(zoneID) == 0 || len(certID) == 0 {
+		return ClientCertificate{}, fmt.Errorf(
+			"you must provide a zone ID and certificate ID")
+	}
+
+	var cert ClientCertificate
+	err := c.apiRequest(method, clientCertificatePath(zoneID)+"/"+
+		url.QueryEscape(certID), nil, nil, &cert)
+	if err != nil {
+		return ClientCertificate{}, fmt.Errorf(
+			"%s client certificate error: %w", operation, err)
+	}
+
+	return cert, nil
+}
@@ -3,20 +3,19 @@ package cloudflare
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
+	"mime/multipart"
 	"net/http"
 	"net/url"
-	"os"
-	"strings"
 	"time"
 )
 
-const endpoint = "https://api.cloudflare.com/client/v4/"
+const defaultEndpoint = "https://api.cloudflare.com/client/v4/"
 
 // Client holds the information necessary to interact with the API
 type Client struct {
@@ -26,10 +25,57 @@ type Client struct {
 	// Email is the email on your account
 	Email string
 
+	// Token is an API token. If it is set we use it rather than Key and Email.
+	Token string
+
+	// Credentials, if set, gives the API token for each request. We use it
+	// rather than Token, Key, and Email.
+	Credentials CredentialsProvider
+
 	// Enable debug output.
 	Debug bool
 
+	// Metrics, if set, receives measurements of each request.
+	Metrics Metrics
+
+	// RateLimiter, if set, limits how often we make requests.
+	RateLimiter *RateLimiter
+
+	// Recorder, if set, is told about each change we make.
+	Recorder Recorder
+
 	httpClient *http.Client
+	baseURL    string
+	retry      retryPolicy
+	logger     *log.Logger
+	cache      *responseCache
+	lastRay    *rayRecorder
+	userAgent  string
+
+	// providedTokens are the tokens Credentials gave us, to redact.
+	providedTokens *tokenRecorder
+
+	// accountID is the account to use when not given one. See WithAccount.
+	accountID string
+
+	// compressMinSize is the smallest request body we gzip. 0 means never.
+	compressMinSize int
+
+	// purgeDedup, if set, skips repeated purges of a zone.
+	purgeDedup *purgeDeduper
+
+	// stale, if set, holds records to fall back to if the API is down.
+	stale *staleRecords
+
+	// protection guards destructive operations unless force is set.
+	protection *Protection
+	force      bool
+
+	// dryRun means to log changes rather than make them.
+	dryRun bool
+
+	// httpSettings holds changes to httpClient until New makes them.
+	httpSettings *httpSettings
 }
 
 // Response holds generic portions of an API response
@@ -38,6 +84,25 @@ type Response struct {
 	Errors  []Error
 }
 
+// resultResponse is an API response along with its undecoded result.
+type resultResponse struct {
+	Response
+	Result     json.RawMessage `json:"result"`
+	ResultInfo ResultInfo      `json:"result_info"`
+}
+
+// ResultInfo holds the pagination information of a response. Count is how
+// many results are on this page and TotalCount how many there are on all
+// pages.
+type ResultInfo struct {
+	Page       int    `json:"page"`
+	PerPage    int    `json:"per_page"`
+	Count      int    `json:"count"`
+	TotalCount int    `json:"total_count"`
+	TotalPages int    `json:"total_pages"`
+	Cursor     string `json:"cursor"`
+}
+
 // Error holds a single error from an API response.
 type Error struct {
 	Code    int
@@ -46,22 +111,38 @@ type Error struct {
 
 // ListZoneResponse holds the top level List Zone response.
 type ListZoneResponse struct {
-	Success bool
-	Errors  []Error
-	Zones   []Zone `json:"result"`
+	Success    bool
+	Errors     []Error
+	ResultInfo ResultInfo `json:"result_info"`
+	Zones      []Zone     `json:"result"`
 }
 
 // Zone holds the result part of a List Zone response.
 type Zone struct {
-	ID   string
-	Name string
+	ID                  string   `json:"id"`
+	Name                string   `json:"name"`
+	Status              string   `json:"status"`
+	Paused              bool     `json:"paused"`
+	Type                string   `json:"type"`
+	NameServers         []string `json:"name_servers"`
+	OriginalNameServers []string `json:"original_name_servers"`
+	Plan                ZonePlan `json:"plan"`
+	CreatedOn           string   `json:"created_on"`
+	ModifiedOn          string   `json:"modified_on"`
+}
+
+// ZonePlan holds information about a zone's plan.
+type ZonePlan struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
 // ListDNSResponse holds the response from listing DNS records.
 type ListDNSResponse struct {
-	Success bool
-	Errors  []Error
-	Records []DNSRecord `json:"result"`
+	Success    bool
+	Errors     []Error
+	ResultInfo ResultInfo  `json:"result_info"`
+	Records    []DNSRecord `json:"result"`
 }
 
 // DNSRecord holds information about a single DNS record.
@@ -78,47 +159,440 @@ type DNSRecord struct {
 	ZoneName   string `json:"zone_name"`
 	CreatedOn  string `json:"created_on"`
 	ModifiedOn string `json:"modified_on"`
+
+	// Priority is the priority of MX and SRV records. Other records don't
+	// have one.
+	Priority *uint16 `json:"priority,omitempty"`
 }
 
 // NewClient creates an API client struct
+//
+// It authenticates with an API key. New is more flexible: this is the same as
+// New(WithKeyEmail(key, email)).
 func NewClient(key, email string) Client {
 	client := &http.Client{}
-	client.Timeout = time.Duration(60 * time.Second)
+	client.Timeout = defaultTimeout
 
 	return Client{
 		Key:        key,
 		Email:      email,
 		httpClient: client,
+		cache:      newResponseCache(),
+		lastRay:    &rayRecorder{},
+
+		providedTokens: &tokenRecorder{},
 	}
 }
 
 // request makes an API request.
 func (c Client) request(method, url string, bodyReader io.Reader) ([]byte,
 	error) {
-	req, err := http.NewRequest(method, url, bodyReader)
-	if err != nil {
-		return nil, fmt.Errorf("unable to create request: %s", err)
+	return c.requestContext(context.Background(), method, url, bodyReader)
+}
+
+// requestContext is request with a context.
+func (c Client) requestContext(ctx context.Context, method, url string,
+	bodyReader io.Reader) ([]byte, error) {
+	body, _, err := c.requestMetaContext(ctx, method, url, bodyReader)
+	return body, err
+}
+
+// responseMeta is information about a response other than its body.
+type responseMeta struct {
+	StatusCode int
+	RayID      string
+}
+
+// requestMetaContext is requestContext that also returns information about
+// the response.
+func (c Client) requestMetaContext(ctx context.Context, method, url string,
+	bodyReader io.Reader) ([]byte, responseMeta, error) {
+	if (c.Recorder == nil && !c.dryRun) || !c.isMutation(method, url) {
+		return c.doRequestMeta(ctx, method, url, bodyReader)
+	}
+
+	// Hold on to the payload to record or log it.
+	var request []byte
+	if bodyReader != nil {
+		var err error
+		request, err = ioutil.ReadAll(bodyReader)
+		if err != nil {
+			return nil, responseMeta{}, fmt.Errorf("unable to read request body: %w",
+				err)
+		}
+		if typed, ok := bodyReader.(*typedBody); ok {
+			bodyReader = newTypedBody(typed.contentType, request)
+		} else {
+			bodyReader = bytes.NewReader(request)
+		}
 	}
 
-	req.Header.Set("X-Auth-Email", c.Email)
-	req.Header.Set("X-Auth-Key", c.Key)
-	req.Header.Set("Content-Type", "application/json")
+	var before []byte
+	if c.Recorder != nil {
+		before = c.priorState(ctx, method, url)
+	}
 
-	resp, err := c.httpClient.Do(req)
+	start := time.Now()
+
+	var body []byte
+	var meta responseMeta
+	var err error
+	if c.dryRun {
+		body, meta = c.pretend(method, url, request)
+	} else {
+		body, meta, err = c.doRequestMeta(ctx, method, url, bodyReader)
+	}
+
+	if c.Recorder != nil {
+		c.recordMutation(start, method, url, before, request, body, meta, err)
+	}
+	return body, meta, err
+}
+
+// doRequestMeta makes the request for requestMetaContext.
+func (c Client) doRequestMeta(ctx context.Context, method, url string,
+	bodyReader io.Reader) ([]byte, responseMeta, error) {
+	resp, err := c.sendContext(ctx, method, url, bodyReader)
 	if err != nil {
-		return nil, fmt.Errorf("request problem: %s", err)
+		return nil, responseMeta{}, err
+	}
+
+	meta := responseMeta{
+		StatusCode: resp.StatusCode,
+		RayID:      resp.Header.Get(rayHeader),
 	}
 
 	body, err := ioutil.ReadAll(resp.Body)
 	err2 := resp.Body.Close()
 	if err != nil {
-		return nil, fmt.Errorf("unable to read body: %s", err)
+		return nil, meta, fmt.Errorf("unable to read body: %w", err)
 	}
 	if err2 != nil {
-		return nil, fmt.Errorf("problem closing body: %s", err2)
+		return nil, meta, fmt.Errorf("problem closing body: %w", err2)
+	}
+
+	return body, meta, nil
+}
+
+// send makes an API request and returns the response without reading its
+// body. The caller must close the body.
+//
+// If the client retries, we may make the request several times.
+func (c Client) send(method, url string, bodyReader io.Reader) (*http.Response,
+	error) {
+	return c.sendContext(context.Background(), method, url, bodyReader)
+}
+
+// sendContext is send with a context. Cancelling it stops the request and any
+// retries.
+func (c Client) sendContext(ctx context.Context, method, url string,
+	bodyReader io.Reader) (*http.Response, error) {
+	contentType := "application/json"
+	if typed, ok := bodyReader.(*typedBody); ok {
+		contentType = typed.contentType
+	}
+
+	// Hold on to the body so we can send it again.
+	var body []byte
+	if bodyReader != nil {
+		var err error
+		body, err = ioutil.ReadAll(bodyReader)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read request body: %w", err)
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.sendOnce(ctx, method, url, contentType, body)
+
+		delay, retry := c.retry.shouldRetry(method, resp, err, attempt)
+		if !retry {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		if rm, ok := c.Metrics.(RetryMetrics); ok {
+			rm.ObserveRetry(metricsEndpoint(method, c.endpoint(), url), attempt+1)
+		}
+
+		if c.Debug {
+			c.logf("%s %s: retrying in %s", method, url, delay)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("request problem: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// sendOnce makes a single attempt at a request.
+func (c Client) sendOnce(ctx context.Context, method, url, contentType string,
+	body []byte) (*http.Response, error) {
+	body, compressed, err := c.compressBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %w", err)
+	}
+
+	if compressed {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+
+	if len(c.userAgent) > 0 {
+		req.Header.Set("User-Agent", c.userAgent)
+	} else {
+		req.Header.Set("User-Agent", DefaultUserAgent)
+	}
+
+	err = c.setAuth(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	if c.RateLimiter != nil {
+		c.RateLimiter.Wait()
+	}
+
+	start := time.Now()
+
+	resp, err := c.httpClient.Do(req)
+
+	if c.Metrics != nil {
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		c.Metrics.ObserveRequest(metricsEndpoint(method, c.endpoint(), url),
+			status, time.Since(start))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("request problem: %w", err)
+	}
+
+	ray := resp.Header.Get(rayHeader)
+	c.lastRay.set(ray)
+	if c.Debug {
+		c.logf("%s %s: %s (ray %s)", method, url, resp.Status, ray)
+	}
+
+	err = decompressResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// endpoint is the base URL of the API.
+func (c Client) endpoint() string {
+	if len(c.baseURL) > 0 {
+		return c.baseURL
+	}
+	return defaultEndpoint
+}
+
+// logf writes debug output.
+func (c Client) logf(format string, args ...interface{}) {
+	message := c.redact(fmt.Sprintf(format, args...))
+	if c.logger != nil {
+		c.logger.Print(message)
+		return
+	}
+	log.Print(message)
+}
+
+// apiRequest makes an API request and decodes the response.
+//
+// path is relative to the API endpoint. values may be nil. If payload is not
+// nil we send it JSON encoded as the request body (or as is if it is a
+// *typedBody). If result is not nil we decode the result portion of the
+// response into it.
+func (c Client) apiRequest(method, path string, values url.Values, payload,
+	result interface{}) error {
+	_, err := c.apiRequestInfo(method, path, values, payload, result)
+	return err
+}
+
+// apiRequestInfo is apiRequest that also returns the response's pagination
+// information.
+func (c Client) apiRequestInfo(method, path string, values url.Values,
+	payload, result interface{}) (ResultInfo, error) {
+	return c.apiRequestContext(context.Background(), method, path, values,
+		payload, result)
+}
+
+// apiRequestContext is apiRequestInfo with a context.
+func (c Client) apiRequestContext(ctx context.Context, method, path string,
+	values url.Values, payload, result interface{}) (ResultInfo, error) {
+	url := c.endpoint() + path
+	if len(values) > 0 {
+		url += "?" + values.Encode()
+	}
+
+	var bodyReader io.Reader
+	var jsonPayload []byte
+	if typed, ok := payload.(*typedBody); ok {
+		bodyReader = typed
+	} else if payload != nil {
+		var err error
+		jsonPayload, err = json.Marshal(payload)
+		if err != nil {
+			return ResultInfo{}, fmt.Errorf("unable to encode to JSON: %w", err)
+		}
+		bodyReader = bytes.NewReader(jsonPayload)
+	}
+
+	body, meta, err := c.requestMetaContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return ResultInfo{}, fmt.Errorf("API request failure: %w", err)
+	}
+
+	var response resultResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		// Some failures, such as from a proxy in front of the API, don't have
+		// the usual envelope.
+		if meta.StatusCode >= 400 {
+			return ResultInfo{}, fmt.Errorf("%w: %s", errorsToError(meta, nil),
+				c.redact(string(body)))
+		}
+		return ResultInfo{}, fmt.Errorf("JSON decoding problem: %s: %s", err,
+			c.redact(string(body)))
+	}
+
+	if c.Debug {
+		c.logf("%s %s (ray %s): %s", method, path, meta.RayID, body)
+	}
+
+	if !response.Success {
+		apiErr := errorsToError(meta, response.Errors)
+		if jsonPayload != nil {
+			return ResultInfo{}, fmt.Errorf("%w. Payload: %s", apiErr,
+				c.redact(string(jsonPayload)))
+		}
+		return ResultInfo{}, apiErr
+	}
+
+	if result == nil || len(response.Result) == 0 {
+		return response.ResultInfo, nil
+	}
+
+	err = json.Unmarshal(response.Result, result)
+	if err != nil {
+		return ResultInfo{}, fmt.Errorf("JSON decoding problem: %w", err)
+	}
+
+	return response.ResultInfo, nil
+}
+
+// typedBody is a request body that is not JSON, such as a multipart form.
+// Pass it as the payload to apiRequest to send it as is.
+type typedBody struct {
+	*bytes.Reader
+	contentType string
+}
+
+// newTypedBody creates a typedBody.
+func newTypedBody(contentType string, body []byte) *typedBody {
+	return &typedBody{Reader: bytes.NewReader(body), contentType: contentType}
+}
+
+// newModuleUpload builds the multipart form the API takes for uploading
+// JavaScript modules, such as Workers and Snippets. metadata describes the
+// upload and is sent JSON encoded. The code goes in a file named fileName.
+func newModuleUpload(metadata interface{}, fileName,
+	code string) (*typedBody, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	jsonMetadata, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode to JSON: %w", err)
+	}
+	err = writer.WriteField("metadata", string(jsonMetadata))
+	if err != nil {
+		return nil, fmt.Errorf("unable to build form: %w", err)
+	}
+
+	part, err := writer.CreatePart(map[string][]string{
+		"Content-Disposition": {
+			fmt.Sprintf(`form-data; name=%q; filename=%q`, fileName, fileName),
+		},
+		"Content-Type": {"application/javascript+module"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to build form: %w", err)
+	}
+	_, err = io.WriteString(part, code)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build form: %w", err)
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, fmt.Errorf("unable to build form: %w", err)
 	}
 
-	return body, nil
+	return newTypedBody(writer.FormDataContentType(), buf.Bytes()), nil
+}
+
+// newFileUpload builds a multipart form holding a file along with other
+// fields.
+func newFileUpload(fileName string, contents []byte,
+	fields map[string]string) (*typedBody, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	for name, value := range fields {
+		err := writer.WriteField(name, value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to build form: %w", err)
+		}
+	}
+
+	part, err := writer.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build form: %w", err)
+	}
+	_, err = part.Write(contents)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build form: %w", err)
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, fmt.Errorf("unable to build form: %w", err)
+	}
+
+	return newTypedBody(writer.FormDataContentType(), buf.Bytes()), nil
+}
+
+// zonePrefix is the path of a zone's endpoints.
+func zonePrefix(zoneID string) string {
+	return "zones/" + url.QueryEscape(zoneID)
+}
+
+// accountPrefix is the path of an account's endpoints.
+func accountPrefix(accountID string) string {
+	return "accounts/" + url.QueryEscape(accountID)
 }
 
 // ListZones makes an API request to list zones.
@@ -138,8 +612,19 @@ func (c Client) request(method, url string, bodyReader io.Reader) ([]byte,
 //
 // Any string parameter, if blank, will use the default. Any integer parameter
 // if negative will use the default.
+//
+// ListZonesPage also says how many pages there are.
 func (c Client) ListZones(name, status string, page, perPage int,
 	order, direction, match string) ([]Zone, error) {
+	zones, _, err := c.ListZonesPage(name, status, page, perPage, order,
+		direction, match)
+	return zones, err
+}
+
+// ListZonesPage is ListZones that also returns the page's pagination
+// information, such as how many pages there are in total.
+func (c Client) ListZonesPage(name, status string, page, perPage int,
+	order, direction, match string) ([]Zone, ResultInfo, error) {
 	values := url.Values{}
 
 	if len(name) > 0 {
@@ -172,25 +657,48 @@ func (c Client) ListZones(name, status string, page, perPage int,
 		values.Add("match", match)
 	}
 
-	url := fmt.Sprintf("%szones?%s", endpoint, values.Encode())
+	url := fmt.Sprintf("%szones?%s", c.endpoint(), values.Encode())
 
-	body, err := c.request("GET", url, nil)
+	body, meta, err := c.requestMetaContext(context.Background(),
+		"GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("API request failure: %s", err)
+		return nil, ResultInfo{}, fmt.Errorf("API request failure: %w", err)
 	}
 
 	var zoneResponse ListZoneResponse
 	err = json.Unmarshal(body, &zoneResponse)
 	if err != nil {
-		return nil, fmt.Errorf("JSON decoding problem: %s", err)
+		return nil, ResultInfo{}, fmt.Errorf("JSON decoding problem: %w", err)
 	}
 
 	if !zoneResponse.Success {
-		return nil, fmt.Errorf("list zone error: %s",
-			errorsToError(zoneResponse.Errors))
+		return nil, ResultInfo{}, fmt.Errorf("list zone error: %w",
+			errorsToError(meta, zoneResponse.Errors))
 	}
 
-	return zoneResponse.Zones, nil
+	return zoneResponse.Zones, zoneResponse.ResultInfo, nil
+}
+
+// ListAllZones retrieves every zone on the account.
+//
+// It requests each page in turn until there are no more. name and status
+// filter as with ListZones(). If status is blank we list active zones.
+func (c Client) ListAllZones(name, status string) ([]Zone, error) {
+	perPage := 50
+	allZones := []Zone{}
+
+	for page := 1; ; page++ {
+		zones, err := c.ListZones(name, status, page, perPage, "", "", "")
+		if err != nil {
+			return nil, err
+		}
+
+		allZones = append(allZones, zones...)
+
+		if len(zones) < perPage {
+			return allZones, nil
+		}
+	}
 }
 
 // ListDNSRecords makes an API request for DNS records.
@@ -209,10 +717,23 @@ func (c Client) ListZones(name, status string, page, perPage int,
 //
 // If a string is empty we will use the default. If an integer is negative
 // we will use the default.
+//
+// ListDNSRecordsPage also says how many pages there are.
 func (c Client) ListDNSRecords(zoneID, recordType, name, content string, page,
 	perPage int, order, direction, match string) ([]DNSRecord, error) {
+	records, _, err := c.ListDNSRecordsPage(zoneID, recordType, name, content,
+		page, perPage, order, direction, match)
+	return records, err
+}
+
+// ListDNSRecordsPage is ListDNSRecords that also returns the page's
+// pagination information, such as how many pages there are in total.
+func (c Client) ListDNSRecordsPage(zoneID, recordType, name, content string,
+	page, perPage int, order, direction, match string) ([]DNSRecord, ResultInfo,
+	error) {
 	if len(zoneID) == 0 {
-		return nil, fmt.Errorf("you must provide a zone ID. Use ListZones() to find one")
+		return nil, ResultInfo{}, fmt.Errorf(
+			"you must provide a zone ID. Use ListZones() to find one")
 	}
 
 	values := url.Values{}
@@ -241,26 +762,134 @@ func (c Client) ListDNSRecords(zoneID, recordType, name, content string, page,
 		values.Set("match", match)
 	}
 
-	url := fmt.Sprintf("%szones/%s/dns_records?%s", endpoint,
+	url := fmt.Sprintf("%szones/%s/dns_records?%s", c.endpoint(),
 		url.QueryEscape(zoneID), values.Encode())
 
-	body, err := c.request("GET", url, nil)
+	body, meta, err := c.requestMetaContext(context.Background(),
+		"GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("API request failure: %s", err)
+		return nil, ResultInfo{}, fmt.Errorf("API request failure: %w", err)
 	}
 
 	var dnsResponse ListDNSResponse
 	err = json.Unmarshal(body, &dnsResponse)
 	if err != nil {
-		return nil, fmt.Errorf("JSON decoding problem: %s", err)
+		return nil, ResultInfo{}, fmt.Errorf("JSON decoding problem: %w", err)
 	}
 
 	if !dnsResponse.Success {
-		return nil, fmt.Errorf("list DNS records error: %s",
-			errorsToError(dnsResponse.Errors))
+		return nil, ResultInfo{}, fmt.Errorf("list DNS records error: %w",
+			errorsToError(meta, dnsResponse.Errors))
 	}
 
-	return dnsResponse.Records, nil
+	return dnsResponse.Records, dnsResponse.ResultInfo, nil
+}
+
+// ListAllDNSRecords retrieves every DNS record in a zone.
+//
+// It requests each page in turn until there are no more. recordType and name
+// may be blank to not filter on them.
+//
+// If the client was created with WithCache we may return what an earlier
+// call found. With WithStaleOnError we may return earlier records if the API
+// is unavailable.
+func (c Client) ListAllDNSRecords(zoneID, recordType, name string) ([]DNSRecord,
+	error) {
+	cacheKey := recordsCacheKey(zoneID) + recordType + ":" + name
+	if c.cache.cachesRecords() {
+		if records, ok := c.cache.get(cacheKey); ok {
+			c.stale.markFresh()
+			return append([]DNSRecord{}, records.([]DNSRecord)...), nil
+		}
+	}
+
+	perPage := 100
+	allRecords := []DNSRecord{}
+
+	for page := 1; ; page++ {
+		records, err := c.ListDNSRecords(zoneID, recordType, name, "", page,
+			perPage, "", "", "")
+		if err != nil {
+			if stale, fetched, ok := c.stale.fallback(cacheKey, err); ok {
+				c.logf("using DNS records fetched at %s: %s",
+					fetched.Format(time.RFC3339), err)
+				return stale, nil
+			}
+			return nil, err
+		}
+
+		allRecords = append(allRecords, records...)
+
+		if len(records) < perPage {
+			if c.cache.cachesRecords() {
+				c.cache.set(cacheKey, append([]DNSRecord{}, allRecords...))
+			}
+			c.stale.remember(cacheKey, allRecords)
+			return allRecords, nil
+		}
+	}
+}
+
+// CreatedTime parses the record's CreatedOn time.
+func (r DNSRecord) CreatedTime() (time.Time, error) {
+	return parseTime(r.CreatedOn)
+}
+
+// ModifiedTime parses the record's ModifiedOn time.
+func (r DNSRecord) ModifiedTime() (time.Time, error) {
+	return parseTime(r.ModifiedOn)
+}
+
+// FilterDNSRecordsByModified returns the records last modified within the
+// given window.
+//
+// A zero since or until leaves that side of the window open. For example, to
+// find records changed in the last day, pass time.Now().Add(-24*time.Hour)
+// and a zero until.
+func FilterDNSRecordsByModified(records []DNSRecord, since,
+	until time.Time) ([]DNSRecord, error) {
+	return filterDNSRecordsByTime(records, since, until, DNSRecord.ModifiedTime)
+}
+
+// FilterDNSRecordsByCreated returns the records created within the given
+// window.
+//
+// A zero since or until leaves that side of the window open.
+func FilterDNSRecordsByCreated(records []DNSRecord, since,
+	until time.Time) ([]DNSRecord, error) {
+	return filterDNSRecordsByTime(records, since, until, DNSRecord.CreatedTime)
+}
+
+func filterDNSRecordsByTime(records []DNSRecord, since, until time.Time,
+	getTime func(DNSRecord) (time.Time, error)) ([]DNSRecord, error) {
+	matches := []DNSRecord{}
+
+	for _, record := range records {
+		t, err := getTime(record)
+		if err != nil {
+			return nil, fmt.Errorf("record %s: %w", record.ID, err)
+		}
+
+		if !since.IsZero() && t.Before(since) {
+			continue
+		}
+		if !until.IsZero() && !t.Before(until) {
+			continue
+		}
+
+		matches = append(matches, record)
+	}
+
+	return matches, nil
+}
+
+// Times in API responses look like 2014-01-01T05:20:00.12345Z.
+func parseTime(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time: %s: %w", s, err)
+	}
+	return t, nil
 }
 
 // UpdateDNSRecord updates a record.
@@ -276,44 +905,203 @@ func (c Client) ListDNSRecords(zoneID, recordType, name, content string, page,
 // CreatedOn
 // ModifiedOn
 func (c Client) UpdateDNSRecord(record DNSRecord) error {
+	err := ValidateDNSRecord(record)
+	if err != nil {
+		return err
+	}
+
 	jsonPayload, err := json.Marshal(record)
 	if err != nil {
-		return fmt.Errorf("unable to encode to JSON: %s", err)
+		return fmt.Errorf("unable to encode to JSON: %w", err)
 	}
 
-	url := fmt.Sprintf("%szones/%s/dns_records/%s", endpoint,
+	url := fmt.Sprintf("%szones/%s/dns_records/%s", c.endpoint(),
 		url.QueryEscape(record.ZoneID), url.QueryEscape(record.ID))
 
 	bodyReader := bytes.NewReader(jsonPayload)
 
-	body, err := c.request("PUT", url, bodyReader)
+	body, meta, err := c.requestMetaContext(context.Background(),
+		"PUT", url, bodyReader)
+	c.cache.forgetPrefix(recordsCacheKey(record.ZoneID))
 	if err != nil {
-		return fmt.Errorf("API request failure: %s", err)
+		return fmt.Errorf("API request failure: %w", err)
 	}
 
 	var response Response
 	err = json.Unmarshal(body, &response)
 	if err != nil {
-		return fmt.Errorf("JSON decoding problem: %s: %s", err, body)
+		return fmt.Errorf("JSON decoding problem: %s: %s", err,
+			c.redact(string(body)))
 	}
 
 	if !response.Success {
-		return fmt.Errorf("update DNS record error: %s. Payload: %s",
-			errorsToError(response.Errors), jsonPayload)
+		return fmt.Errorf("update DNS record error: %w. Payload: %s",
+			errorsToError(meta, response.Errors), c.redact(string(jsonPayload)))
 	}
 
 	return nil
 }
 
+// GetDNSRecord retrieves a record. We always ask the API, even if the client
+// caches records.
+func (c Client) GetDNSRecord(zoneID, recordID string) (DNSRecord, error) {
+	if len(zoneID) == 0 || len(recordID) == 0 {
+		return DNSRecord{}, fmt.Errorf("you must provide a zone ID and record ID")
+	}
+
+	var record DNSRecord
+	err := c.apiRequest("GET", zonePrefix(zoneID)+"/dns_records/"+
+		url.QueryEscape(recordID), nil, nil, &record)
+	if err != nil {
+		return DNSRecord{}, fmt.Errorf("get DNS record error: %w", err)
+	}
+
+	return record, nil
+}
+
+// UpdateDNSRecordIfUnchanged is UpdateDNSRecord except it first checks the
+// record has not changed since the caller read it. This stops two programs
+// updating the same record from undoing each other's changes.
+//
+// The record's ModifiedOn must be as we gave it to the caller. If the record
+// was modified since, we make no change and return a *ConflictError holding
+// the record as it is now. We also forget any of the zone's records we
+// remember (see WithCache), as they may be out of date too.
+//
+// The API has no conditional update, so there is a brief window between
+// checking and updating where another change could still be lost.
+func (c Client) UpdateDNSRecordIfUnchanged(record DNSRecord) error {
+	if len(record.ModifiedOn) == 0 {
+		return fmt.Errorf(
+			"record has no modified time. Retrieve it from the API before updating it")
+	}
+
+	current, err := c.GetDNSRecord(record.ZoneID, record.ID)
+	if err != nil {
+		return err
+	}
+
+	if current.ModifiedOn != record.ModifiedOn {
+		// Whatever we remember about the zone's records is out of date too.
+		c.cache.forgetPrefix(recordsCacheKey(record.ZoneID))
+		current.ZoneID = record.ZoneID
+		return &ConflictError{Current: current, Expected: record.ModifiedOn}
+	}
+
+	return c.UpdateDNSRecord(record)
+}
+
+// CreateDNSRecord creates a record.
+//
+// Set Type, Name, Content, TTL, and Proxied. The other fields are ignored
+// except ZoneID which must be set. A TTL of 1 means automatic.
+//
+// We return the record as created.
+func (c Client) CreateDNSRecord(record DNSRecord) (DNSRecord, error) {
+	if len(record.ZoneID) == 0 {
+		return DNSRecord{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	err := ValidateDNSRecord(record)
+	if err != nil {
+		return DNSRecord{}, err
+	}
+
+	type CreatePayload struct {
+		Type     string  `json:"type"`
+		Name     string  `json:"name"`
+		Content  string  `json:"content"`
+		TTL      int     `json:"ttl"`
+		Proxied  bool    `json:"proxied"`
+		Priority *uint16 `json:"priority,omitempty"`
+	}
+
+	payload := CreatePayload{
+		Type:     record.Type,
+		Name:     record.Name,
+		Content:  record.Content,
+		TTL:      record.TTL,
+		Proxied:  record.Proxied,
+		Priority: record.Priority,
+	}
+
+	if payload.TTL <= 0 {
+		payload.TTL = TTLAutomatic
+	}
+
+	var created DNSRecord
+	err = c.apiRequest("POST", fmt.Sprintf("zones/%s/dns_records",
+		url.QueryEscape(record.ZoneID)), nil, payload, &created)
+	c.cache.forgetPrefix(recordsCacheKey(record.ZoneID))
+	if err != nil {
+		return DNSRecord{}, fmt.Errorf("create DNS record error: %w", err)
+	}
+
+	return created, nil
+}
+
+// DeleteDNSRecord deletes a record.
+//
+// The record's ZoneID and ID must be set. Typically you find it with
+// ListDNSRecords().
+//
+// A Protection policy refuses this unless forced.
+func (c Client) DeleteDNSRecord(record DNSRecord) error {
+	if len(record.ZoneID) == 0 || len(record.ID) == 0 {
+		return fmt.Errorf("you must provide a zone ID and record ID")
+	}
+
+	detail := fmt.Sprintf("%s %s %s", record.Name, record.Type, record.Content)
+
+	return c.guard(OperationDeleteDNSRecord, record.ID, detail, func() error {
+		err := c.apiRequest("DELETE", fmt.Sprintf("zones/%s/dns_records/%s",
+			url.QueryEscape(record.ZoneID), url.QueryEscape(record.ID)), nil, nil,
+			nil)
+		c.cache.forgetPrefix(recordsCacheKey(record.ZoneID))
+		if err != nil {
+			return fmt.Errorf("delete DNS record error: %w", err)
+		}
+
+		return nil
+	})
+}
+
 // PurgeAllFiles purges all of the files from Cloudflare's cache for the
 // given zone.
 //
 // To find the zone ID, refer to ListAllZone().
+//
+// A Protection policy refuses this unless forced. With WithPurgeDedup, we
+// skip purging a zone we purged recently.
 func (c Client) PurgeAllFiles(zoneID string) error {
 	if zoneID == "" {
 		return fmt.Errorf("you must provide a zone ID")
 	}
 
+	return c.guard(OperationPurgeEverything, zoneID, "", func() error {
+		// Pretending to purge should not stop a real purge.
+		if c.dryRun {
+			return c.purgeAllFiles(zoneID)
+		}
+
+		run, ok := c.purgeDedup.start(zoneID)
+		if !ok {
+			err := run.wait()
+			if err != nil {
+				return err
+			}
+			c.logf("skipping purge of zone %s: already purged at %s", zoneID,
+				run.started.Format(time.RFC3339))
+			return nil
+		}
+
+		err := c.purgeAllFiles(zoneID)
+		c.purgeDedup.done(zoneID, run, err)
+		return err
+	})
+}
+
+func (c Client) purgeAllFiles(zoneID string) error {
 	type PurgePayload struct {
 		PurgeEverything bool `json:"purge_everything"`
 	}
@@ -322,77 +1110,106 @@ func (c Client) PurgeAllFiles(zoneID string) error {
 
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("unable to build JSON: %s", err)
+		return fmt.Errorf("unable to build JSON: %w", err)
 	}
 
-	url := fmt.Sprintf("%szones/%s/purge_cache", endpoint,
+	url := fmt.Sprintf("%szones/%s/purge_cache", c.endpoint(),
 		url.QueryEscape(zoneID))
 
 	bodyReader := bytes.NewReader(jsonPayload)
 
-	body, err := c.request("DELETE", url, bodyReader)
+	body, meta, err := c.requestMetaContext(context.Background(),
+		"DELETE", url, bodyReader)
 	if err != nil {
-		return fmt.Errorf("API request failure: %s", err)
+		return fmt.Errorf("API request failure: %w", err)
 	}
 
 	var response Response
 	err = json.Unmarshal(body, &response)
 	if err != nil {
-		return fmt.Errorf("JSON decoding problem: %s: %s", err, body)
+		return fmt.Errorf("JSON decoding problem: %s: %s", err,
+			c.redact(string(body)))
 	}
 
 	if c.Debug {
-		log.Printf("%v", response)
+		c.logf("%v", response)
 	}
 
 	if !response.Success {
-		return fmt.Errorf("purge error: %s. Payload: %s",
-			errorsToError(response.Errors), jsonPayload)
+		return fmt.Errorf("purge error: %w. Payload: %s",
+			errorsToError(meta, response.Errors), c.redact(string(jsonPayload)))
 	}
 
 	return nil
 }
 
-// ReadKeyFromFile reads an API key from a given file.
+// The most items we may send in a single selective purge request.
+const maxPurgeItems = 30
+
+// PurgeFiles purges the given URLs from Cloudflare's cache for the zone.
 //
-// The file should contain nothing other than the API key.
-func ReadKeyFromFile(keyFile string) (string, error) {
-	fh, err := os.Open(keyFile)
-	if err != nil {
-		return "", err
-	}
-	defer func() {
-		err := fh.Close()
-		if err != nil {
-			log.Printf("close: %s: %s", keyFile, err)
-		}
-	}()
+// URLs should be complete, e.g. https://www.example.com/style.css. If there
+// are more than the API accepts in one request, we make several.
+func (c Client) PurgeFiles(zoneID string, urls []string) error {
+	return c.purgeItems(zoneID, "files", urls)
+}
 
-	content, err := ioutil.ReadAll(fh)
-	if err != nil {
-		return "", fmt.Errorf("problem reading from file: %s", err)
-	}
+// PurgePrefixes purges everything under the given URL prefixes from
+// Cloudflare's cache for the zone.
+//
+// Prefixes should not include a scheme, e.g. www.example.com/images.
+func (c Client) PurgePrefixes(zoneID string, prefixes []string) error {
+	return c.purgeItems(zoneID, "prefixes", prefixes)
+}
 
-	key := strings.TrimSpace(string(content))
+// PurgeTags purges the files with the given Cache-Tag values from
+// Cloudflare's cache for the zone.
+func (c Client) PurgeTags(zoneID string, tags []string) error {
+	return c.purgeItems(zoneID, "tags", tags)
+}
+
+// PurgeHosts purges the files served for the given hostnames from
+// Cloudflare's cache for the zone.
+func (c Client) PurgeHosts(zoneID string, hosts []string) error {
+	return c.purgeItems(zoneID, "hosts", hosts)
+}
+
+// purgeItems makes selective purge requests. kind is the payload key (files,
+// tags, hosts, prefixes).
+func (c Client) purgeItems(zoneID, kind string, items []string) error {
+	if zoneID == "" {
+		return fmt.Errorf("you must provide a zone ID")
+	}
 
-	if len(key) == 0 {
-		return "", fmt.Errorf("no key found in file")
+	if len(items) == 0 {
+		return fmt.Errorf("you must provide at least one item to purge")
 	}
 
-	return key, nil
-}
+	path := fmt.Sprintf("zones/%s/purge_cache", url.QueryEscape(zoneID))
+
+	for start := 0; start < len(items); start += maxPurgeItems {
+		end := start + maxPurgeItems
+		if end > len(items) {
+			end = len(items)
+		}
 
-// We can get back multiple errors from the API. Concatenate them together
-// for ease of return.
-func errorsToError(apiErrors []Error) error {
-	msg := ""
+		payload := map[string][]string{kind: items[start:end]}
 
-	for _, err := range apiErrors {
-		if len(msg) > 0 {
-			msg += ", "
+		err := c.apiRequest("POST", path, nil, payload, nil)
+		if err != nil {
+			return fmt.Errorf("purge error: %w", err)
 		}
-		msg += fmt.Sprintf("Code %d: %s", err.Code, err.Message)
 	}
 
-	return errors.New(msg)
+	return nil
+}
+
+// We can get back multiple errors from the API. Return them together as an
+// APIError along with the response's HTTP status and ray ID.
+func errorsToError(meta responseMeta, apiErrors []Error) error {
+	return &APIError{
+		StatusCode: meta.StatusCode,
+		RayID:      meta.RayID,
+		Errors:     apiErrors,
+	}
 }
@@ -0,0 +1,85 @@
+// cf is a single program providing all of the commands as subcommands.
+//
+// For example:
+//
+//	cf dns update -hostname home.example.com -domain example.com ...
+//	cf cache purge -domain example.com ...
+//	cf zones list ...
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/horgh/cloudflare/internal/cli"
+	"github.com/horgh/cloudflare/internal/cli/dnssync"
+	"github.com/horgh/cloudflare/internal/cli/ipupdate"
+	"github.com/horgh/cloudflare/internal/cli/purge"
+	"github.com/horgh/cloudflare/internal/cli/records"
+	"github.com/horgh/cloudflare/internal/cli/recordset"
+	"github.com/horgh/cloudflare/internal/cli/smoke"
+	"github.com/horgh/cloudflare/internal/cli/stats"
+	"github.com/horgh/cloudflare/internal/cli/zones"
+)
+
+// command is a subcommand, e.g. "dns update".
+type command struct {
+	group       string
+	action      string
+	description string
+	run         func(name string, args []string) int
+}
+
+var commands = []command{
+	{"cache", "purge", "Purge cached files for domains.", purge.Run},
+	{"dns", "list", "List DNS records of a domain.", records.Run},
+	{"dns", "set", "Set a DNS record of any type.", recordset.Run},
+	{"dns", "sync", "Make DNS records match a file.", dnssync.Run},
+	{"dns", "update", "Update an A/AAAA record to the current IP.", ipupdate.Run},
+	{"stats", "show", "Show a summary of a domain's traffic.", stats.Run},
+	{"test", "smoke", "Smoke test the API against a test zone.", smoke.Run},
+	{"zones", "list", "List zones on the account.", zones.Run},
+}
+
+func main() {
+	log.SetFlags(0)
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	if len(args) == 1 && cli.HandleVersion("cf", args) {
+		return cli.ExitOK
+	}
+
+	if len(args) < 2 {
+		usage()
+		return cli.ExitUsage
+	}
+
+	for _, cmd := range commands {
+		if cmd.group == args[0] && cmd.action == args[1] {
+			name := fmt.Sprintf("cf %s %s", cmd.group, cmd.action)
+			return cmd.run(name, args[2:])
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "cf: unknown command: %s %s\n", args[0], args[1])
+	usage()
+	return cli.ExitUsage
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: cf <command> <subcommand> [flags]\n\n")
+	fmt.Fprintf(os.Stderr, "Commands:\n")
+
+	w := tabwriter.NewWriter(os.Stderr, 0, 0, 2, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %s %s\t%s\n", cmd.group, cmd.action, cmd.description)
+	}
+	_ = w.Flush()
+
+	fmt.Fprintf(os.Stderr, "\nRun a command with -h to see its flags. "+
+		"cf -version prints the version.\n")
+}
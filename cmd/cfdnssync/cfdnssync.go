@@ -0,0 +1,16 @@
+// cfdnssync makes a zone's DNS records match a file describing them.
+//
+// It is the same as "cf dns sync".
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/horgh/cloudflare/internal/cli/dnssync"
+)
+
+func main() {
+	log.SetFlags(0)
+	os.Exit(dnssync.Run("cfdnssync", os.Args[1:]))
+}
@@ -0,0 +1,16 @@
+// cfrecords lists the DNS records of a Cloudflare domain.
+//
+// It is the same as "cf dns list".
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/horgh/cloudflare/internal/cli/records"
+)
+
+func main() {
+	log.SetFlags(0)
+	os.Exit(records.Run("cfrecords", os.Args[1:]))
+}
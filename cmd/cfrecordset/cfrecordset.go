@@ -0,0 +1,16 @@
+// cfrecordset sets a DNS record of any type to the given content.
+//
+// It is the same as "cf dns set".
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/horgh/cloudflare/internal/cli/recordset"
+)
+
+func main() {
+	log.SetFlags(0)
+	os.Exit(recordset.Run("cfrecordset", os.Args[1:]))
+}
@@ -0,0 +1,16 @@
+// cfsmoke runs a smoke test against a Cloudflare zone set aside for testing.
+//
+// It is the same as "cf test smoke".
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/horgh/cloudflare/internal/cli/smoke"
+)
+
+func main() {
+	log.SetFlags(0)
+	os.Exit(smoke.Run("cfsmoke", os.Args[1:]))
+}
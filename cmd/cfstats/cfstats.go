@@ -0,0 +1,16 @@
+// cfstats prints a summary of a domain's recent traffic.
+//
+// It is the same as "cf stats show".
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/horgh/cloudflare/internal/cli/stats"
+)
+
+func main() {
+	log.SetFlags(0)
+	os.Exit(stats.Run("cfstats", os.Args[1:]))
+}
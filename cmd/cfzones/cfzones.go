@@ -0,0 +1,16 @@
+// cfzones lists the zones on a Cloudflare account.
+//
+// It is the same as "cf zones list".
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/horgh/cloudflare/internal/cli/zones"
+)
+
+func main() {
+	log.SetFlags(0)
+	os.Exit(zones.Run("cfzones", os.Args[1:]))
+}
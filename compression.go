@@ -0,0 +1,99 @@
+package cloudflare
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// WithRequestCompression gzips request bodies of at least minSize bytes, such
+// as large zone file imports or bulk writes. Not every endpoint accepts
+// compressed bodies, so this is off by default.
+func WithRequestCompression(minSize int) Option {
+	return func(c *Client) error {
+		if minSize <= 0 {
+			return fmt.Errorf("minimum size must be positive")
+		}
+		c.compressMinSize = minSize
+		return nil
+	}
+}
+
+// compressBody gzips a request body if the client is set to and it is large
+// enough. We return the body to send and whether we compressed it.
+func (c Client) compressBody(body []byte) ([]byte, bool, error) {
+	if c.compressMinSize <= 0 || len(body) < c.compressMinSize {
+		return body, false, nil
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+
+	_, err := w.Write(body)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to compress body: %w", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to compress body: %w", err)
+	}
+
+	return buf.Bytes(), true, nil
+}
+
+// decompressResponse replaces the response's body with a decompressed one if
+// the server compressed it.
+//
+// We ask for compressed responses ourselves rather than leaving it to
+// net/http, as an HTTP client given with WithHTTPClient may not.
+func decompressResponse(resp *http.Response) error {
+	var reader io.ReadCloser
+	var err error
+
+	switch strings.ToLower(resp.Header.Get("Content-Encoding")) {
+	case "gzip":
+		reader, err = gzip.NewReader(resp.Body)
+	case "deflate":
+		reader, err = zlib.NewReader(resp.Body)
+	default:
+		return nil
+	}
+
+	if err != nil {
+		_ = resp.Body.Close()
+		return fmt.Errorf("unable to decompress response: %w", err)
+	}
+
+	resp.Body = decompressedBody{reader: reader, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+
+	return nil
+}
+
+// decompressedBody reads through a decompressor and closes both it and the
+// original body.
+type decompressedBody struct {
+	reader io.ReadCloser
+	body   io.ReadCloser
+}
+
+func (d decompressedBody) Read(p []byte) (int, error) {
+	return d.reader.Read(p)
+}
+
+func (d decompressedBody) Close() error {
+	err := d.reader.Close()
+	err2 := d.body.Close()
+	if err != nil {
+		return err
+	}
+	return err2
+}
@@ -0,0 +1,229 @@
+// Package config loads settings shared by the command line programs.
+//
+// The configuration file holds one or more named profiles. Each profile
+// supplies the credentials and a default domain. The credentials are either
+// an API token (token, token_file, or token_command) or the account email
+// and API key (key or key_file). The format is INI-like and is also valid for
+// simple TOML:
+//
+//	[default]
+//	email = "me@example.com"
+//	key_file = "/home/me/.cloudflare-key"
+//	domain = "example.com"
+//
+//	[work]
+//	token_file = "~/.cloudflare-token"
+//	domain = "example.org"
+//
+// Blank lines and lines starting with # or ; are ignored.
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultProfile is the profile used when no name is given.
+const DefaultProfile = "default"
+
+// Profile holds the settings of a single profile.
+type Profile struct {
+	// Name is the name of the profile (its section name).
+	Name string
+
+	// Email is the email on your account.
+	Email string
+
+	// Key is the API key. If it is blank, KeyFile should be set.
+	Key string
+
+	// KeyFile is a path to a file containing the API key.
+	KeyFile string
+
+	// Token is an API token. If it is set, Email and Key are not needed.
+	Token string
+
+	// TokenFile is a path to a file containing an API token.
+	TokenFile string
+
+	// TokenCommand is a command that outputs an API token, such as
+	// "pass show cf-token".
+	TokenCommand string
+
+	// Domain is the default domain (zone) to operate on.
+	Domain string
+}
+
+// DefaultPath returns the default location of the configuration file. This
+// is $XDG_CONFIG_HOME/cloudflare/config, or ~/.config/cloudflare/config if
+// XDG_CONFIG_HOME is not set. We use this on every system, including macOS.
+func DefaultPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if !filepath.IsAbs(dir) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("unable to determine config directory: %w",
+				err)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(dir, "cloudflare", "config"), nil
+}
+
+// Load reads all profiles from the given file.
+//
+// If file is blank we use DefaultPath().
+func Load(file string) (map[string]Profile, error) {
+	if file == "" {
+		path, err := DefaultPath()
+		if err != nil {
+			return nil, err
+		}
+		file = path
+	}
+
+	fh, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := fh.Close()
+		if err != nil {
+			log.Printf("close: %s: %s", file, err)
+		}
+	}()
+
+	profiles := map[string]Profile{}
+	var current *Profile
+	lineNumber := 0
+
+	scanner := bufio.NewScanner(fh)
+
+	for scanner.Scan() {
+		lineNumber++
+
+		text := strings.TrimSpace(scanner.Text())
+		if len(text) == 0 || text[0] == '#' || text[0] == ';' {
+			continue
+		}
+
+		if text[0] == '[' {
+			if text[len(text)-1] != ']' {
+				return nil, fmt.Errorf("%s:%d: malformed section header", file,
+					lineNumber)
+			}
+
+			name := unquote(strings.TrimSpace(text[1 : len(text)-1]))
+			if len(name) == 0 {
+				return nil, fmt.Errorf("%s:%d: blank profile name", file, lineNumber)
+			}
+
+			if current != nil {
+				profiles[current.Name] = *current
+			}
+
+			current = &Profile{Name: name}
+			if existing, ok := profiles[name]; ok {
+				current = &existing
+			}
+			continue
+		}
+
+		if current == nil {
+			return nil, fmt.Errorf("%s:%d: setting outside of a profile", file,
+				lineNumber)
+		}
+
+		pieces := strings.SplitN(text, "=", 2)
+		if len(pieces) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected key = value", file, lineNumber)
+		}
+
+		key := strings.ToLower(strings.TrimSpace(pieces[0]))
+		value := unquote(strings.TrimSpace(pieces[1]))
+
+		switch key {
+		case "email":
+			current.Email = value
+		case "key":
+			current.Key = value
+		case "key_file":
+			current.KeyFile = expandHome(value)
+		case "token":
+			current.Token = value
+		case "token_file":
+			current.TokenFile = expandHome(value)
+		case "token_command":
+			current.TokenCommand = value
+		case "domain", "zone":
+			current.Domain = value
+		default:
+			return nil, fmt.Errorf("%s:%d: unknown setting: %s", file, lineNumber,
+				key)
+		}
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("scan error: %w", err)
+	}
+
+	if current != nil {
+		profiles[current.Name] = *current
+	}
+
+	return profiles, nil
+}
+
+// LoadProfile reads the file and returns the named profile.
+//
+// If file is blank we use DefaultPath(). If name is blank we use
+// DefaultProfile.
+func LoadProfile(file, name string) (Profile, error) {
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	profiles, err := Load(file)
+	if err != nil {
+		return Profile{}, fmt.Errorf("unable to load config: %w", err)
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("profile not found: %s", name)
+	}
+
+	return profile, nil
+}
+
+// Strip a pair of surrounding quotes if there are any. This lets us accept
+// TOML style strings.
+func unquote(s string) string {
+	if len(s) >= 2 {
+		if (s[0] == '"' && s[len(s)-1] == '"') ||
+			(s[0] == '\'' && s[len(s)-1] == '\'') {
+			return s[1 : len(s)-1]
+		}
+	}
+	return s
+}
+
+// Expand a leading ~/ to the user's home directory.
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+
+	return filepath.Join(home, path[2:])
+}
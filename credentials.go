@@ -0,0 +1,174 @@
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CredentialsProvider gives the API token to use for a request, such as by
+// fetching it from a secret store.
+//
+// Set Client.Credentials or use WithCredentialsProvider to use one. We ask it
+// for the token before each request, so a provider that does something slow
+// should be wrapped with CachedCredentials.
+//
+// Implementations must be safe for concurrent use.
+type CredentialsProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// CredentialsFunc lets a function act as a CredentialsProvider.
+type CredentialsFunc func(ctx context.Context) (string, error)
+
+// Token calls f.
+func (f CredentialsFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// DefaultTokenVariable is the environment variable EnvCredentials reads if
+// not given one.
+const DefaultTokenVariable = "CLOUDFLARE_API_TOKEN"
+
+// WithCredentialsProvider authenticates using API tokens from the provider.
+func WithCredentialsProvider(p CredentialsProvider) Option {
+	return func(c *Client) error {
+		if p == nil {
+			return fmt.Errorf("credentials provider may not be nil")
+		}
+		c.Credentials = p
+		return nil
+	}
+}
+
+// EnvCredentials reads the token from an environment variable each time.
+// If name is blank we use DefaultTokenVariable.
+func EnvCredentials(name string) CredentialsProvider {
+	if name == "" {
+		name = DefaultTokenVariable
+	}
+
+	return CredentialsFunc(func(ctx context.Context) (string, error) {
+		token := strings.TrimSpace(os.Getenv(name))
+		if len(token) == 0 {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return token, nil
+	})
+}
+
+// FileCredentials reads the token with ReadTokenFromFile each time, so the
+// file may be rewritten as the token rotates. opts are passed on.
+func FileCredentials(tokenFile string,
+	opts ...ReadOption) CredentialsProvider {
+	return CredentialsFunc(func(ctx context.Context) (string, error) {
+		return ReadTokenFromFile(tokenFile, opts...)
+	})
+}
+
+// ExecCredentials runs a command and uses the first line it outputs as the
+// token, e.g.
+//
+//	cloudflare.ExecCredentials("pass", "show", "cf-token")
+//
+// The command runs each time we need the token, so wrap it with
+// CachedCredentials.
+func ExecCredentials(name string, args ...string) CredentialsProvider {
+	return CredentialsFunc(func(ctx context.Context) (string, error) {
+		cmd := exec.CommandContext(ctx, name, args...)
+
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+
+		output, err := cmd.Output()
+		if err != nil {
+			message := strings.TrimSpace(stderr.String())
+			if len(message) > 0 {
+				return "", fmt.Errorf("%s failed: %w: %s", name, err, message)
+			}
+			return "", fmt.Errorf("%s failed: %w", name, err)
+		}
+
+		token, _, _ := strings.Cut(string(output), "\n")
+		token = strings.TrimSpace(token)
+		if len(token) == 0 {
+			return "", fmt.Errorf("%s did not output a token", name)
+		}
+
+		err = checkTokenFormat(token)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", name, err)
+		}
+
+		return token, nil
+	})
+}
+
+// CachedCredentials remembers the token from a provider for ttl before asking
+// it again. Errors are not remembered.
+func CachedCredentials(p CredentialsProvider,
+	ttl time.Duration) CredentialsProvider {
+	cache := &cachedCredentials{provider: p, ttl: ttl}
+	return CredentialsFunc(cache.token)
+}
+
+type cachedCredentials struct {
+	provider CredentialsProvider
+	ttl      time.Duration
+
+	mutex   sync.Mutex
+	value   string
+	fetched time.Time
+}
+
+func (c *cachedCredentials) token(ctx context.Context) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if len(c.value) > 0 && time.Since(c.fetched) < c.ttl {
+		return c.value, nil
+	}
+
+	token, err := c.provider.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.value = token
+	c.fetched = time.Now()
+	return token, nil
+}
+
+// usesToken reports whether we authenticate with an API token rather than a
+// key and email.
+func (c Client) usesToken() bool {
+	return len(c.Token) > 0 || c.Credentials != nil
+}
+
+// setAuth adds the headers that authenticate a request.
+func (c Client) setAuth(ctx context.Context, req *http.Request) error {
+	if c.Credentials != nil {
+		token, err := c.Credentials.Token(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to get credentials: %w", err)
+		}
+		c.providedTokens.add(token)
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+
+	if len(c.Token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+		return nil
+	}
+
+	req.Header.Set("X-Auth-Email", c.Email)
+	req.Header.Set("X-Auth-Key", c.Key)
+	return nil
+}
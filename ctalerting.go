@@ -0,0 +1,64 @@
+package cloudflare
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CTAlerting holds a zone's Certificate Transparency Monitoring settings.
+// With it on, Cloudflare emails when a certificate is issued for one of the
+// zone's hostnames by any certificate authority.
+//
+// The API does not expose the certificates it found, only these settings.
+type CTAlerting struct {
+	Enabled bool `json:"enabled"`
+
+	// Emails are who to alert. If empty, Cloudflare alerts the account's
+	// super administrators.
+	Emails []string `json:"emails"`
+}
+
+// GetCTAlerting retrieves a zone's Certificate Transparency Monitoring
+// settings.
+func (c Client) GetCTAlerting(zoneID string) (CTAlerting, error) {
+	if len(zoneID) == 0 {
+		return CTAlerting{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var alerting CTAlerting
+	err := c.apiRequest("GET", zonePrefix(zoneID)+"/ct/alerting", nil, nil,
+		&alerting)
+	if err != nil {
+		return CTAlerting{}, fmt.Errorf("get CT alerting error: %w", err)
+	}
+
+	return alerting, nil
+}
+
+// UpdateCTAlerting turns Certificate Transparency Monitoring on or off for a
+// zone and sets who it alerts. We return the settings as updated.
+func (c Client) UpdateCTAlerting(zoneID string,
+	alerting CTAlerting) (CTAlerting, error) {
+	if len(zoneID) == 0 {
+		return CTAlerting{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	for _, email := range alerting.Emails {
+		if !strings.Contains(email, "@") {
+			return CTAlerting{}, fmt.Errorf("invalid email: %s", email)
+		}
+	}
+
+	if alerting.Emails == nil {
+		alerting.Emails = []string{}
+	}
+
+	var updated CTAlerting
+	err := c.apiRequest("PATCH", zonePrefix(zoneID)+"/ct/alerting", nil,
+		alerting, &updated)
+	if err != nil {
+		return CTAlerting{}, fmt.Errorf("update CT alerting error: %w", err)
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,73 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// CustomHostname is a hostname of a SaaS provider's customer, served through
+// the provider's zone (Cloudflare for SaaS).
+type CustomHostname struct {
+	ID       string `json:"id"`
+	Hostname string `json:"hostname"`
+
+	// Status is e.g. pending, active, moved, or deleted.
+	Status string `json:"status"`
+
+	SSL CustomHostnameSSL `json:"ssl"`
+
+	// OwnershipVerification is a TXT record the customer may add to prove
+	// they own the hostname.
+	OwnershipVerification struct {
+		Type  string `json:"type"`
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"ownership_verification"`
+
+	VerificationErrors []string `json:"verification_errors"`
+	CreatedAt          string   `json:"created_at"`
+}
+
+// CustomHostnameSSL holds the state of a custom hostname's certificate.
+type CustomHostnameSSL struct {
+	ID string `json:"id"`
+
+	// Status is e.g. initializing, pending_validation, active, or
+	// validation_timed_out.
+	Status string `json:"status"`
+
+	// Method is how the certificate is validated: http, txt, or email.
+	Method string `json:"method"`
+
+	Type string `json:"type"`
+
+	ValidationRecords []struct {
+		TXTName  string   `json:"txt_name,omitempty"`
+		TXTValue string   `json:"txt_value,omitempty"`
+		HTTPURL  string   `json:"http_url,omitempty"`
+		HTTPBody string   `json:"http_body,omitempty"`
+		Emails   []string `json:"emails,omitempty"`
+	} `json:"validation_records"`
+
+	ValidationErrors []struct {
+		Message string `json:"message"`
+	} `json:"validation_errors"`
+}
+
+// GetCustomHostname retrieves a custom hostname.
+func (c Client) GetCustomHostname(zoneID,
+	hostnameID string) (CustomHostname, error) {
+	if len(zoneID) == 0 || len(hostnameID) == 0 {
+		return CustomHostname{}, fmt.Errorf(
+			"you must provide a zone ID and custom hostname ID")
+	}
+
+	var hostname CustomHostname
+	err := c.apiRequest("GET", zonePrefix(zoneID)+"/custom_hostnames/"+
+		url.QueryEscape(hostnameID), nil, nil, &hostname)
+	if err != nil {
+		return CustomHostname{}, fmt.Errorf("get custom hostname error: %w", err)
+	}
+
+	return hostname, nil
+}
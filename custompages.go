@@ -0,0 +1,182 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Custom page IDs.
+const (
+	CustomPage500Errors        = "500_errors"
+	CustomPage1000Errors       = "1000_errors"
+	CustomPageWAFBlock         = "waf_block"
+	CustomPageRateLimitBlock   = "ratelimit_block"
+	CustomPageIPBlock          = "ip_block"
+	CustomPageCountryChallenge = "country_challenge"
+	CustomPageWAFChallenge     = "waf_challenge"
+	CustomPageBasicChallenge   = "basic_challenge"
+	CustomPageManagedChallenge = "managed_challenge"
+	CustomPageUnderAttack      = "under_attack"
+)
+
+// Custom page states.
+const (
+	// CustomPageDefault uses Cloudflare's page.
+	CustomPageDefault = "default"
+
+	// CustomPageCustomized uses the page at the custom page's URL.
+	CustomPageCustomized = "customized"
+)
+
+// CustomPage is a page Cloudflare shows in place of the origin's, such as
+// when it blocks a request or cannot reach the origin.
+type CustomPage struct {
+	ID             string   `json:"id"`
+	Description    string   `json:"description"`
+	URL            string   `json:"url"`
+	State          string   `json:"state"`
+	RequiredTokens []string `json:"required_tokens"`
+	PreviewTarget  string   `json:"preview_target"`
+	CreatedOn      string   `json:"created_on"`
+	ModifiedOn     string   `json:"modified_on"`
+}
+
+// ListZoneCustomPages lists a zone's custom pages.
+func (c Client) ListZoneCustomPages(zoneID string) ([]CustomPage, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	return c.listCustomPages(zonePrefix(zoneID))
+}
+
+// GetZoneCustomPage retrieves one of a zone's custom pages by its ID, one of
+// the CustomPage constants.
+func (c Client) GetZoneCustomPage(zoneID, pageID string) (CustomPage, error) {
+	if len(zoneID) == 0 {
+		return CustomPage{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	return c.getCustomPage(zonePrefix(zoneID), pageID)
+}
+
+// UpdateZoneCustomPage changes one of a zone's custom pages.
+//
+// To use your own page, pass the URL of its HTML and CustomPageCustomized.
+// Cloudflare fetches the page when you do this, so publish it first. The page
+// must include the custom page's RequiredTokens. To go back to Cloudflare's
+// page, pass a blank URL and CustomPageDefault.
+func (c Client) UpdateZoneCustomPage(zoneID, pageID, pageURL,
+	state string) (CustomPage, error) {
+	if len(zoneID) == 0 {
+		return CustomPage{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	return c.updateCustomPage(zonePrefix(zoneID), pageID, pageURL, state)
+}
+
+// ListAccountCustomPages lists an account's custom pages. These apply to
+// the account's zones that do not have their own.
+func (c Client) ListAccountCustomPages(accountID string) ([]CustomPage,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	return c.listCustomPages(accountPrefix(accountID))
+}
+
+// GetAccountCustomPage retrieves one of an account's custom pages.
+func (c Client) GetAccountCustomPage(accountID, pageID string) (CustomPage,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return CustomPage{}, err
+	}
+	if len(accountID) == 0 {
+		return CustomPage{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	return c.getCustomPage(accountPrefix(accountID), pageID)
+}
+
+// UpdateAccountCustomPage changes one of an account's custom pages. See
+// UpdateZoneCustomPage.
+func (c Client) UpdateAccountCustomPage(accountID, pageID, pageURL,
+	state string) (CustomPage, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return CustomPage{}, err
+	}
+	if len(accountID) == 0 {
+		return CustomPage{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	return c.updateCustomPage(accountPrefix(accountID), pageID, pageURL, state)
+}
+
+// The following work with custom pages of either zones or accounts. prefix
+// is zones/<id> or accounts/<id>.
+
+func (c Client) listCustomPages(prefix string) ([]CustomPage, error) {
+	var pages []CustomPage
+	err := c.apiRequest("GET", prefix+"/custom_pages", nil, nil, &pages)
+	if err != nil {
+		return nil, fmt.Errorf("list custom pages error: %w", err)
+	}
+
+	return pages, nil
+}
+
+func (c Client) getCustomPage(prefix, pageID string) (CustomPage, error) {
+	if len(pageID) == 0 {
+		return CustomPage{}, fmt.Errorf("you must provide a custom page ID")
+	}
+
+	var page CustomPage
+	err := c.apiRequest("GET", prefix+"/custom_pages/"+url.QueryEscape(pageID),
+		nil, nil, &page)
+	if err != nil {
+		return CustomPage{}, fmt.Errorf("get custom page error: %w", err)
+	}
+
+	return page, nil
+}
+
+func (c Client) updateCustomPage(prefix, pageID, pageURL,
+	state string) (CustomPage, error) {
+	if len(pageID) == 0 {
+		return CustomPage{}, fmt.Errorf("you must provide a custom page ID")
+	}
+
+	switch state {
+	case CustomPageCustomized:
+		if !strings.HasPrefix(pageURL, "http://") &&
+			!strings.HasPrefix(pageURL, "https://") {
+			return CustomPage{}, fmt.Errorf(
+				"a customized page needs an http or https URL, not %q", pageURL)
+		}
+	case CustomPageDefault:
+	default:
+		return CustomPage{}, fmt.Errorf("invalid custom page state: %q", state)
+	}
+
+	payload := struct {
+		URL   string `json:"url"`
+		State string `json:"state"`
+	}{pageURL, state}
+
+	var page CustomPage
+	err := c.apiRequest("PUT", prefix+"/custom_pages/"+url.QueryEscape(pageID),
+		nil, payload, &page)
+	if err != nil {
+		return CustomPage{}, fmt.Errorf("update custom page error: %w", err)
+	}
+
+	return page, nil
+}
@@ -0,0 +1,106 @@
+package cloudflare
+
+import "fmt"
+
+// PhaseDDoSL7 is the phase holding HTTP DDoS protection.
+const PhaseDDoSL7 = "ddos_l7"
+
+// DDoSL7RulesetID is the ID of the HTTP DDoS Attack Protection managed
+// ruleset.
+const DDoSL7RulesetID = "4d21379b4f9f4bb088e0729962c8b3cf"
+
+// DDoS sensitivity levels, from most to least sensitive.
+const (
+	DDoSSensitivityDefault = "default"
+	DDoSSensitivityMedium  = "medium"
+	DDoSSensitivityLow     = "low"
+	DDoSSensitivityOff     = "eoff"
+)
+
+// GetDDoSL7Overrides retrieves the zone's overrides of the HTTP DDoS managed
+// ruleset. If there are none we return empty overrides, meaning the
+// ruleset's defaults apply.
+func (c Client) GetDDoSL7Overrides(zoneID string) (RulesetOverrides, error) {
+	ruleset, err := c.GetZoneEntrypointRuleset(zoneID, PhaseDDoSL7)
+	if err != nil {
+		// A zone that has never overridden anything has no entry point.
+		if IsNotFound(err) {
+			return RulesetOverrides{}, nil
+		}
+		return RulesetOverrides{}, err
+	}
+
+	rule, ok := findDDoSL7Rule(ruleset)
+	if !ok || rule.ActionParameters.Overrides == nil {
+		return RulesetOverrides{}, nil
+	}
+
+	return *rule.ActionParameters.Overrides, nil
+}
+
+// SetDDoSL7Overrides changes the sensitivity and/or action of the zone's HTTP
+// DDoS protection. It replaces any previous overrides.
+//
+// For example, to lower the sensitivity during an incident of false
+// positives:
+//
+//	client.SetDDoSL7Overrides(zoneID, cloudflare.RulesetOverrides{
+//		SensitivityLevel: cloudflare.DDoSSensitivityLow,
+//	})
+func (c Client) SetDDoSL7Overrides(zoneID string,
+	overrides RulesetOverrides) (Ruleset, error) {
+	err := checkDDoSSensitivity(overrides.SensitivityLevel)
+	if err != nil {
+		return Ruleset{}, err
+	}
+	for _, rule := range overrides.Rules {
+		err := checkDDoSSensitivity(rule.SensitivityLevel)
+		if err != nil {
+			return Ruleset{}, err
+		}
+	}
+
+	rule := RulesetRule{
+		Action: "execute",
+		ActionParameters: &RulesetActionParameters{
+			ID:        DDoSL7RulesetID,
+			Overrides: &overrides,
+		},
+		Expression:  "true",
+		Description: "HTTP DDoS overrides",
+		Enabled:     true,
+	}
+
+	// Update the rule in place if there is one so we keep any other rules in
+	// the phase.
+	ruleset, err := c.GetZoneEntrypointRuleset(zoneID, PhaseDDoSL7)
+	if err == nil {
+		existing, ok := findDDoSL7Rule(ruleset)
+		if ok {
+			rule.ID = existing.ID
+			return c.UpdateZoneRulesetRule(zoneID, ruleset.ID, rule)
+		}
+	}
+
+	return c.AddZonePhaseRule(zoneID, PhaseDDoSL7, rule)
+}
+
+func findDDoSL7Rule(ruleset Ruleset) (RulesetRule, bool) {
+	for _, rule := range ruleset.Rules {
+		if rule.Action == "execute" && rule.ActionParameters != nil &&
+			rule.ActionParameters.ID == DDoSL7RulesetID {
+			return rule, true
+		}
+	}
+	return RulesetRule{}, false
+}
+
+func checkDDoSSensitivity(level string) error {
+	switch level {
+	case "", DDoSSensitivityDefault, DDoSSensitivityMedium,
+		DDoSSensitivityLow, DDoSSensitivityOff:
+		return nil
+	default:
+		return fmt.Errorf("invalid sensitivity level: %s", level)
+	}
+}
@@ -0,0 +1,190 @@
+package cloudflare
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Delegation describes the result of delegating a subdomain.
+type Delegation struct {
+	// Records are the NS records we created.
+	Records []DNSRecord
+
+	// Shadowed are existing records at or below the subdomain. Once the
+	// subdomain is delegated, resolvers will ask the new nameservers about
+	// these names, so Cloudflare will no longer serve them. They are left in
+	// place so that absorbing the subdomain back restores them.
+	Shadowed []DNSRecord
+}
+
+// DelegateSubdomain delegates a subdomain of the zone to external
+// nameservers.
+//
+// It creates an NS record for the subdomain for each nameserver. subdomain
+// must be a name within the zone, e.g. "dev.example.com" for the zone
+// "example.com". ttl may be 1 for automatic.
+//
+// We refuse to delegate if the subdomain is already delegated, if it has a
+// CNAME (which may not coexist with NS records), or if a nameserver is within
+// the subdomain itself (as that would require glue records).
+//
+// Records that the delegation shadows are reported in the result. Check them:
+// it is easy to delegate a name and forget that records below it exist.
+func (c Client) DelegateSubdomain(zone Zone, subdomain string,
+	nameservers []string, ttl int) (Delegation, error) {
+	subdomain, err := validateSubdomain(zone, subdomain)
+	if err != nil {
+		return Delegation{}, err
+	}
+
+	if len(nameservers) == 0 {
+		return Delegation{}, fmt.Errorf("you must provide at least one nameserver")
+	}
+
+	seen := map[string]struct{}{}
+	var cleanNameservers []string
+	for _, ns := range nameservers {
+		ns = NormalizeName(ns)
+		if !isValidHostname(ns) {
+			return Delegation{}, fmt.Errorf("invalid nameserver: %s", ns)
+		}
+		if ns == subdomain || strings.HasSuffix(ns, "."+subdomain) {
+			return Delegation{}, fmt.Errorf(
+				"nameserver %s is inside %s. Glue records are not supported", ns,
+				subdomain)
+		}
+		if _, ok := seen[ns]; ok {
+			continue
+		}
+		seen[ns] = struct{}{}
+		cleanNameservers = append(cleanNameservers, ns)
+	}
+
+	records, err := c.ListAllDNSRecords(zone.ID, "", "")
+	if err != nil {
+		return Delegation{}, fmt.Errorf("unable to list DNS records: %w", err)
+	}
+
+	var shadowed []DNSRecord
+	for _, record := range records {
+		name := NormalizeName(record.Name)
+		if name != subdomain && !strings.HasSuffix(name, "."+subdomain) {
+			continue
+		}
+
+		if name == subdomain && record.Type == RecordTypeNS {
+			return Delegation{}, fmt.Errorf("%s is already delegated (NS %s)",
+				subdomain, record.Content)
+		}
+
+		if name == subdomain && record.Type == RecordTypeCNAME {
+			return Delegation{}, fmt.Errorf(
+				"%s has a CNAME record. Remove it before delegating", subdomain)
+		}
+
+		shadowed = append(shadowed, record)
+	}
+
+	delegation := Delegation{Shadowed: shadowed}
+
+	for _, ns := range cleanNameservers {
+		record, err := c.CreateDNSRecord(DNSRecord{
+			ZoneID:  zone.ID,
+			Type:    RecordTypeNS,
+			Name:    subdomain,
+			Content: ns,
+			TTL:     ttl,
+		})
+		if err != nil {
+			return delegation, fmt.Errorf("unable to create NS record for %s: %w",
+				ns, err)
+		}
+		delegation.Records = append(delegation.Records, record)
+	}
+
+	return delegation, nil
+}
+
+// AbsorbSubdomain undoes a delegation by deleting the subdomain's NS records.
+//
+// Cloudflare then answers for the subdomain again, including any records
+// that were shadowed by the delegation.
+//
+// We return the records we deleted.
+func (c Client) AbsorbSubdomain(zone Zone, subdomain string) ([]DNSRecord,
+	error) {
+	subdomain, err := validateSubdomain(zone, subdomain)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := c.ListAllDNSRecords(zone.ID, RecordTypeNS, "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list DNS records: %w", err)
+	}
+
+	var deleted []DNSRecord
+	for _, record := range records {
+		if NormalizeName(record.Name) != subdomain {
+			continue
+		}
+
+		err := c.DeleteDNSRecord(record)
+		if err != nil {
+			return deleted, fmt.Errorf("unable to delete NS record %s: %w",
+				record.Content, err)
+		}
+		deleted = append(deleted, record)
+	}
+
+	if len(deleted) == 0 {
+		return nil, fmt.Errorf("%s is not delegated", subdomain)
+	}
+
+	return deleted, nil
+}
+
+// Check the subdomain is strictly within the zone. Return it normalized.
+func validateSubdomain(zone Zone, subdomain string) (string, error) {
+	if len(zone.ID) == 0 || len(zone.Name) == 0 {
+		return "", fmt.Errorf("you must provide a zone with ID and name")
+	}
+
+	subdomain = NormalizeName(subdomain)
+	zoneName := NormalizeName(zone.Name)
+
+	if !isValidHostname(subdomain) {
+		return "", fmt.Errorf("invalid subdomain: %s", subdomain)
+	}
+
+	if !strings.HasSuffix(subdomain, "."+zoneName) {
+		return "", fmt.Errorf("%s is not a subdomain of %s", subdomain, zoneName)
+	}
+
+	return subdomain, nil
+}
+
+// Check a name looks like a hostname: dot separated labels of letters,
+// digits, hyphens, and underscores, none longer than 63 characters.
+func isValidHostname(name string) bool {
+	if len(name) == 0 || len(name) > 253 {
+		return false
+	}
+
+	for _, label := range strings.Split(name, ".") {
+		if len(label) == 0 || len(label) > 63 {
+			return false
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' &&
+				r != '_' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
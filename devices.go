@@ -0,0 +1,347 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Device is a device enrolled in Zero Trust with the WARP client.
+type Device struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	DeviceType   string     `json:"device_type"`
+	OSVersion    string     `json:"os_version"`
+	Version      string     `json:"version"`
+	Model        string     `json:"model"`
+	SerialNumber string     `json:"serial_number"`
+	IP           string     `json:"ip"`
+	Created      string     `json:"created"`
+	LastSeen     string     `json:"last_seen"`
+	Updated      string     `json:"updated"`
+	RevokedAt    string     `json:"revoked_at,omitempty"`
+	User         DeviceUser `json:"user"`
+}
+
+// DeviceUser is who enrolled a device.
+type DeviceUser struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+// DevicePostureRule checks something about devices, such as that the disk
+// is encrypted. Access and Gateway policies can require devices pass it.
+type DevicePostureRule struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// Type is what to check, e.g. file, application, disk_encryption,
+	// os_version, firewall, or an integration such as crowdstrike_s2s.
+	Type string `json:"type"`
+
+	// Schedule is how often to check, e.g. 5m. Expiration is how long a
+	// result lasts. Either may be blank for the default.
+	Schedule   string `json:"schedule,omitempty"`
+	Expiration string `json:"expiration,omitempty"`
+
+	// Match limits the rule to platforms, e.g.
+	// [{"platform": "windows"}]. Input configures the check. Both depend on
+	// Type and are passed as is.
+	Match []map[string]interface{} `json:"match,omitempty"`
+	Input map[string]interface{}   `json:"input,omitempty"`
+}
+
+// DevicePostureIntegration connects a third party service, such as an EDR
+// provider, that device posture rules can check with.
+type DevicePostureIntegration struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+
+	// Type is the service, e.g. crowdstrike_s2s, intune, or workspace_one.
+	Type string `json:"type"`
+
+	// Interval is how often to poll the service, e.g. 10m.
+	Interval string `json:"interval"`
+
+	// Config holds the service's credentials and such. It is passed as is.
+	// The API does not return secrets.
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+func devicesPath(accountID string) string {
+	return accountPrefix(accountID) + "/devices"
+}
+
+// ListDevices lists an account's enrolled devices.
+func (c Client) ListDevices(accountID string) ([]Device, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var devices []Device
+	err = c.apiRequest("GET", devicesPath(accountID), nil, nil, &devices)
+	if err != nil {
+		return nil, fmt.Errorf("list devices error: %w", err)
+	}
+
+	return devices, nil
+}
+
+// GetDevice retrieves an enrolled device.
+func (c Client) GetDevice(accountID, deviceID string) (Device, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return Device{}, err
+	}
+	if len(accountID) == 0 || len(deviceID) == 0 {
+		return Device{}, fmt.Errorf("you must provide an account ID and device ID")
+	}
+
+	var device Device
+	err = c.apiRequest("GET", devicesPath(accountID)+"/"+
+		url.QueryEscape(deviceID), nil, nil, &device)
+	if err != nil {
+		return Device{}, fmt.Errorf("get device error: %w", err)
+	}
+
+	return device, nil
+}
+
+// RevokeDevices revokes devices' registrations. They lose access until they
+// are unrevoked or enroll again.
+func (c Client) RevokeDevices(accountID string, deviceIDs []string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	return c.changeDevices(accountID, "revoke", deviceIDs)
+}
+
+// UnrevokeDevices undoes RevokeDevices.
+func (c Client) UnrevokeDevices(accountID string, deviceIDs []string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	return c.changeDevices(accountID, "unrevoke", deviceIDs)
+}
+
+func (c Client) changeDevices(accountID, operation string,
+	deviceIDs []string) error {
+	if len(accountID) == 0 {
+		return fmt.Errorf("you must provide an account ID")
+	}
+
+	if len(deviceIDs) == 0 {
+		return fmt.Errorf("you must provide at least one device ID")
+	}
+
+	err := c.apiRequest("POST", devicesPath(accountID)+"/"+operation, nil,
+		deviceIDs, nil)
+	if err != nil {
+		return fmt.Errorf("%s devices error: %w", operation, err)
+	}
+
+	return nil
+}
+
+// ListDevicePostureRules lists an account's device posture rules.
+func (c Client) ListDevicePostureRules(accountID string) ([]DevicePostureRule,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var rules []DevicePostureRule
+	err = c.apiRequest("GET", devicesPath(accountID)+"/posture", nil, nil,
+		&rules)
+	if err != nil {
+		return nil, fmt.Errorf("list device posture rules error: %w", err)
+	}
+
+	return rules, nil
+}
+
+// CreateDevicePostureRule creates a device posture rule.
+func (c Client) CreateDevicePostureRule(accountID string,
+	rule DevicePostureRule) (DevicePostureRule, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return DevicePostureRule{}, err
+	}
+	if len(accountID) == 0 {
+		return DevicePostureRule{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	if len(rule.Name) == 0 || len(rule.Type) == 0 {
+		return DevicePostureRule{}, fmt.Errorf(
+			"a device posture rule needs a name and type")
+	}
+
+	rule.ID = ""
+
+	var created DevicePostureRule
+	err = c.apiRequest("POST", devicesPath(accountID)+"/posture", nil, rule,
+		&created)
+	if err != nil {
+		return DevicePostureRule{}, fmt.Errorf(
+			"create device posture rule error: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateDevicePostureRule replaces a device posture rule. Its ID must be
+// set.
+func (c Client) UpdateDevicePostureRule(accountID string,
+	rule DevicePostureRule) (DevicePostureRule, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return DevicePostureRule{}, err
+	}
+	if len(accountID) == 0 || len(rule.ID) == 0 {
+		return DevicePostureRule{}, fmt.Errorf(
+			"you must provide an account ID and rule ID")
+	}
+
+	if len(rule.Name) == 0 || len(rule.Type) == 0 {
+		return DevicePostureRule{}, fmt.Errorf(
+			"a device posture rule needs a name and type")
+	}
+
+	var updated DevicePostureRule
+	err = c.apiRequest("PUT", devicesPath(accountID)+"/posture/"+
+		url.QueryEscape(rule.ID), nil, rule, &updated)
+	if err != nil {
+		return DevicePostureRule{}, fmt.Errorf(
+			"update device posture rule error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteDevicePostureRule deletes a device posture rule.
+func (c Client) DeleteDevicePostureRule(accountID, ruleID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(ruleID) == 0 {
+		return fmt.Errorf("you must provide an account ID and rule ID")
+	}
+
+	err = c.apiRequest("DELETE", devicesPath(accountID)+"/posture/"+
+		url.QueryEscape(ruleID), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete device posture rule error: %w", err)
+	}
+
+	return nil
+}
+
+// ListDevicePostureIntegrations lists an account's device posture
+// integrations.
+func (c Client) ListDevicePostureIntegrations(
+	accountID string) ([]DevicePostureIntegration, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var integrations []DevicePostureIntegration
+	err = c.apiRequest("GET", devicesPath(accountID)+"/posture/integration",
+		nil, nil, &integrations)
+	if err != nil {
+		return nil, fmt.Errorf("list device posture integrations error: %w",
+			err)
+	}
+
+	return integrations, nil
+}
+
+// CreateDevicePostureIntegration creates a device posture integration.
+func (c Client) CreateDevicePostureIntegration(accountID string,
+	integration DevicePostureIntegration) (DevicePostureIntegration, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return DevicePostureIntegration{}, err
+	}
+	if len(accountID) == 0 {
+		return DevicePostureIntegration{}, fmt.Errorf(
+			"you must provide an account ID")
+	}
+
+	if len(integration.Name) == 0 || len(integration.Type) == 0 {
+		return DevicePostureIntegration{}, fmt.Errorf(
+			"a device posture integration needs a name and type")
+	}
+
+	integration.ID = ""
+
+	var created DevicePostureIntegration
+	err = c.apiRequest("POST", devicesPath(accountID)+"/posture/integration",
+		nil, integration, &created)
+	if err != nil {
+		return DevicePostureIntegration{}, fmt.Errorf(
+			"create device posture integration error: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateDevicePostureIntegration changes a device posture integration. Its ID
+// must be set. Blank fields are unchanged.
+func (c Client) UpdateDevicePostureIntegration(accountID string,
+	integration DevicePostureIntegration) (DevicePostureIntegration, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return DevicePostureIntegration{}, err
+	}
+	if len(accountID) == 0 || len(integration.ID) == 0 {
+		return DevicePostureIntegration{}, fmt.Errorf(
+			"you must provide an account ID and integration ID")
+	}
+
+	var updated DevicePostureIntegration
+	err = c.apiRequest("PATCH", devicesPath(accountID)+
+		"/posture/integration/"+url.QueryEscape(integration.ID), nil,
+		integration, &updated)
+	if err != nil {
+		return DevicePostureIntegration{}, fmt.Errorf(
+			"update device posture integration error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteDevicePostureIntegration deletes a device posture integration.
+func (c Client) DeleteDevicePostureIntegration(accountID,
+	integrationID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(integrationID) == 0 {
+		return fmt.Errorf("you must provide an account ID and integration ID")
+	}
+
+	err = c.apiRequest("DELETE", devicesPath(accountID)+
+		"/posture/integration/"+url.QueryEscape(integrationID), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete device posture integration error: %w", err)
+	}
+
+	return nil
+}
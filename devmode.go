@@ -0,0 +1,66 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DevelopmentMode holds the state of a zone's development mode. While it is
+// on, Cloudflare bypasses its cache so changes to the origin show
+// immediately. It turns itself off after three hours.
+type DevelopmentMode struct {
+	Enabled bool
+
+	// Remaining is how long until development mode turns off.
+	Remaining time.Duration
+}
+
+// GetDevelopmentMode retrieves the state of a zone's development mode.
+func (c Client) GetDevelopmentMode(zoneID string) (DevelopmentMode, error) {
+	setting, err := c.GetZoneSetting(zoneID, SettingDevelopmentMode)
+	if err != nil {
+		return DevelopmentMode{}, err
+	}
+
+	return developmentModeFromSetting(setting)
+}
+
+// EnableDevelopmentMode turns on development mode for a zone. We return its
+// state afterwards.
+func (c Client) EnableDevelopmentMode(zoneID string) (DevelopmentMode,
+	error) {
+	setting, err := c.UpdateZoneSetting(zoneID, SettingDevelopmentMode, "on")
+	if err != nil {
+		return DevelopmentMode{}, err
+	}
+
+	// In dry run mode we don't get the setting back.
+	if c.dryRun {
+		return DevelopmentMode{Enabled: true}, nil
+	}
+
+	return developmentModeFromSetting(setting)
+}
+
+// DisableDevelopmentMode turns off development mode for a zone.
+func (c Client) DisableDevelopmentMode(zoneID string) error {
+	_, err := c.UpdateZoneSetting(zoneID, SettingDevelopmentMode, "off")
+	return err
+}
+
+func developmentModeFromSetting(setting ZoneSetting) (DevelopmentMode, error) {
+	var value string
+	err := json.Unmarshal(setting.Value, &value)
+	if err != nil {
+		return DevelopmentMode{}, fmt.Errorf(
+			"development mode value is not a string: %w", err)
+	}
+
+	mode := DevelopmentMode{Enabled: value == "on"}
+	if mode.Enabled && setting.TimeRemaining > 0 {
+		mode.Remaining = time.Duration(setting.TimeRemaining) * time.Second
+	}
+
+	return mode, nil
+}
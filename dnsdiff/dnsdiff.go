@@ -0,0 +1,236 @@
+// Package dnsdiff works out how to change a zone's DNS records to match the
+// records it should have.
+//
+// Compare the records you want with the live ones to get a Plan. Print it to
+// show what would change, then Apply it:
+//
+//	live, err := client.ListAllDNSRecords(zoneID, "", "")
+//	...
+//	plan := dnsdiff.Diff(desired, live, dnsdiff.Options{Prune: true})
+//	fmt.Print(plan)
+//	err = plan.Apply(client)
+//
+// Names are compared case insensitively and without trailing dots, as is the
+// content of records holding hostnames (such as CNAME). A TTL of 0 means
+// automatic.
+package dnsdiff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/horgh/cloudflare"
+)
+
+// Actions a change may take.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Change is a single step in converging the live records to the desired
+// records.
+type Change struct {
+	// Action is one of the Action constants.
+	Action string `json:"action"`
+
+	// Record is the record to create, the record as it should be after an
+	// update, or the record to delete.
+	Record cloudflare.DNSRecord `json:"record"`
+
+	// Before is the live record before an update.
+	Before *cloudflare.DNSRecord `json:"before,omitempty"`
+}
+
+func (c Change) String() string {
+	symbol := map[string]string{
+		ActionCreate: "+",
+		ActionUpdate: "~",
+		ActionDelete: "-",
+	}[c.Action]
+	s := fmt.Sprintf("%s %s %s %s %s ttl=%d proxied=%t", symbol, c.Action,
+		c.Record.Type, c.Record.Name, content(c.Record), c.Record.TTL,
+		c.Record.Proxied)
+	if c.Before != nil && content(*c.Before) != content(c.Record) {
+		s += fmt.Sprintf(" (was %s)", content(*c.Before))
+	}
+	return s
+}
+
+// Plan is the changes to make, in the order to make them.
+type Plan struct {
+	Changes []Change `json:"changes"`
+}
+
+// Empty is whether there is nothing to change.
+func (p Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String describes each change on its own line.
+func (p Plan) String() string {
+	var b strings.Builder
+	for _, c := range p.Changes {
+		b.WriteString(c.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Apply makes the changes. We stop at the first that fails. The changes before
+// it will have been made.
+//
+// With a client in dry run mode (see cloudflare.WithDryRun) the client logs
+// each change rather than making it.
+func (p Plan) Apply(client cloudflare.Client) error {
+	for _, c := range p.Changes {
+		var err error
+		switch c.Action {
+		case ActionCreate:
+			_, err = client.CreateDNSRecord(c.Record)
+		case ActionUpdate:
+			err = client.UpdateDNSRecord(c.Record)
+		case ActionDelete:
+			err = client.DeleteDNSRecord(c.Record)
+		default:
+			err = fmt.Errorf("unknown action: %s", c.Action)
+		}
+		if err != nil {
+			return fmt.Errorf("unable to %s %s record %s: %w", c.Action,
+				c.Record.Type, c.Record.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Options control how we compare records.
+type Options struct {
+	// Prune means to delete live records that are not desired. Otherwise we
+	// leave them alone.
+	Prune bool
+}
+
+// Diff compares desired records with live ones and works out what to change.
+//
+// Records are grouped by name and type. Within a group, records with the same
+// content are matched and updated if their TTL or proxied setting differ.
+// Remaining records are paired up and updated to the new content. Anything
+// left over is created or deleted.
+//
+// Deletes come first so that e.g. a CNAME replacing other records at a name
+// does not conflict, then updates, then creates.
+func Diff(desired, live []cloudflare.DNSRecord, opts Options) Plan {
+	desiredGroups := groupRecords(desired)
+	liveGroups := groupRecords(live)
+
+	var keys []string
+	for key := range desiredGroups {
+		keys = append(keys, key)
+	}
+	for key := range liveGroups {
+		if _, ok := desiredGroups[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var deletes, updates, creates []Change
+
+	for _, key := range keys {
+		want := desiredGroups[key]
+		have := liveGroups[key]
+
+		var unmatchedWant []cloudflare.DNSRecord
+		for _, w := range want {
+			w.TTL = cloudflare.NormalizeTTL(w.TTL)
+
+			found := -1
+			for i, h := range have {
+				if ContentEqual(w, h) {
+					found = i
+					break
+				}
+			}
+
+			if found == -1 {
+				unmatchedWant = append(unmatchedWant, w)
+				continue
+			}
+
+			h := have[found]
+			have = append(have[:found:found], have[found+1:]...)
+
+			if w.TTL != h.TTL || w.Proxied != h.Proxied {
+				updates = append(updates, updateChange(w, h))
+			}
+		}
+
+		for len(unmatchedWant) > 0 && len(have) > 0 {
+			updates = append(updates, updateChange(unmatchedWant[0], have[0]))
+			unmatchedWant = unmatchedWant[1:]
+			have = have[1:]
+		}
+
+		for _, w := range unmatchedWant {
+			creates = append(creates, Change{Action: ActionCreate, Record: w})
+		}
+
+		if opts.Prune {
+			for _, h := range have {
+				deletes = append(deletes, Change{Action: ActionDelete, Record: h})
+			}
+		}
+	}
+
+	changes := append(deletes, updates...)
+	return Plan{Changes: append(changes, creates...)}
+}
+
+func updateChange(want, have cloudflare.DNSRecord) Change {
+	updated := have
+	updated.Content = want.Content
+	updated.Priority = want.Priority
+	updated.TTL = want.TTL
+	updated.Proxied = want.Proxied
+
+	before := have
+	return Change{Action: ActionUpdate, Record: updated, Before: &before}
+}
+
+func groupRecords(records []cloudflare.DNSRecord) map[string][]cloudflare.DNSRecord {
+	groups := map[string][]cloudflare.DNSRecord{}
+	for _, record := range records {
+		key := NormalizeName(record.Name) + " " + strings.ToUpper(record.Type)
+		groups[key] = append(groups[key], record)
+	}
+	return groups
+}
+
+// ContentEqual is whether two records of the same type hold the same data.
+// It is cloudflare.ContentEqual.
+func ContentEqual(a, b cloudflare.DNSRecord) bool {
+	return cloudflare.ContentEqual(a, b)
+}
+
+// NormalizeName lowercases a name and removes any trailing dot. It is
+// cloudflare.NormalizeName.
+func NormalizeName(name string) string {
+	return cloudflare.NormalizeName(name)
+}
+
+// QualifyName turns a name relative to the zone into a fully qualified one.
+// It is cloudflare.QualifyName.
+func QualifyName(name, zone string) string {
+	return cloudflare.QualifyName(name, zone)
+}
+
+// A record's content including its priority, if it has one.
+func content(record cloudflare.DNSRecord) string {
+	if record.Priority == nil {
+		return record.Content
+	}
+	return fmt.Sprintf("%d %s", *record.Priority, record.Content)
+}
@@ -0,0 +1,323 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DNSFirewallCluster is a DNS Firewall cluster: Cloudflare nameservers that
+// proxy and cache DNS queries for upstream nameservers.
+type DNSFirewallCluster struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+
+	// UpstreamIPs are the nameservers we proxy for.
+	UpstreamIPs []string `json:"upstream_ips"`
+
+	// DNSFirewallIPs are the cluster's addresses. Point resolvers at them.
+	DNSFirewallIPs []string `json:"dns_firewall_ips,omitempty"`
+
+	// These bound the TTLs (in seconds) of cached answers, overriding the
+	// upstream's. Nil for the defaults.
+	MinimumCacheTTL  *int `json:"minimum_cache_ttl,omitempty"`
+	MaximumCacheTTL  *int `json:"maximum_cache_ttl,omitempty"`
+	NegativeCacheTTL *int `json:"negative_cache_ttl,omitempty"`
+
+	// Ratelimit is the most queries per second each Cloudflare data center
+	// sends upstream. Nil for no limit.
+	Ratelimit *int `json:"ratelimit,omitempty"`
+
+	// Retries is how many times to retry an unanswered upstream query.
+	Retries *int `json:"retries,omitempty"`
+
+	DeprecateAnyRequests bool `json:"deprecate_any_requests"`
+	ECSFallback          bool `json:"ecs_fallback"`
+
+	ModifiedOn string `json:"modified_on,omitempty"`
+}
+
+// DNSAnalyticsReport holds DNS analytics: metrics summed over a time range,
+// broken down by dimensions.
+type DNSAnalyticsReport struct {
+	// Rows holds a row per combination of dimension values.
+	Rows []DNSAnalyticsRow
+
+	// Totals holds each metric summed over all rows.
+	Totals map[string]float64
+}
+
+// DNSAnalyticsRow is a row of a DNSAnalyticsReport.
+type DNSAnalyticsRow struct {
+	// Dimensions maps each dimension (e.g. queryName) to its value.
+	Dimensions map[string]string
+
+	// Metrics maps each metric (e.g. queryCount) to its value.
+	Metrics map[string]float64
+}
+
+// DNSAnalyticsOptions selects the analytics to retrieve.
+type DNSAnalyticsOptions struct {
+	Since time.Time
+	Until time.Time
+
+	// Metrics are e.g. queryCount and uncachedCount. If empty, queryCount.
+	Metrics []string
+
+	// Dimensions are e.g. queryName, queryType, and responseCode. If empty
+	// there is a single row.
+	Dimensions []string
+
+	// Filters limits the queries counted, e.g. responseCode==NXDOMAIN.
+	// Blank for all.
+	Filters string
+
+	// Limit is the most rows. Zero for the API's default.
+	Limit int
+}
+
+func dnsFirewallPath(accountID string) string {
+	return accountPrefix(accountID) + "/dns_firewall"
+}
+
+// ListDNSFirewallClusters lists an account's DNS Firewall clusters.
+func (c Client) ListDNSFirewallClusters(
+	accountID string) ([]DNSFirewallCluster, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var clusters []DNSFirewallCluster
+	paginate(context.Background(), c, dnsFirewallPath(accountID), nil, 50,
+		"list DNS Firewall clusters",
+		func(cluster DNSFirewallCluster, e error) bool {
+			if e != nil {
+				err = e
+				return false
+			}
+			clusters = append(clusters, cluster)
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return clusters, nil
+}
+
+// GetDNSFirewallCluster retrieves a DNS Firewall cluster.
+func (c Client) GetDNSFirewallCluster(accountID,
+	clusterID string) (DNSFirewallCluster, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return DNSFirewallCluster{}, err
+	}
+	if len(accountID) == 0 || len(clusterID) == 0 {
+		return DNSFirewallCluster{}, fmt.Errorf(
+			"you must provide an account ID and cluster ID")
+	}
+
+	var cluster DNSFirewallCluster
+	err = c.apiRequest("GET", dnsFirewallPath(accountID)+"/"+
+		url.QueryEscape(clusterID), nil, nil, &cluster)
+	if err != nil {
+		return DNSFirewallCluster{}, fmt.Errorf(
+			"get DNS Firewall cluster error: %w", err)
+	}
+
+	return cluster, nil
+}
+
+// CreateDNSFirewallCluster creates a DNS Firewall cluster. Name and
+// UpstreamIPs are required.
+func (c Client) CreateDNSFirewallCluster(accountID string,
+	cluster DNSFirewallCluster) (DNSFirewallCluster, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return DNSFirewallCluster{}, err
+	}
+	if len(accountID) == 0 {
+		return DNSFirewallCluster{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	err = validateDNSFirewallCluster(cluster)
+	if err != nil {
+		return DNSFirewallCluster{}, err
+	}
+
+	cluster.ID = ""
+	cluster.DNSFirewallIPs = nil
+	cluster.ModifiedOn = ""
+
+	var created DNSFirewallCluster
+	err = c.apiRequest("POST", dnsFirewallPath(accountID), nil, cluster,
+		&created)
+	if err != nil {
+		return DNSFirewallCluster{}, fmt.Errorf(
+			"create DNS Firewall cluster error: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateDNSFirewallCluster changes a DNS Firewall cluster. Its ID must be
+// set. Nil fields are unchanged.
+func (c Client) UpdateDNSFirewallCluster(accountID string,
+	cluster DNSFirewallCluster) (DNSFirewallCluster, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return DNSFirewallCluster{}, err
+	}
+	if len(accountID) == 0 || len(cluster.ID) == 0 {
+		return DNSFirewallCluster{}, fmt.Errorf(
+			"you must provide an account ID and cluster ID")
+	}
+
+	err = validateDNSFirewallCluster(cluster)
+	if err != nil {
+		return DNSFirewallCluster{}, err
+	}
+
+	id := cluster.ID
+	cluster.ID = ""
+	cluster.ModifiedOn = ""
+
+	var updated DNSFirewallCluster
+	err = c.apiRequest("PATCH", dnsFirewallPath(accountID)+"/"+
+		url.QueryEscape(id), nil, cluster, &updated)
+	if err != nil {
+		return DNSFirewallCluster{}, fmt.Errorf(
+			"update DNS Firewall cluster error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteDNSFirewallCluster deletes a DNS Firewall cluster. It stops
+// answering queries.
+func (c Client) DeleteDNSFirewallCluster(accountID, clusterID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(clusterID) == 0 {
+		return fmt.Errorf("you must provide an account ID and cluster ID")
+	}
+
+	err = c.apiRequest("DELETE", dnsFirewallPath(accountID)+"/"+
+		url.QueryEscape(clusterID), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete DNS Firewall cluster error: %w", err)
+	}
+
+	return nil
+}
+
+func validateDNSFirewallCluster(cluster DNSFirewallCluster) error {
+	if len(cluster.Name) == 0 {
+		return fmt.Errorf("you must provide a cluster name")
+	}
+
+	if len(cluster.UpstreamIPs) == 0 {
+		return fmt.Errorf("you must provide at least one upstream IP")
+	}
+
+	for _, ip := range cluster.UpstreamIPs {
+		if net.ParseIP(ip) == nil {
+			return fmt.Errorf("invalid upstream IP: %s", ip)
+		}
+	}
+
+	if cluster.MinimumCacheTTL != nil && cluster.MaximumCacheTTL != nil &&
+		*cluster.MinimumCacheTTL > *cluster.MaximumCacheTTL {
+		return fmt.Errorf("minimum cache TTL is above the maximum")
+	}
+
+	return nil
+}
+
+// DNSFirewallAnalytics retrieves analytics of the queries a DNS Firewall
+// cluster answered.
+func (c Client) DNSFirewallAnalytics(accountID, clusterID string,
+	opts DNSAnalyticsOptions) (DNSAnalyticsReport, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return DNSAnalyticsReport{}, err
+	}
+	if len(accountID) == 0 || len(clusterID) == 0 {
+		return DNSAnalyticsReport{}, fmt.Errorf(
+			"you must provide an account ID and cluster ID")
+	}
+
+	if opts.Since.IsZero() || opts.Until.IsZero() {
+		return DNSAnalyticsReport{}, fmt.Errorf(
+			"you must provide a start and end time")
+	}
+
+	if !opts.Since.Before(opts.Until) {
+		return DNSAnalyticsReport{}, fmt.Errorf("since must be before until")
+	}
+
+	metrics := opts.Metrics
+	if len(metrics) == 0 {
+		metrics = []string{"queryCount"}
+	}
+
+	values := url.Values{}
+	values.Set("since", opts.Since.UTC().Format(time.RFC3339))
+	values.Set("until", opts.Until.UTC().Format(time.RFC3339))
+	values.Set("metrics", strings.Join(metrics, ","))
+	if len(opts.Dimensions) > 0 {
+		values.Set("dimensions", strings.Join(opts.Dimensions, ","))
+	}
+	if len(opts.Filters) > 0 {
+		values.Set("filters", opts.Filters)
+	}
+	if opts.Limit > 0 {
+		values.Set("limit", fmt.Sprintf("%d", opts.Limit))
+	}
+
+	var result struct {
+		Data []struct {
+			Dimensions []string  `json:"dimensions"`
+			Metrics    []float64 `json:"metrics"`
+		} `json:"data"`
+		Totals map[string]float64 `json:"totals"`
+	}
+	err = c.apiRequest("GET", dnsFirewallPath(accountID)+"/"+
+		url.QueryEscape(clusterID)+"/dns_analytics/report", values, nil, &result)
+	if err != nil {
+		return DNSAnalyticsReport{}, fmt.Errorf(
+			"DNS Firewall analytics error: %w", err)
+	}
+
+	// The API gives rows as lists in the order we asked for the dimensions
+	// and metrics.
+	report := DNSAnalyticsReport{Totals: result.Totals}
+	for _, data := range result.Data {
+		row := DNSAnalyticsRow{
+			Dimensions: map[string]string{},
+			Metrics:    map[string]float64{},
+		}
+		for i, value := range data.Dimensions {
+			if i < len(opts.Dimensions) {
+				row.Dimensions[opts.Dimensions[i]] = value
+			}
+		}
+		for i, value := range data.Metrics {
+			if i < len(metrics) {
+				row.Metrics[metrics[i]] = value
+			}
+		}
+		report.Rows = append(report.Rows, row)
+	}
+
+	return report, nil
+}
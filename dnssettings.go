@@ -0,0 +1,97 @@
+package cloudflare
+
+import "fmt"
+
+// Nameserver types a zone may use.
+const (
+	NameserversStandard       = "cloudflare.standard"
+	NameserversStandardRandom = "cloudflare.standard.random"
+	NameserversCustomAccount  = "custom.account"
+	NameserversCustomTenant   = "custom.tenant"
+	NameserversCustomZone     = "custom.zone"
+)
+
+// DNSSettings holds a zone's DNS settings. These are separate from the zone
+// settings read with GetZoneSetting.
+type DNSSettings struct {
+	// FlattenAllCNAMEs is whether CNAME records are flattened everywhere in
+	// the zone rather than only at its apex.
+	FlattenAllCNAMEs bool `json:"flatten_all_cnames"`
+
+	// FoundationDNS is whether the zone uses Foundation DNS's advanced
+	// nameservers.
+	FoundationDNS bool `json:"foundation_dns"`
+
+	// MultiProvider is whether the zone may use other DNS providers as well,
+	// honouring NS records at its apex.
+	MultiProvider bool `json:"multi_provider"`
+
+	Nameservers DNSSettingsNameservers `json:"nameservers"`
+
+	// NSTTL is the TTL of the zone's NS records.
+	NSTTL int `json:"ns_ttl"`
+
+	// SecondaryOverrides is whether a secondary zone's records may be
+	// overridden (e.g. to proxy them).
+	SecondaryOverrides bool `json:"secondary_overrides"`
+
+	// ZoneMode is standard, cdn_only, or dns_only.
+	ZoneMode string `json:"zone_mode"`
+}
+
+// DNSSettingsNameservers holds which nameservers a zone uses.
+type DNSSettingsNameservers struct {
+	// Type is one of the Nameservers constants.
+	Type string `json:"type"`
+
+	// NSSet is which set of custom nameservers to use, if Type is a custom
+	// type.
+	NSSet int `json:"ns_set,omitempty"`
+}
+
+// DNSSettingsUpdate holds changes to a zone's DNS settings. Fields left nil
+// are unchanged.
+type DNSSettingsUpdate struct {
+	FlattenAllCNAMEs   *bool                   `json:"flatten_all_cnames,omitempty"`
+	FoundationDNS      *bool                   `json:"foundation_dns,omitempty"`
+	MultiProvider      *bool                   `json:"multi_provider,omitempty"`
+	Nameservers        *DNSSettingsNameservers `json:"nameservers,omitempty"`
+	NSTTL              *int                    `json:"ns_ttl,omitempty"`
+	SecondaryOverrides *bool                   `json:"secondary_overrides,omitempty"`
+	ZoneMode           *string                 `json:"zone_mode,omitempty"`
+}
+
+// GetDNSSettings retrieves a zone's DNS settings, such as whether it
+// flattens all CNAMEs.
+func (c Client) GetDNSSettings(zoneID string) (DNSSettings, error) {
+	if len(zoneID) == 0 {
+		return DNSSettings{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var settings DNSSettings
+	err := c.apiRequest("GET", zonePrefix(zoneID)+"/dns_settings", nil, nil,
+		&settings)
+	if err != nil {
+		return DNSSettings{}, fmt.Errorf("get DNS settings error: %w", err)
+	}
+
+	return settings, nil
+}
+
+// UpdateDNSSettings changes a zone's DNS settings. We return the settings
+// afterwards.
+func (c Client) UpdateDNSSettings(zoneID string,
+	update DNSSettingsUpdate) (DNSSettings, error) {
+	if len(zoneID) == 0 {
+		return DNSSettings{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var settings DNSSettings
+	err := c.apiRequest("PATCH", zonePrefix(zoneID)+"/dns_settings", nil,
+		update, &settings)
+	if err != nil {
+		return DNSSettings{}, fmt.Errorf("update DNS settings error: %w", err)
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,44 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Do makes an arbitrary API request. Use it to reach endpoints this package
+// does not wrap yet.
+//
+// path is relative to the API's base URL, e.g. "zones/<id>/dns_records".
+// params may be nil. If body is not nil we send it JSON encoded. If out is
+// not nil we decode the result portion of the response into it. The request
+// is authenticated, rate limited, retried, and measured as for every other
+// request.
+//
+// We return an error if the API reports one.
+func (c Client) Do(method, path string, params url.Values, body,
+	out interface{}) error {
+	return c.DoContext(context.Background(), method, path, params, body, out)
+}
+
+// DoContext is Do with a context.
+func (c Client) DoContext(ctx context.Context, method, path string,
+	params url.Values, body, out interface{}) error {
+	if len(method) == 0 {
+		return fmt.Errorf("you must provide a method")
+	}
+
+	path = strings.TrimPrefix(path, "/")
+	if len(path) == 0 {
+		return fmt.Errorf("you must provide a path")
+	}
+
+	_, err := c.apiRequestContext(ctx, strings.ToUpper(method), path, params,
+		body, out)
+	if err != nil {
+		return fmt.Errorf("%s %s error: %w", strings.ToUpper(method), path, err)
+	}
+
+	return nil
+}
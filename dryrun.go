@@ -0,0 +1,48 @@
+package cloudflare
+
+// dryRunResponse is the response we pretend to get for a change in dry run
+// mode. Its result is null so decoding it leaves the caller's result alone.
+const dryRunResponse = `{"success":true,"errors":[],"messages":[],"result":null}`
+
+// WithDryRun makes the client log the changes it would make rather than
+// making them. Requests that only read, such as listing records or GraphQL
+// queries, are still made.
+//
+// Changes appear to succeed, so code making several changes goes through
+// them all and each is logged. What they return is empty: for example
+// CreateDNSRecord returns a record without an ID. Check IsDryRun before
+// relying on results. If Client.Recorder is set it is told about each change
+// with DryRun set.
+func WithDryRun() Option {
+	return func(c *Client) error {
+		c.dryRun = true
+		return nil
+	}
+}
+
+// DryRun returns a copy of the client in dry run mode (see WithDryRun).
+// For example:
+//
+//	err := client.DryRun().PurgeAllFiles(zoneID)
+func (c Client) DryRun() Client {
+	c.dryRun = true
+	return c
+}
+
+// IsDryRun reports whether the client is in dry run mode.
+func (c Client) IsDryRun() bool {
+	return c.dryRun
+}
+
+// pretend logs a change we would make and returns a response to record as
+// if we made it.
+func (c Client) pretend(method, url string, request []byte) ([]byte,
+	responseMeta) {
+	if len(request) > 0 {
+		c.logf("dry run: %s %s: %s", method, c.resourcePath(url), request)
+	} else {
+		c.logf("dry run: %s %s", method, c.resourcePath(url))
+	}
+
+	return []byte(dryRunResponse), responseMeta{StatusCode: 200}
+}
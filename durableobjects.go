@@ -0,0 +1,106 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// DurableObjectNamespace holds a Durable Object namespace. Each namespace
+// belongs to a Worker class.
+type DurableObjectNamespace struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Script    string `json:"script"`
+	Class     string `json:"class"`
+	UseSQLite bool   `json:"use_sqlite"`
+}
+
+// DurableObject is an object within a namespace.
+type DurableObject struct {
+	ID            string `json:"id"`
+	HasStoredData bool   `json:"hasStoredData"`
+}
+
+// ListDurableObjectNamespaces retrieves the account's Durable Object
+// namespaces.
+func (c Client) ListDurableObjectNamespaces(accountID string) (
+	[]DurableObjectNamespace, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var namespaces []DurableObjectNamespace
+	err = c.apiRequest("GET", accountPrefix(accountID)+
+		"/workers/durable_objects/namespaces", nil, nil, &namespaces)
+	if err != nil {
+		return nil, fmt.Errorf("list durable object namespaces error: %w", err)
+	}
+
+	return namespaces, nil
+}
+
+// ListDurableObjects retrieves a page of the objects in a namespace.
+//
+// cursor is blank for the first page. For later pages, pass the cursor we
+// returned. When we return a blank cursor there are no more pages. limit may
+// be 0 for the API's default.
+func (c Client) ListDurableObjects(accountID, namespaceID, cursor string,
+	limit int) ([]DurableObject, string, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(accountID) == 0 || len(namespaceID) == 0 {
+		return nil, "", fmt.Errorf(
+			"you must provide an account ID and namespace ID")
+	}
+
+	values := url.Values{}
+	if len(cursor) > 0 {
+		values.Set("cursor", cursor)
+	}
+	if limit > 0 {
+		values.Set("limit", strconv.Itoa(limit))
+	}
+
+	var objects []DurableObject
+	info, err := c.apiRequestInfo("GET", accountPrefix(accountID)+
+		"/workers/durable_objects/namespaces/"+url.QueryEscape(namespaceID)+
+		"/objects", values, nil, &objects)
+	if err != nil {
+		return nil, "", fmt.Errorf("list durable objects error: %w", err)
+	}
+
+	return objects, info.Cursor, nil
+}
+
+// ListAllDurableObjects retrieves every object in a namespace.
+func (c Client) ListAllDurableObjects(accountID, namespaceID string) (
+	[]DurableObject, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	allObjects := []DurableObject{}
+	cursor := ""
+
+	for {
+		objects, next, err := c.ListDurableObjects(accountID, namespaceID,
+			cursor, 10000)
+		if err != nil {
+			return nil, err
+		}
+
+		allObjects = append(allObjects, objects...)
+
+		if len(next) == 0 || len(objects) == 0 {
+			return allObjects, nil
+		}
+		cursor = next
+	}
+}
@@ -0,0 +1,196 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+)
+
+// Errors to compare against with errors.Is to find out why a request failed.
+//
+// For example:
+//
+//	err := client.UpdateDNSRecord(record)
+//	if errors.Is(err, cloudflare.ErrNotFound) {
+//		...
+//	}
+//
+// IsAuthError and the other Is functions do the same.
+var (
+	// ErrAuth means the credentials are invalid or not allowed to do what we
+	// asked.
+	ErrAuth = errors.New("authentication failed")
+
+	// ErrRateLimited means we made too many requests.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrNotFound means what we asked about does not exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrConflict means something changed since we read it. See
+	// ConflictError.
+	ErrConflict = errors.New("conflict")
+)
+
+// API error codes we classify. The API does not always give a matching HTTP
+// status.
+var (
+	authErrorCodes = map[int]struct{}{
+		6003:  {}, // Invalid request headers
+		9103:  {}, // Unknown X-Auth-Key or X-Auth-Email
+		9106:  {}, // Missing X-Auth-Key, X-Auth-Email or Authorization headers
+		9109:  {}, // Invalid access token
+		10000: {}, // Authentication error
+	}
+
+	rateLimitErrorCodes = map[int]struct{}{
+		971:   {}, // Please wait and consider throttling your request speed
+		10013: {}, // Rate limited
+	}
+
+	notFoundErrorCodes = map[int]struct{}{
+		1001:  {}, // Invalid zone identifier
+		7003:  {}, // Could not route to path, perhaps your object identifier is invalid
+		81044: {}, // Record does not exist
+	}
+)
+
+// APIError is an error response from the API.
+type APIError struct {
+	// StatusCode is the HTTP status of the response. It may be 0 if we don't
+	// know it.
+	StatusCode int
+
+	// RayID is the response's CF-Ray header. Cloudflare support can use it to
+	// find the request.
+	RayID string
+
+	// Errors are the errors the API gave.
+	Errors []Error
+}
+
+func (e *APIError) Error() string {
+	var msgs []string
+	for _, err := range e.Errors {
+		msgs = append(msgs, fmt.Sprintf("Code %d: %s", err.Code, err.Message))
+	}
+
+	msg := strings.Join(msgs, ", ")
+	if len(msgs) == 0 {
+		msg = fmt.Sprintf("HTTP status %d", e.StatusCode)
+	}
+
+	if len(e.RayID) > 0 {
+		msg += fmt.Sprintf(" (ray %s)", e.RayID)
+	}
+
+	return msg
+}
+
+// Is reports whether the error is one of ErrAuth, ErrRateLimited, or
+// ErrNotFound. This is what makes errors.Is work.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrAuth:
+		return e.StatusCode == http.StatusUnauthorized ||
+			e.StatusCode == http.StatusForbidden || e.hasCode(authErrorCodes)
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests ||
+			e.hasCode(rateLimitErrorCodes)
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound ||
+			e.hasCode(notFoundErrorCodes)
+	default:
+		return false
+	}
+}
+
+// Whether any of the errors have one of the codes.
+func (e *APIError) hasCode(codes map[int]struct{}) bool {
+	for _, err := range e.Errors {
+		if _, ok := codes[err.Code]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ConflictError means a record was modified by someone else since the caller
+// read it, so we did not change it.
+type ConflictError struct {
+	// Current is the record as it is now.
+	Current DNSRecord
+
+	// Expected is the modified time the caller's copy of the record had.
+	Expected string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf(
+		"record %s %s was modified at %s, after the copy we have (modified at %s)",
+		e.Current.Name, e.Current.Type, e.Current.ModifiedOn, e.Expected)
+}
+
+// Is reports whether target is ErrConflict.
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// IsAuthError reports whether the request failed because of the credentials.
+func IsAuthError(err error) bool {
+	return errors.Is(err, ErrAuth)
+}
+
+// IsRateLimited reports whether the request failed because we made too many
+// requests.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// IsNotFound reports whether the request failed because what we asked about
+// does not exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsTemporary reports whether the request failed in a way that may go away if
+// we try again later: we were rate limited, the API had a server error, the
+// request timed out, or the connection was cut off.
+//
+// Network errors that trying again won't fix, such as a host that does not
+// exist, a bad certificate, or a connection being refused, are not
+// temporary.
+func IsTemporary(err error) bool {
+	if IsRateLimited(err) {
+		return true
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
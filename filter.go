@@ -0,0 +1,186 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Fields a DNSRecordFilter may match on by comparison.
+const (
+	FilterName    = "name"
+	FilterContent = "content"
+	FilterComment = "comment"
+)
+
+// Comparisons a DNSRecordFilter may make.
+const (
+	FilterExact      = "exact"
+	FilterContains   = "contains"
+	FilterStartsWith = "startswith"
+	FilterEndsWith   = "endswith"
+)
+
+// DNSRecordFilter builds the query filters the API supports when listing DNS
+// records, such as matching names containing a string:
+//
+//	filter := cloudflare.DNSRecordFilter{}.
+//		Where(cloudflare.FilterName, cloudflare.FilterEndsWith, ".internal").
+//		Type("A").
+//		MatchAny()
+//
+// By default a record must match every condition. Each method returns a
+// new filter, so a filter may be extended without changing the original.
+// Mistakes such as an unknown comparison are reported when the filter is
+// used.
+type DNSRecordFilter struct {
+	conditions []filterCondition
+	matchAny   bool
+	err        error
+}
+
+type filterCondition struct {
+	param string
+	value string
+
+	// narrow means the condition always applies, even with MatchAny. The
+	// type and proxied conditions are like this.
+	narrow bool
+}
+
+// Where adds a condition comparing field (one of the Filter field constants)
+// to value using op (one of the Filter comparison constants).
+func (f DNSRecordFilter) Where(field, op, value string) DNSRecordFilter {
+	switch field {
+	case FilterName, FilterContent, FilterComment:
+	default:
+		return f.fail(fmt.Errorf("invalid filter field: %s", field))
+	}
+
+	switch op {
+	case FilterExact, FilterContains, FilterStartsWith, FilterEndsWith:
+	default:
+		return f.fail(fmt.Errorf("invalid filter comparison: %s", op))
+	}
+
+	if len(value) == 0 {
+		return f.fail(fmt.Errorf("filter on %s.%s needs a value", field, op))
+	}
+
+	return f.with(field+"."+op, value)
+}
+
+// Type adds a condition on the record's type, e.g. A.
+func (f DNSRecordFilter) Type(recordType string) DNSRecordFilter {
+	if !recordTypePattern.MatchString(recordType) {
+		return f.fail(fmt.Errorf("invalid record type: %s", recordType))
+	}
+
+	return f.withNarrow("type", recordType)
+}
+
+// Proxied adds a condition on whether the record is proxied.
+func (f DNSRecordFilter) Proxied(proxied bool) DNSRecordFilter {
+	return f.withNarrow("proxied", strconv.FormatBool(proxied))
+}
+
+// Tag adds a condition that the record has a tag. value may be blank to
+// match the tag with any value.
+func (f DNSRecordFilter) Tag(name, value string) DNSRecordFilter {
+	if len(name) == 0 || strings.Contains(name, ":") {
+		return f.fail(fmt.Errorf("invalid tag name: %q", name))
+	}
+
+	if len(value) == 0 {
+		return f.with("tag.present", name)
+	}
+
+	return f.with("tag", name+":"+value)
+}
+
+// MatchAny makes the filter match records meeting any of its conditions
+// rather than all of them.
+//
+// Conditions on type and proxied still always apply, as do the Type, Name,
+// and Content of DNSRecordListOptions, so they narrow the records matching
+// any of the other conditions. The API can't combine conditions this way, so
+// DNSRecords checks them against the records it receives.
+func (f DNSRecordFilter) MatchAny() DNSRecordFilter {
+	f.matchAny = true
+	return f
+}
+
+// Values encodes the filter as query parameters. It returns an error if the
+// filter was built incorrectly.
+//
+// With MatchAny, conditions on type and proxied are left out. See MatchAny.
+func (f DNSRecordFilter) Values() (url.Values, error) {
+	values, _, err := f.query()
+	return values, err
+}
+
+// query encodes the filter as query parameters. With MatchAny it also gives
+// the conditions we must check ourselves, as a function reporting whether a
+// record meets them.
+func (f DNSRecordFilter) query() (url.Values, func(DNSRecord) bool, error) {
+	if f.err != nil {
+		return nil, nil, f.err
+	}
+
+	values := url.Values{}
+	var narrow []filterCondition
+	anyConditions := 0
+	for _, condition := range f.conditions {
+		if f.matchAny && condition.narrow {
+			narrow = append(narrow, condition)
+			continue
+		}
+		values.Add(condition.param, condition.value)
+		anyConditions++
+	}
+
+	if f.matchAny {
+		if anyConditions < 2 {
+			return nil, nil, fmt.Errorf("matching any condition needs at " +
+				"least two besides type and proxied")
+		}
+		values.Set("match", "any")
+	}
+
+	return values, func(record DNSRecord) bool {
+		for _, condition := range narrow {
+			switch condition.param {
+			case "type":
+				if !strings.EqualFold(record.Type, condition.value) {
+					return false
+				}
+			case "proxied":
+				if strconv.FormatBool(record.Proxied) != condition.value {
+					return false
+				}
+			}
+		}
+		return true
+	}, nil
+}
+
+func (f DNSRecordFilter) with(param, value string) DNSRecordFilter {
+	f.conditions = append(f.conditions[:len(f.conditions):len(f.conditions)],
+		filterCondition{param: param, value: value})
+	return f
+}
+
+func (f DNSRecordFilter) withNarrow(param, value string) DNSRecordFilter {
+	f.conditions = append(f.conditions[:len(f.conditions):len(f.conditions)],
+		filterCondition{param: param, value: value, narrow: true})
+	return f
+}
+
+// We keep the first error.
+func (f DNSRecordFilter) fail(err error) DNSRecordFilter {
+	if f.err == nil {
+		f.err = err
+	}
+	return f
+}
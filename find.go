@@ -0,0 +1,135 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// How many zones FindDNSRecords searches at once by default.
+const defaultFindConcurrency = 4
+
+// FindDNSRecordsOptions filters the records FindDNSRecords looks for.
+type FindDNSRecordsOptions struct {
+	// Type and Content may be blank to not filter on them.
+	Type    string
+	Content string
+
+	// Concurrency is how many zones to search at once. Zero means 4.
+	Concurrency int
+}
+
+// FindDNSRecords searches every zone on the account for DNS records. This is
+// useful for questions such as "where is this IP used?":
+//
+//	records, err := client.FindDNSRecords("", cloudflare.FindDNSRecordsOptions{
+//		Content: "192.0.2.1",
+//	})
+//
+// name is the record's name. It may be blank to find records with any name,
+// but then you should give Content. If name is given we only search zones it
+// could be in.
+//
+// Each record's ZoneID and ZoneName say which zone it is in. They are sorted
+// by zone name and then record name.
+func (c Client) FindDNSRecords(name string,
+	opts FindDNSRecordsOptions) ([]DNSRecord, error) {
+	if len(name) == 0 && len(opts.Content) == 0 {
+		return nil, fmt.Errorf("you must provide a name or content")
+	}
+
+	name = NormalizeName(name)
+
+	zones, err := c.ListAllZones("", "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list zones: %w", err)
+	}
+
+	var searchZones []Zone
+	for _, zone := range zones {
+		if len(name) == 0 || name == zone.Name ||
+			strings.HasSuffix(name, "."+zone.Name) {
+			searchZones = append(searchZones, zone)
+		}
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultFindConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mutex sync.Mutex
+	var found []DNSRecord
+	var firstErr error
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for _, zone := range searchZones {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(zone Zone) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			records, err := c.findInZone(ctx, zone, name, opts)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("zone %s: %w", zone.Name, err)
+					cancel()
+				}
+				return
+			}
+			found = append(found, records...)
+		}(zone)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].ZoneName != found[j].ZoneName {
+			return found[i].ZoneName < found[j].ZoneName
+		}
+		return found[i].Name < found[j].Name
+	})
+
+	return found, nil
+}
+
+// Search a zone for records.
+func (c Client) findInZone(ctx context.Context, zone Zone, name string,
+	opts FindDNSRecordsOptions) ([]DNSRecord, error) {
+	var records []DNSRecord
+
+	for record, err := range c.DNSRecords(ctx, zone.ID, DNSRecordListOptions{
+		Type:    opts.Type,
+		Name:    name,
+		Content: opts.Content,
+	}) {
+		if err != nil {
+			return nil, err
+		}
+
+		// Not every response includes these.
+		record.ZoneID = zone.ID
+		record.ZoneName = zone.Name
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,204 @@
+package cloudflare
+
+import (
+	"fmt"
+	"time"
+)
+
+// FirewallEvent is a request a security feature acted on, such as one a WAF
+// rule blocked.
+type FirewallEvent struct {
+	Time      time.Time `json:"time"`
+	Action    string    `json:"action"`
+	Source    string    `json:"source"`
+	RuleID    string    `json:"rule_id"`
+	RayID     string    `json:"ray_id"`
+	ClientIP  string    `json:"client_ip"`
+	Country   string    `json:"country"`
+	Method    string    `json:"method"`
+	Host      string    `json:"host"`
+	Path      string    `json:"path"`
+	Query     string    `json:"query"`
+	UserAgent string    `json:"user_agent"`
+}
+
+// FirewallEventsOptions selects which events FirewallEvents retrieves.
+type FirewallEventsOptions struct {
+	// Since and Until bound the time range. Since is inclusive and Until is
+	// exclusive. Until defaults to now.
+	Since time.Time
+	Until time.Time
+
+	// Action (e.g. block), RuleID, ClientIP, and Source (e.g. firewallCustom)
+	// may be blank to not filter on them.
+	Action   string
+	RuleID   string
+	ClientIP string
+	Source   string
+
+	// Limit is the most events to return. Zero means 1000.
+	Limit int
+}
+
+// The most events the API gives per query.
+const firewallEventsPageSize = 1000
+
+// FirewallEvents retrieves a zone's firewall events, most recent first.
+//
+// We make as many queries as needed to get up to opts.Limit events. Note the
+// API limits how far back you can query depending on your plan.
+func (c Client) FirewallEvents(zoneID string,
+	opts FirewallEventsOptions) ([]FirewallEvent, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	if opts.Since.IsZero() {
+		return nil, fmt.Errorf("you must provide a start time")
+	}
+
+	until := opts.Until
+	if until.IsZero() {
+		until = time.Now()
+	}
+
+	if !opts.Since.Before(until) {
+		return nil, fmt.Errorf("since must be before until")
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 1000
+	}
+
+	// We page backwards through time. Events may share a timestamp, so each
+	// page starts at the last page's oldest time and we skip events we saw.
+	var events []FirewallEvent
+	seen := map[string]struct{}{}
+	cursor := until
+	inclusive := false
+
+	for len(events) < limit {
+		pageSize := min(firewallEventsPageSize, limit)
+		page, err := c.firewallEventsPage(zoneID, opts, cursor, inclusive,
+			pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("firewall events error: %w", err)
+		}
+
+		added := 0
+		for _, event := range page {
+			if _, ok := seen[event.RayID]; ok {
+				continue
+			}
+			seen[event.RayID] = struct{}{}
+			events = append(events, event)
+			added++
+			if len(events) == limit {
+				break
+			}
+		}
+
+		if len(page) < pageSize || added == 0 {
+			break
+		}
+
+		cursor = page[len(page)-1].Time
+		inclusive = true
+	}
+
+	return events, nil
+}
+
+func (c Client) firewallEventsPage(zoneID string, opts FirewallEventsOptions,
+	until time.Time, inclusive bool, limit int) ([]FirewallEvent, error) {
+	untilOp := "datetime_lt"
+	if inclusive {
+		untilOp = "datetime_leq"
+	}
+
+	filter := map[string]interface{}{
+		"datetime_geq": opts.Since.UTC().Format(time.RFC3339),
+		untilOp:        until.UTC().Format(time.RFC3339),
+	}
+	if len(opts.Action) > 0 {
+		filter["action"] = opts.Action
+	}
+	if len(opts.RuleID) > 0 {
+		filter["ruleId"] = opts.RuleID
+	}
+	if len(opts.ClientIP) > 0 {
+		filter["clientIP"] = opts.ClientIP
+	}
+	if len(opts.Source) > 0 {
+		filter["source"] = opts.Source
+	}
+
+	query := `query($zoneTag: string, $filter: FirewallEventsAdaptiveFilter_InputObject, $limit: uint64!) {
+  viewer {
+    zones(filter: {zoneTag: $zoneTag}) {
+      events: firewallEventsAdaptive(filter: $filter, limit: $limit, orderBy: [datetime_DESC]) {
+        datetime action source ruleId rayName clientIP clientCountryName
+        clientRequestHTTPMethodName clientRequestHTTPHost clientRequestPath
+        clientRequestQuery userAgent
+      }
+    }
+  }
+}`
+
+	variables := map[string]interface{}{
+		"zoneTag": zoneID,
+		"filter":  filter,
+		"limit":   limit,
+	}
+
+	var result struct {
+		Viewer struct {
+			Zones []struct {
+				Events []struct {
+					Datetime  time.Time `json:"datetime"`
+					Action    string    `json:"action"`
+					Source    string    `json:"source"`
+					RuleID    string    `json:"ruleId"`
+					RayName   string    `json:"rayName"`
+					ClientIP  string    `json:"clientIP"`
+					Country   string    `json:"clientCountryName"`
+					Method    string    `json:"clientRequestHTTPMethodName"`
+					Host      string    `json:"clientRequestHTTPHost"`
+					Path      string    `json:"clientRequestPath"`
+					Query     string    `json:"clientRequestQuery"`
+					UserAgent string    `json:"userAgent"`
+				} `json:"events"`
+			} `json:"zones"`
+		} `json:"viewer"`
+	}
+
+	err := c.graphqlRequest(query, variables, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Viewer.Zones) == 0 {
+		return nil, fmt.Errorf("zone not found: %s", zoneID)
+	}
+
+	var events []FirewallEvent
+	for _, e := range result.Viewer.Zones[0].Events {
+		events = append(events, FirewallEvent{
+			Time:      e.Datetime,
+			Action:    e.Action,
+			Source:    e.Source,
+			RuleID:    e.RuleID,
+			RayID:     e.RayName,
+			ClientIP:  e.ClientIP,
+			Country:   e.Country,
+			Method:    e.Method,
+			Host:      e.Host,
+			Path:      e.Path,
+			Query:     e.Query,
+			UserAgent: e.UserAgent,
+		})
+	}
+
+	return events, nil
+}
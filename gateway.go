@@ -0,0 +1,519 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Zero Trust Gateway filters the DNS, HTTP, and network traffic of an
+// organization's users and devices. Rules decide what happens to traffic,
+// lists hold values rules match against, and locations are the networks
+// (such as offices) whose DNS queries Gateway filters.
+
+// Gateway rule filters. These say which kind of traffic a rule applies to.
+const (
+	GatewayFilterDNS  = "dns"
+	GatewayFilterHTTP = "http"
+	GatewayFilterL4   = "l4"
+)
+
+// Gateway rule actions. Not all apply to every filter.
+const (
+	GatewayActionAllow        = "allow"
+	GatewayActionBlock        = "block"
+	GatewayActionOn           = "on"
+	GatewayActionOff          = "off"
+	GatewayActionIsolate      = "isolate"
+	GatewayActionNoIsolate    = "noisolate"
+	GatewayActionOverride     = "override"
+	GatewayActionL4Override   = "l4_override"
+	GatewayActionEgress       = "egress"
+	GatewayActionResolve      = "resolve"
+	GatewayActionSafeSearch   = "safesearch"
+	GatewayActionYTRestricted = "ytrestricted"
+	GatewayActionScan         = "scan"
+	GatewayActionNoScan       = "noscan"
+)
+
+// Gateway list types.
+const (
+	GatewayListSerial = "SERIAL"
+	GatewayListURL    = "URL"
+	GatewayListDomain = "DOMAIN"
+	GatewayListEmail  = "EMAIL"
+	GatewayListIP     = "IP"
+)
+
+// GatewayRule is a Gateway policy.
+type GatewayRule struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// Precedence orders rules. Lower runs first.
+	Precedence int    `json:"precedence"`
+	Enabled    bool   `json:"enabled"`
+	Action     string `json:"action"`
+
+	// Filters is the kind of traffic the rule applies to, one of the
+	// GatewayFilter constants.
+	Filters []string `json:"filters"`
+
+	// Traffic, Identity, and DevicePosture are expressions the traffic must
+	// match, e.g. any(dns.domains[*] == "example.com"). They may be blank.
+	Traffic       string `json:"traffic"`
+	Identity      string `json:"identity"`
+	DevicePosture string `json:"device_posture"`
+
+	// RuleSettings configures the action, such as the block page. It is
+	// passed as is.
+	RuleSettings map[string]interface{} `json:"rule_settings,omitempty"`
+
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// GatewayList is a list of values Gateway rules can match against, e.g.
+// $<list ID> in a traffic expression.
+type GatewayList struct {
+	ID          string            `json:"id,omitempty"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Type        string            `json:"type"`
+	Count       int               `json:"count,omitempty"`
+	Items       []GatewayListItem `json:"items,omitempty"`
+	CreatedAt   string            `json:"created_at,omitempty"`
+	UpdatedAt   string            `json:"updated_at,omitempty"`
+}
+
+// GatewayListItem is a value in a Gateway list.
+type GatewayListItem struct {
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+	CreatedAt   string `json:"created_at,omitempty"`
+}
+
+// GatewayLocation is a network whose DNS queries Gateway filters.
+type GatewayLocation struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+
+	// ClientDefault is whether this is the location for devices that don't
+	// match another.
+	ClientDefault bool `json:"client_default"`
+	ECSSupport    bool `json:"ecs_support"`
+
+	// Networks are the source IPv4 networks of the location in CIDR
+	// notation.
+	Networks []GatewayLocationNetwork `json:"networks"`
+
+	// DOHSubdomain and IP are set by the API. Point the location's resolvers
+	// at them.
+	DOHSubdomain string `json:"doh_subdomain,omitempty"`
+	IP           string `json:"ip,omitempty"`
+
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// GatewayLocationNetwork is a network of a location.
+type GatewayLocationNetwork struct {
+	Network string `json:"network"`
+}
+
+func gatewayPath(accountID string) string {
+	return accountPrefix(accountID) + "/gateway"
+}
+
+// ListGatewayRules lists an account's Gateway rules.
+func (c Client) ListGatewayRules(accountID string) ([]GatewayRule, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var rules []GatewayRule
+	err = c.apiRequest("GET", gatewayPath(accountID)+"/rules", nil, nil,
+		&rules)
+	if err != nil {
+		return nil, fmt.Errorf("list Gateway rules error: %w", err)
+	}
+
+	return rules, nil
+}
+
+// GetGatewayRule retrieves a Gateway rule.
+func (c Client) GetGatewayRule(accountID, ruleID string) (GatewayRule,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return GatewayRule{}, err
+	}
+	if len(accountID) == 0 || len(ruleID) == 0 {
+		return GatewayRule{}, fmt.Errorf(
+			"you must provide an account ID and rule ID")
+	}
+
+	var rule GatewayRule
+	err = c.apiRequest("GET", gatewayPath(accountID)+"/rules/"+
+		url.QueryEscape(ruleID), nil, nil, &rule)
+	if err != nil {
+		return GatewayRule{}, fmt.Errorf("get Gateway rule error: %w", err)
+	}
+
+	return rule, nil
+}
+
+// CreateGatewayRule creates a Gateway rule.
+func (c Client) CreateGatewayRule(accountID string,
+	rule GatewayRule) (GatewayRule, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return GatewayRule{}, err
+	}
+	if len(accountID) == 0 {
+		return GatewayRule{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	err = validateGatewayRule(rule)
+	if err != nil {
+		return GatewayRule{}, err
+	}
+
+	rule.ID = ""
+
+	var created GatewayRule
+	err = c.apiRequest("POST", gatewayPath(accountID)+"/rules", nil, rule,
+		&created)
+	if err != nil {
+		return GatewayRule{}, fmt.Errorf("create Gateway rule error: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateGatewayRule replaces a Gateway rule. Its ID must be set.
+func (c Client) UpdateGatewayRule(accountID string,
+	rule GatewayRule) (GatewayRule, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return GatewayRule{}, err
+	}
+	if len(accountID) == 0 || len(rule.ID) == 0 {
+		return GatewayRule{}, fmt.Errorf(
+			"you must provide an account ID and rule ID")
+	}
+
+	err = validateGatewayRule(rule)
+	if err != nil {
+		return GatewayRule{}, err
+	}
+
+	var updated GatewayRule
+	err = c.apiRequest("PUT", gatewayPath(accountID)+"/rules/"+
+		url.QueryEscape(rule.ID), nil, rule, &updated)
+	if err != nil {
+		return GatewayRule{}, fmt.Errorf("update Gateway rule error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteGatewayRule deletes a Gateway rule.
+func (c Client) DeleteGatewayRule(accountID, ruleID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(ruleID) == 0 {
+		return fmt.Errorf("you must provide an account ID and rule ID")
+	}
+
+	err = c.apiRequest("DELETE", gatewayPath(accountID)+"/rules/"+
+		url.QueryEscape(ruleID), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete Gateway rule error: %w", err)
+	}
+
+	return nil
+}
+
+// Check what we can about a Gateway rule before sending it.
+func validateGatewayRule(rule GatewayRule) error {
+	if len(rule.Name) == 0 || len(rule.Action) == 0 {
+		return fmt.Errorf("a Gateway rule needs a name and action")
+	}
+
+	if len(rule.Filters) == 0 {
+		return fmt.Errorf("a Gateway rule needs a filter (dns, http, or l4)")
+	}
+
+	for _, filter := range rule.Filters {
+		switch filter {
+		case GatewayFilterDNS, GatewayFilterHTTP, GatewayFilterL4:
+		default:
+			return fmt.Errorf("invalid Gateway rule filter: %q", filter)
+		}
+	}
+
+	for _, expr := range []string{rule.Traffic, rule.Identity,
+		rule.DevicePosture} {
+		if len(expr) == 0 {
+			continue
+		}
+		err := checkExpressionSyntax(expr)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListGatewayLists lists an account's Gateway lists. They do not include
+// their items. Use ListGatewayListItems for those.
+func (c Client) ListGatewayLists(accountID string) ([]GatewayList, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var lists []GatewayList
+	err = c.apiRequest("GET", gatewayPath(accountID)+"/lists", nil, nil,
+		&lists)
+	if err != nil {
+		return nil, fmt.Errorf("list Gateway lists error: %w", err)
+	}
+
+	return lists, nil
+}
+
+// ListGatewayListItems retrieves the items in a Gateway list.
+func (c Client) ListGatewayListItems(accountID,
+	listID string) ([]GatewayListItem, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 || len(listID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID and list ID")
+	}
+
+	// The items come in pages, each a list of items.
+	var pages [][]GatewayListItem
+	err = c.apiRequest("GET", gatewayPath(accountID)+"/lists/"+
+		url.QueryEscape(listID)+"/items", nil, nil, &pages)
+	if err != nil {
+		return nil, fmt.Errorf("list Gateway list items error: %w", err)
+	}
+
+	var items []GatewayListItem
+	for _, page := range pages {
+		items = append(items, page...)
+	}
+
+	return items, nil
+}
+
+// CreateGatewayList creates a Gateway list with its items.
+func (c Client) CreateGatewayList(accountID string,
+	list GatewayList) (GatewayList, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return GatewayList{}, err
+	}
+	if len(accountID) == 0 {
+		return GatewayList{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	if len(list.Name) == 0 {
+		return GatewayList{}, fmt.Errorf("you must provide a list name")
+	}
+
+	switch list.Type {
+	case GatewayListSerial, GatewayListURL, GatewayListDomain,
+		GatewayListEmail, GatewayListIP:
+	default:
+		return GatewayList{}, fmt.Errorf("invalid Gateway list type: %q",
+			list.Type)
+	}
+
+	list.ID = ""
+	list.Count = 0
+
+	var created GatewayList
+	err = c.apiRequest("POST", gatewayPath(accountID)+"/lists", nil, list,
+		&created)
+	if err != nil {
+		return GatewayList{}, fmt.Errorf("create Gateway list error: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateGatewayListItems adds and removes items from a Gateway list. remove
+// holds the values to remove.
+func (c Client) UpdateGatewayListItems(accountID, listID string,
+	add []GatewayListItem, remove []string) (GatewayList, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return GatewayList{}, err
+	}
+	if len(accountID) == 0 || len(listID) == 0 {
+		return GatewayList{}, fmt.Errorf(
+			"you must provide an account ID and list ID")
+	}
+
+	payload := struct {
+		Append []GatewayListItem `json:"append,omitempty"`
+		Remove []string          `json:"remove,omitempty"`
+	}{add, remove}
+
+	var updated GatewayList
+	err = c.apiRequest("PATCH", gatewayPath(accountID)+"/lists/"+
+		url.QueryEscape(listID), nil, payload, &updated)
+	if err != nil {
+		return GatewayList{}, fmt.Errorf(
+			"update Gateway list items error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteGatewayList deletes a Gateway list. Rules must not use it.
+func (c Client) DeleteGatewayList(accountID, listID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(listID) == 0 {
+		return fmt.Errorf("you must provide an account ID and list ID")
+	}
+
+	err = c.apiRequest("DELETE", gatewayPath(accountID)+"/lists/"+
+		url.QueryEscape(listID), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete Gateway list error: %w", err)
+	}
+
+	return nil
+}
+
+// ListGatewayLocations lists an account's Gateway locations.
+func (c Client) ListGatewayLocations(accountID string) ([]GatewayLocation,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var locations []GatewayLocation
+	err = c.apiRequest("GET", gatewayPath(accountID)+"/locations", nil, nil,
+		&locations)
+	if err != nil {
+		return nil, fmt.Errorf("list Gateway locations error: %w", err)
+	}
+
+	return locations, nil
+}
+
+// CreateGatewayLocation creates a Gateway location.
+func (c Client) CreateGatewayLocation(accountID string,
+	location GatewayLocation) (GatewayLocation, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return GatewayLocation{}, err
+	}
+	if len(accountID) == 0 {
+		return GatewayLocation{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	err = validateGatewayLocation(location)
+	if err != nil {
+		return GatewayLocation{}, err
+	}
+
+	location.ID = ""
+
+	var created GatewayLocation
+	err = c.apiRequest("POST", gatewayPath(accountID)+"/locations", nil,
+		location, &created)
+	if err != nil {
+		return GatewayLocation{}, fmt.Errorf(
+			"create Gateway location error: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateGatewayLocation replaces a Gateway location. Its ID must be set.
+func (c Client) UpdateGatewayLocation(accountID string,
+	location GatewayLocation) (GatewayLocation, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return GatewayLocation{}, err
+	}
+	if len(accountID) == 0 || len(location.ID) == 0 {
+		return GatewayLocation{}, fmt.Errorf(
+			"you must provide an account ID and location ID")
+	}
+
+	err = validateGatewayLocation(location)
+	if err != nil {
+		return GatewayLocation{}, err
+	}
+
+	var updated GatewayLocation
+	err = c.apiRequest("PUT", gatewayPath(accountID)+"/locations/"+
+		url.QueryEscape(location.ID), nil, location, &updated)
+	if err != nil {
+		return GatewayLocation{}, fmt.Errorf(
+			"update Gateway location error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteGatewayLocation deletes a Gateway location.
+func (c Client) DeleteGatewayLocation(accountID, locationID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(locationID) == 0 {
+		return fmt.Errorf("you must provide an account ID and location ID")
+	}
+
+	err = c.apiRequest("DELETE", gatewayPath(accountID)+"/locations/"+
+		url.QueryEscape(locationID), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete Gateway location error: %w", err)
+	}
+
+	return nil
+}
+
+func validateGatewayLocation(location GatewayLocation) error {
+	if len(location.Name) == 0 {
+		return fmt.Errorf("you must provide a location name")
+	}
+
+	for _, network := range location.Networks {
+		if !strings.Contains(network.Network, "/") {
+			return fmt.Errorf("location network must be in CIDR notation: %s",
+				network.Network)
+		}
+	}
+
+	return nil
+}
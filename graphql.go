@@ -0,0 +1,61 @@
+package cloudflare
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// graphqlError is an error from the GraphQL API.
+type graphqlError struct {
+	Message string `json:"message"`
+}
+
+// graphqlRequest makes a query against the GraphQL API and decodes the data
+// portion of the response into result.
+//
+// The GraphQL API does not use the same response envelope as the rest of the
+// API. Instead it has data and errors.
+func (c Client) graphqlRequest(query string, variables map[string]interface{},
+	result interface{}) error {
+	payload := map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("unable to encode to JSON: %w", err)
+	}
+
+	body, err := c.request("POST", c.endpoint()+"graphql",
+		bytes.NewReader(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("API request failure: %w", err)
+	}
+
+	var response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []graphqlError  `json:"errors"`
+	}
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return fmt.Errorf("JSON decoding problem: %s: %s", err, body)
+	}
+
+	if len(response.Errors) > 0 {
+		var msgs []string
+		for _, e := range response.Errors {
+			msgs = append(msgs, e.Message)
+		}
+		return fmt.Errorf("GraphQL error: %s", strings.Join(msgs, ", "))
+	}
+
+	err = json.Unmarshal(response.Data, result)
+	if err != nil {
+		return fmt.Errorf("JSON decoding problem: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,270 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+)
+
+// IntelCategory is a content or risk category Cloudflare assigns domains and
+// IPs, e.g. Malware.
+type IntelCategory struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	SuperCategoryID int    `json:"super_category_id,omitempty"`
+	Description     string `json:"description,omitempty"`
+}
+
+// IntelDomain holds what Cloudflare knows about a domain.
+type IntelDomain struct {
+	Domain            string          `json:"domain"`
+	ContentCategories []IntelCategory `json:"content_categories"`
+	RiskTypes         []IntelCategory `json:"risk_types"`
+
+	// PopularityRank is the domain's rank by traffic. Zero if unranked.
+	PopularityRank int `json:"popularity_rank"`
+
+	// RiskScore is from 0 (safe) to 1 (risky).
+	RiskScore float64 `json:"risk_score"`
+
+	Application *struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"application,omitempty"`
+
+	ResolvesToRefs []struct {
+		ID    string `json:"id"`
+		Value string `json:"value"`
+	} `json:"resolves_to_refs"`
+}
+
+// IntelDomainHistory holds how a domain was categorized over time.
+type IntelDomainHistory struct {
+	Domain          string                      `json:"domain"`
+	Categorizations []IntelDomainCategorization `json:"categorizations"`
+}
+
+// IntelDomainCategorization is a domain's categories for a period.
+type IntelDomainCategorization struct {
+	Categories []IntelCategory `json:"categories"`
+	Start      string          `json:"start"`
+	End        string          `json:"end"`
+}
+
+// IntelIP holds what Cloudflare knows about an IP.
+type IntelIP struct {
+	IP string `json:"ip"`
+
+	// BelongsTo is the network announcing the IP.
+	BelongsTo struct {
+		ID          interface{} `json:"id"`
+		Value       string      `json:"value"`
+		Type        string      `json:"type"`
+		Country     string      `json:"country"`
+		Description string      `json:"description"`
+	} `json:"belongs_to_ref"`
+
+	RiskTypes []IntelCategory `json:"risk_types"`
+
+	PTRLookup struct {
+		PTRRecords []string `json:"ptr_records"`
+	} `json:"ptr_lookup"`
+}
+
+// PassiveDNSRecord is a hostname seen resolving to an IP.
+type PassiveDNSRecord struct {
+	Hostname  string `json:"hostname"`
+	FirstSeen string `json:"first_seen"`
+	LastSeen  string `json:"last_seen"`
+}
+
+// WHOISRecord holds a domain's registration data.
+type WHOISRecord struct {
+	Domain            string   `json:"domain"`
+	Registrar         string   `json:"registrar"`
+	CreatedDate       string   `json:"created_date"`
+	UpdatedDate       string   `json:"updated_date"`
+	ExpirationDate    string   `json:"expiration_date"`
+	Nameservers       []string `json:"nameservers"`
+	Registrant        string   `json:"registrant"`
+	RegistrantOrg     string   `json:"registrant_org"`
+	RegistrantCountry string   `json:"registrant_country"`
+	RegistrantEmail   string   `json:"registrant_email"`
+	DNSSEC            bool     `json:"dnssec"`
+	Status            []string `json:"status"`
+
+	// Found is false if the domain has no WHOIS record.
+	Found bool `json:"found"`
+}
+
+func intelPath(accountID, endpoint string) string {
+	return accountPrefix(accountID) + "/intel/" + endpoint
+}
+
+// GetIntelDomain retrieves what Cloudflare knows about a domain, such as its
+// content categories and risk types.
+func (c Client) GetIntelDomain(accountID, domain string) (IntelDomain,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return IntelDomain{}, err
+	}
+	if len(accountID) == 0 || len(domain) == 0 {
+		return IntelDomain{}, fmt.Errorf(
+			"you must provide an account ID and domain")
+	}
+
+	values := url.Values{}
+	values.Set("domain", domain)
+
+	var info IntelDomain
+	err = c.apiRequest("GET", intelPath(accountID, "domain"), values, nil,
+		&info)
+	if err != nil {
+		return IntelDomain{}, fmt.Errorf("get intel domain error: %w", err)
+	}
+
+	return info, nil
+}
+
+// GetIntelDomainHistory retrieves how a domain was categorized over time.
+func (c Client) GetIntelDomainHistory(accountID,
+	domain string) ([]IntelDomainCategorization, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 || len(domain) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID and domain")
+	}
+
+	values := url.Values{}
+	values.Set("domain", domain)
+
+	var histories []IntelDomainHistory
+	err = c.apiRequest("GET", intelPath(accountID, "domain-history"), values,
+		nil, &histories)
+	if err != nil {
+		return nil, fmt.Errorf("get intel domain history error: %w", err)
+	}
+
+	for _, history := range histories {
+		if history.Domain == domain {
+			return history.Categorizations, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetIntelIP retrieves what Cloudflare knows about an IP, such as the
+// network it belongs to and its risk types.
+func (c Client) GetIntelIP(accountID, ip string) (IntelIP, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return IntelIP{}, err
+	}
+	if len(accountID) == 0 {
+		return IntelIP{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	values, err := intelIPValues(ip)
+	if err != nil {
+		return IntelIP{}, err
+	}
+
+	var infos []IntelIP
+	err = c.apiRequest("GET", intelPath(accountID, "ip"), values, nil, &infos)
+	if err != nil {
+		return IntelIP{}, fmt.Errorf("get intel IP error: %w", err)
+	}
+
+	if len(infos) == 0 {
+		return IntelIP{}, fmt.Errorf("no intel for IP %s: %w", ip, ErrNotFound)
+	}
+
+	return infos[0], nil
+}
+
+// ListPassiveDNS lists the hostnames seen resolving to an IP.
+func (c Client) ListPassiveDNS(accountID, ip string) ([]PassiveDNSRecord,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	values, err := intelIPValues(ip)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []PassiveDNSRecord
+	for page := 1; ; page++ {
+		values.Set("page", strconv.Itoa(page))
+		values.Set("per_page", "100")
+
+		var result struct {
+			Count          int                `json:"count"`
+			ReverseRecords []PassiveDNSRecord `json:"reverse_records"`
+		}
+		err := c.apiRequest("GET", intelPath(accountID, "dns"), values, nil,
+			&result)
+		if err != nil {
+			return nil, fmt.Errorf("list passive DNS error: %w", err)
+		}
+
+		records = append(records, result.ReverseRecords...)
+
+		if len(result.ReverseRecords) == 0 || len(records) >= result.Count {
+			break
+		}
+	}
+
+	return records, nil
+}
+
+// GetWHOIS retrieves a domain's WHOIS record.
+func (c Client) GetWHOIS(accountID, domain string) (WHOISRecord, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return WHOISRecord{}, err
+	}
+	if len(accountID) == 0 || len(domain) == 0 {
+		return WHOISRecord{}, fmt.Errorf(
+			"you must provide an account ID and domain")
+	}
+
+	values := url.Values{}
+	values.Set("domain", domain)
+
+	var record WHOISRecord
+	err = c.apiRequest("GET", intelPath(accountID, "whois"), values, nil,
+		&record)
+	if err != nil {
+		return WHOISRecord{}, fmt.Errorf("get WHOIS error: %w", err)
+	}
+
+	return record, nil
+}
+
+// The Intel endpoints take an IP as either ipv4 or ipv6.
+func intelIPValues(ip string) (url.Values, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return nil, fmt.Errorf("invalid IP: %s", ip)
+	}
+
+	values := url.Values{}
+	if parsed.To4() != nil {
+		values.Set("ipv4", ip)
+	} else {
+		values.Set("ipv6", ip)
+	}
+
+	return values, nil
+}
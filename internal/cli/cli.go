@@ -0,0 +1,306 @@
+// Package cli holds what the command line programs have in common: exit
+// codes, credential flags, and flag types.
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/horgh/cloudflare"
+	"github.com/horgh/cloudflare/config"
+)
+
+// Exit codes used by every command.
+const (
+	// ExitOK means the command succeeded.
+	ExitOK = 0
+
+	// ExitFailure means the command failed while doing its work.
+	ExitFailure = 1
+
+	// ExitUsage means the command was invoked incorrectly.
+	ExitUsage = 2
+
+	// ExitAuthFailure means the API rejected our credentials. Commands that
+	// distinguish failures use this and ExitTemporaryFailure.
+	ExitAuthFailure = 3
+
+	// ExitTemporaryFailure means the command failed in a way that may go away
+	// if tried again later, such as being rate limited or requests timing
+	// out.
+	ExitTemporaryFailure = 4
+)
+
+// FailureExitCode returns the exit code for a command that failed with the
+// error: ExitAuthFailure, ExitTemporaryFailure, or otherwise ExitFailure.
+func FailureExitCode(err error) int {
+	if cloudflare.IsAuthError(err) {
+		return ExitAuthFailure
+	}
+	if cloudflare.IsTemporary(err) {
+		return ExitTemporaryFailure
+	}
+	return ExitFailure
+}
+
+// Credentials holds what we need to talk to the API.
+type Credentials struct {
+	Email string
+
+	// Key is the API key if we have it. Otherwise KeyFile is set.
+	Key     string
+	KeyFile string
+
+	// Token is an API token if we have it. Otherwise TokenFile or
+	// TokenCommand may be set. If any is set we use the token rather than the
+	// email and key.
+	Token        string
+	TokenFile    string
+	TokenCommand string
+
+	// Domain is the default domain from the profile, if any.
+	Domain string
+
+	// StrictKeyFile means to refuse to read key and token files other users
+	// can read rather than warning.
+	StrictKeyFile bool
+
+	Verbose bool
+}
+
+// CredentialFlags are the flags every command takes for its credentials.
+type CredentialFlags struct {
+	email        *string
+	keyFile      *string
+	tokenFile    *string
+	tokenCommand *string
+	profile      *string
+	configFile   *string
+	strictKey    *bool
+	verbose      *bool
+}
+
+// AddCredentialFlags defines the credential flags on the flag set.
+func AddCredentialFlags(fs *flag.FlagSet) *CredentialFlags {
+	return &CredentialFlags{
+		email:        fs.String("email", "", "Email address on your Cloudflare account."),
+		keyFile:      fs.String("key-file", "", "Path to file containing API key. The file should contain nothing but your key. Give env:NAME to read it from an environment variable, fd:N from a file descriptor, or - from stdin. This is under Profile -> API Tokens -> API Keys."),
+		tokenFile:    fs.String("token-file", "", "Path to file containing an API token. Use this rather than -email and -key-file if you have a token."),
+		tokenCommand: fs.String("token-command", "", "Command that outputs an API token, e.g. \"pass show cf-token\". It is split on spaces and run without a shell. Use this rather than -token-file to fetch the token from a secret store. You may not give both."),
+		profile:      fs.String("profile", "", "Profile to load from the config file. Flags override its settings."),
+		configFile:   fs.String("config", "", "Path to the config file. Defaults to ~/.config/cloudflare/config."),
+		strictKey:    fs.Bool("strict-key-file", false, "Refuse to read a key or token file that other users can read, rather than warning."),
+		verbose:      fs.Bool("verbose", false, "Toggle verbose output."),
+	}
+}
+
+// Load resolves the credentials after the flags are parsed.
+//
+// If a profile or config file was given we load the profile and use its
+// settings where flags were not given.
+func (f *CredentialFlags) Load() (Credentials, error) {
+	creds := Credentials{
+		Email:         *f.email,
+		KeyFile:       *f.keyFile,
+		TokenFile:     *f.tokenFile,
+		TokenCommand:  *f.tokenCommand,
+		StrictKeyFile: *f.strictKey,
+		Verbose:       *f.verbose,
+	}
+
+	if len(*f.profile) > 0 || len(*f.configFile) > 0 {
+		p, err := config.LoadProfile(*f.configFile, *f.profile)
+		if err != nil {
+			return Credentials{}, err
+		}
+
+		if len(creds.Email) == 0 {
+			creds.Email = p.Email
+		}
+		if len(creds.KeyFile) == 0 {
+			creds.KeyFile = p.KeyFile
+			creds.Key = p.Key
+		}
+		if len(creds.TokenFile) == 0 && len(creds.TokenCommand) == 0 &&
+			len(*f.email) == 0 && len(*f.keyFile) == 0 {
+			creds.TokenFile = p.TokenFile
+			creds.Token = p.Token
+			creds.TokenCommand = p.TokenCommand
+		}
+		creds.Domain = p.Domain
+	}
+
+	tokenSources := 0
+	for _, source := range []string{creds.Token, creds.TokenFile,
+		creds.TokenCommand} {
+		if len(source) > 0 {
+			tokenSources++
+		}
+	}
+
+	if tokenSources > 1 {
+		return Credentials{}, fmt.Errorf(
+			"you may only provide one of a token, token file, or token command")
+	}
+
+	if tokenSources == 1 {
+		return creds, nil
+	}
+
+	if len(creds.Email) == 0 {
+		return Credentials{}, fmt.Errorf("you must provide an API token or an email")
+	}
+
+	if len(creds.KeyFile) == 0 && len(creds.Key) == 0 {
+		return Credentials{}, fmt.Errorf("you must provide an API key file")
+	}
+
+	return creds, nil
+}
+
+// How long we use the token from a token command before running it again.
+const tokenCommandTTL = time.Hour
+
+// Client creates an API client, reading the token or key if necessary. With
+// a token command we run it when we first need the token and then hourly.
+// opts configure the client further.
+func (c Credentials) Client(opts ...cloudflare.Option) (cloudflare.Client,
+	error) {
+	var auth cloudflare.Option
+	command := strings.Fields(c.TokenCommand)
+
+	policy := cloudflare.PermissionWarn
+	if c.StrictKeyFile {
+		policy = cloudflare.PermissionFail
+	}
+	readOpt := cloudflare.WithPermissionPolicy(policy)
+
+	if len(c.Token) > 0 || len(c.TokenFile) > 0 {
+		token := c.Token
+		if token == "" {
+			var err error
+			token, err = cloudflare.ReadTokenFromFile(c.TokenFile, readOpt)
+			if err != nil {
+				return cloudflare.Client{}, fmt.Errorf("unable to read token: %w",
+					err)
+			}
+		}
+		auth = cloudflare.WithToken(token)
+	} else if len(command) > 0 {
+		auth = cloudflare.WithCredentialsProvider(cloudflare.CachedCredentials(
+			cloudflare.ExecCredentials(command[0], command[1:]...),
+			tokenCommandTTL))
+	} else {
+		key := c.Key
+		if key == "" {
+			var err error
+			key, err = cloudflare.ReadKeyFromFile(c.KeyFile, readOpt)
+			if err != nil {
+				return cloudflare.Client{}, fmt.Errorf("unable to read key: %w", err)
+			}
+		}
+		auth = cloudflare.WithKeyEmail(key, c.Email)
+	}
+
+	return cloudflare.New(append([]cloudflare.Option{
+		auth,
+		cloudflare.WithDebug(c.Verbose),
+	}, opts...)...)
+}
+
+// NewFlagSet creates a flag set for a command. It reports errors rather than
+// exiting so the command can return an exit code.
+//
+// It defines -version. See HandleVersion.
+func NewFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Bool("version", false, "Print the version and exit.")
+	return fs
+}
+
+// HandleVersion prints the version if the arguments include -version. If so
+// the command should exit without doing anything else.
+//
+// We look for the flag before parsing so that it works without the flags a
+// command otherwise requires.
+func HandleVersion(name string, arguments []string) bool {
+	for _, arg := range arguments {
+		if arg == "--" {
+			break
+		}
+
+		switch strings.TrimLeft(arg, "-") {
+		case "version", "version=true":
+			if strings.HasPrefix(arg, "-") {
+				PrintVersion(name)
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// PrintVersion prints the command's version.
+func PrintVersion(name string) {
+	fmt.Printf("%s %s\n", name, cloudflare.Version)
+}
+
+// UsageError reports a usage problem and returns ExitUsage.
+func UsageError(fs *flag.FlagSet, err error) int {
+	fmt.Fprintf(fs.Output(), "%s: %s\n", fs.Name(), err)
+	fs.PrintDefaults()
+	return ExitUsage
+}
+
+// StringList is a flag that may be given multiple times.
+type StringList []string
+
+func (s *StringList) String() string {
+	return strings.Join(*s, ", ")
+}
+
+// Set appends a value.
+func (s *StringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// Output formats.
+const (
+	// OutputText is human readable output.
+	OutputText = "text"
+
+	// OutputJSON is JSON on stdout. Logs still go to stderr.
+	OutputJSON = "json"
+)
+
+// AddOutputFlag defines the -output flag on the flag set.
+func AddOutputFlag(fs *flag.FlagSet) *string {
+	return fs.String("output", OutputText, "Output format: text or json. With json, results are written to stdout as JSON and logs go to stderr.")
+}
+
+// CheckOutput validates the value of the -output flag.
+func CheckOutput(output string) error {
+	if output != OutputText && output != OutputJSON {
+		return fmt.Errorf("output must be %s or %s", OutputText, OutputJSON)
+	}
+	return nil
+}
+
+// PrintJSON writes a value to stdout as JSON.
+func PrintJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(v)
+	if err != nil {
+		return fmt.Errorf("unable to encode to JSON: %w", err)
+	}
+
+	return nil
+}
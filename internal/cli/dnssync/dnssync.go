@@ -0,0 +1,237 @@
+// Package dnssync makes a zone's DNS records match a file describing them.
+//
+// The file lists the records the zone should have. We compare them with the
+// live records and create, update, and delete records to converge. With
+// -dry-run we print the plan and log each change rather than making it.
+//
+// The file may be YAML or JSON (decided by its extension):
+//
+//	zone: example.com
+//	records:
+//	  - name: "@"
+//	    type: A
+//	    content: 192.0.2.1
+//	    proxied: true
+//	  - name: www
+//	    type: CNAME
+//	    content: example.com
+//	    ttl: 300
+//	  - name: "@"
+//	    type: MX
+//	    content: mail.example.com
+//	    priority: 10
+//
+// Names may be relative to the zone ("www"), "@" for the zone itself, or fully
+// qualified. A TTL of 1 or no TTL means automatic.
+package dnssync
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/horgh/cloudflare"
+	"github.com/horgh/cloudflare/dnsdiff"
+	"github.com/horgh/cloudflare/internal/cli"
+	"gopkg.in/yaml.v3"
+)
+
+// Args are command line arguments.
+type Args struct {
+	Credentials cli.Credentials
+	File        string
+	Domain      string
+	DryRun      bool
+	NoDelete    bool
+	Output      string
+}
+
+// State is the desired state of a zone.
+type State struct {
+	Zone    string        `json:"zone" yaml:"zone"`
+	Records []StateRecord `json:"records" yaml:"records"`
+}
+
+// StateRecord is a record in the desired state.
+type StateRecord struct {
+	Name    string `json:"name" yaml:"name"`
+	Type    string `json:"type" yaml:"type"`
+	Content string `json:"content" yaml:"content"`
+	TTL     int    `json:"ttl" yaml:"ttl"`
+	Proxied bool   `json:"proxied" yaml:"proxied"`
+
+	// Priority is required for MX and SRV records.
+	Priority *uint16 `json:"priority,omitempty" yaml:"priority,omitempty"`
+}
+
+// Run runs the command. name is how it was invoked and args are its
+// arguments. We return the exit code.
+func Run(name string, arguments []string) int {
+	fs := cli.NewFlagSet(name)
+	if cli.HandleVersion(name, arguments) {
+		return cli.ExitOK
+	}
+
+	args, err := getArgs(fs, arguments)
+	if err != nil {
+		if err == flag.ErrHelp {
+			return cli.ExitOK
+		}
+		return cli.UsageError(fs, err)
+	}
+
+	err = run(args)
+	if err != nil {
+		log.Print(err)
+		return cli.ExitFailure
+	}
+
+	return cli.ExitOK
+}
+
+func run(args Args) error {
+	state, err := loadState(args.File)
+	if err != nil {
+		return err
+	}
+
+	domain := args.Domain
+	if len(domain) == 0 {
+		domain = state.Zone
+	}
+	if len(domain) == 0 {
+		return fmt.Errorf("no zone given in the file or with -domain")
+	}
+
+	client, err := args.Credentials.Client()
+	if err != nil {
+		return err
+	}
+
+	zones, err := client.ListZones(domain, "", -1, -1, "", "", "")
+	if err != nil {
+		return fmt.Errorf("unable to list zones: %w", err)
+	}
+
+	if len(zones) != 1 {
+		return fmt.Errorf("zone not found for domain: %s", domain)
+	}
+	zone := zones[0]
+
+	desired, err := stateToRecords(state, zone)
+	if err != nil {
+		return err
+	}
+
+	live, err := client.ListAllDNSRecords(zone.ID, "", "")
+	if err != nil {
+		return fmt.Errorf("unable to list DNS records: %w", err)
+	}
+
+	plan := dnsdiff.Diff(desired, live, dnsdiff.Options{Prune: !args.NoDelete})
+
+	if args.Output == cli.OutputJSON {
+		err := cli.PrintJSON(plan.Changes)
+		if err != nil {
+			return err
+		}
+	} else {
+		if plan.Empty() {
+			log.Printf("%s is up to date.", zone.Name)
+		}
+		fmt.Print(plan)
+	}
+
+	// The client logs what it would change rather than changing it.
+	if args.DryRun {
+		client = client.DryRun()
+	}
+
+	return plan.Apply(client)
+}
+
+func getArgs(fs *flag.FlagSet, arguments []string) (Args, error) {
+	credentialFlags := cli.AddCredentialFlags(fs)
+	file := fs.String("file", "", "Path to the file describing the records the zone should have (YAML or JSON).")
+	domain := fs.String("domain", "", "Domain of the zone. If not given we use the zone in the file.")
+	dryRun := fs.Bool("dry-run", false, "Print the changes we would make rather than making them.")
+	noDelete := fs.Bool("no-delete", false, "Do not delete live records missing from the file.")
+	output := cli.AddOutputFlag(fs)
+
+	err := fs.Parse(arguments)
+	if err != nil {
+		return Args{}, err
+	}
+
+	creds, err := credentialFlags.Load()
+	if err != nil {
+		return Args{}, err
+	}
+
+	if len(*file) == 0 {
+		return Args{}, fmt.Errorf("you must provide a file")
+	}
+
+	err = cli.CheckOutput(*output)
+	if err != nil {
+		return Args{}, err
+	}
+
+	return Args{
+		Credentials: creds,
+		File:        *file,
+		Domain:      *domain,
+		DryRun:      *dryRun,
+		NoDelete:    *noDelete,
+		Output:      *output,
+	}, nil
+}
+
+func loadState(file string) (State, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return State{}, err
+	}
+
+	var state State
+	if strings.ToLower(filepath.Ext(file)) == ".json" {
+		err = json.Unmarshal(data, &state)
+	} else {
+		err = yaml.Unmarshal(data, &state)
+	}
+	if err != nil {
+		return State{}, fmt.Errorf("unable to parse %s: %w", file, err)
+	}
+
+	return state, nil
+}
+
+// Turn the records from the file into records in the zone with fully
+// qualified names.
+func stateToRecords(state State, zone cloudflare.Zone) ([]cloudflare.DNSRecord,
+	error) {
+	var records []cloudflare.DNSRecord
+
+	for i, r := range state.Records {
+		if len(r.Name) == 0 || len(r.Type) == 0 || len(r.Content) == 0 {
+			return nil, fmt.Errorf("record %d: name, type, and content are required",
+				i+1)
+		}
+
+		records = append(records, cloudflare.DNSRecord{
+			ZoneID:   zone.ID,
+			Name:     dnsdiff.QualifyName(r.Name, zone.Name),
+			Type:     strings.ToUpper(r.Type),
+			Content:  r.Content,
+			TTL:      r.TTL,
+			Proxied:  r.Proxied,
+			Priority: r.Priority,
+		})
+	}
+
+	return records, nil
+}
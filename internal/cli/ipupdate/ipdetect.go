@@ -0,0 +1,201 @@
+package ipupdate
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// defaultIPProviders is the chain of providers we try when the user does not
+// specify any.
+const defaultIPProviders = "icanhazip,ipify,cloudflare"
+
+// ipProvider determines the current public IP.
+type ipProvider struct {
+	name   string
+	lookup func() (net.IP, error)
+}
+
+var ipHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// Parse a comma separated list of providers. Each may be:
+//
+//	icanhazip - https://icanhazip.com
+//	ipify - https://api.ipify.org
+//	cloudflare - https://1.1.1.1/cdn-cgi/trace
+//	interface:NAME - The first global unicast IP on a local interface
+//	A http:// or https:// URL - A service returning the IP as plain text
+func parseIPProviders(spec string) ([]ipProvider, error) {
+	var providers []ipProvider
+
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if len(name) == 0 {
+			continue
+		}
+
+		provider, err := newIPProvider(name)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, provider)
+	}
+
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("no IP providers given")
+	}
+
+	return providers, nil
+}
+
+func newIPProvider(name string) (ipProvider, error) {
+	switch {
+	case name == "icanhazip":
+		return ipProvider{name: name, lookup: func() (net.IP, error) {
+			return lookupPlainTextIP("https://icanhazip.com")
+		}}, nil
+	case name == "ipify":
+		return ipProvider{name: name, lookup: func() (net.IP, error) {
+			return lookupPlainTextIP("https://api.ipify.org")
+		}}, nil
+	case name == "cloudflare":
+		return ipProvider{name: name, lookup: lookupCloudflareTrace}, nil
+	case strings.HasPrefix(name, "interface:"):
+		iface := strings.TrimPrefix(name, "interface:")
+		return ipProvider{name: name, lookup: func() (net.IP, error) {
+			return lookupInterfaceIP(iface)
+		}}, nil
+	case strings.HasPrefix(name, "http://") || strings.HasPrefix(name, "https://"):
+		return ipProvider{name: name, lookup: func() (net.IP, error) {
+			return lookupPlainTextIP(name)
+		}}, nil
+	default:
+		return ipProvider{}, fmt.Errorf("unknown IP provider: %s", name)
+	}
+}
+
+// Try each provider in turn until one succeeds.
+func lookupIP(providers []ipProvider, verbose bool) (net.IP, error) {
+	var failures []string
+
+	for _, provider := range providers {
+		ip, err := provider.lookup()
+		if err != nil {
+			if verbose {
+				log.Printf("IP provider %s failed: %s", provider.name, err)
+			}
+			failures = append(failures, fmt.Sprintf("%s: %s", provider.name, err))
+			continue
+		}
+
+		if verbose {
+			log.Printf("IP provider %s found IP %s", provider.name, ip)
+		}
+		return ip, nil
+	}
+
+	return nil, fmt.Errorf("all IP providers failed: %s",
+		strings.Join(failures, ", "))
+}
+
+// Fetch a URL that responds with nothing but an IP.
+func lookupPlainTextIP(url string) (net.IP, error) {
+	body, err := httpGet(url)
+	if err != nil {
+		return nil, err
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(body))
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP in response: %s", body)
+	}
+
+	return ip, nil
+}
+
+// Cloudflare's trace endpoint responds with key=value lines, one of which is
+// ip=<IP>.
+func lookupCloudflareTrace() (net.IP, error) {
+	body, err := httpGet("https://1.1.1.1/cdn-cgi/trace")
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(text, "ip=") {
+			continue
+		}
+
+		ip := net.ParseIP(strings.TrimPrefix(text, "ip="))
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP in response: %s", text)
+		}
+		return ip, nil
+	}
+
+	return nil, fmt.Errorf("no IP found in response")
+}
+
+// Find the first global unicast IP on a local network interface. IPv4 is
+// preferred.
+func lookupInterfaceIP(name string) (net.IP, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get addresses: %w", err)
+	}
+
+	var found net.IP
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || !ipNet.IP.IsGlobalUnicast() {
+			continue
+		}
+
+		if ipNet.IP.To4() != nil {
+			return ipNet.IP, nil
+		}
+		if found == nil {
+			found = ipNet.IP
+		}
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("no global unicast IP on interface %s", name)
+	}
+
+	return found, nil
+}
+
+func httpGet(url string) (string, error) {
+	resp, err := ipHTTPClient.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("request problem: %w", err)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	err2 := resp.Body.Close()
+	if err != nil {
+		return "", fmt.Errorf("unable to read body: %w", err)
+	}
+	if err2 != nil {
+		return "", fmt.Errorf("problem closing body: %w", err2)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	return string(body), nil
+}
@@ -0,0 +1,409 @@
+// Package ipupdate makes a Cloudflare API request to update an A record IP.
+//
+// If the IP is an IPv6 address we update the AAAA record instead.
+//
+// With -interval it keeps running, checking periodically.
+package ipupdate
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/horgh/cloudflare"
+	"github.com/horgh/cloudflare/internal/cli"
+	"github.com/horgh/cloudflare/internal/cli/recordset"
+)
+
+// Args are command line arguments.
+type Args struct {
+	Credentials     cli.Credentials
+	Domain          string
+	Hostname        string
+	IP              net.IP
+	IPProviders     []ipProvider
+	OnlyIfDifferent bool
+	CheckViaAPI     bool
+	CreateMissing   bool
+	OnChangeExec    string
+	OnChangeWebhook string
+	Verbose         bool
+	Output          string
+
+	// CheckAuthoritative means to check the current IP by asking the zone's
+	// authoritative nameservers rather than a recursive resolver.
+	CheckAuthoritative bool
+
+	// Resolver is the DNS server (or DNS-over-HTTPS URL) to check the
+	// current IP with. Blank means to use the system's.
+	Resolver string
+
+	// Interval is how often to check when running continuously. 0 means to
+	// check once.
+	Interval time.Duration
+
+	// TTL is the TTL to set. 0 means to leave an existing record's TTL alone
+	// and to use automatic for a new record.
+	TTL int
+
+	// Proxied is whether the record should be proxied. nil means to leave an
+	// existing record's setting alone and to not proxy a new record.
+	Proxied *bool
+}
+
+// Run runs the command. name is how it was invoked and args are its
+// arguments. We return the exit code.
+func Run(name string, arguments []string) int {
+	fs := cli.NewFlagSet(name)
+	if cli.HandleVersion(name, arguments) {
+		return cli.ExitOK
+	}
+
+	args, err := getArgs(fs, arguments)
+	if err != nil {
+		if err == flag.ErrHelp {
+			return cli.ExitOK
+		}
+		return cli.UsageError(fs, err)
+	}
+
+	if args.Interval > 0 {
+		return runContinuously(args)
+	}
+
+	client, err := args.Credentials.Client()
+	if err != nil {
+		log.Print(err)
+		return cli.ExitFailure
+	}
+
+	result, err := run(client, args)
+	if err != nil {
+		log.Print(err)
+		return cli.FailureExitCode(err)
+	}
+
+	if args.Output == cli.OutputJSON {
+		err := cli.PrintJSON(result)
+		if err != nil {
+			log.Print(err)
+			return cli.ExitFailure
+		}
+	}
+
+	return cli.ExitOK
+}
+
+// How long we remember the zone and record between checks when running
+// continuously. The record only changes when we change it unless someone
+// changes it elsewhere, so this can be long.
+const cacheTTL = time.Hour
+
+// How old the records we last fetched may be for us to still decide with
+// them if the API is unavailable. They are fetched about every cacheTTL.
+const staleMaxAge = 6 * time.Hour
+
+// Check every interval until killed. Failures are logged rather than ending
+// the program as they may be temporary.
+func runContinuously(args Args) int {
+	client, err := args.Credentials.Client(cloudflare.WithCache(cacheTTL),
+		cloudflare.WithStaleOnError(staleMaxAge))
+	if err != nil {
+		log.Print(err)
+		return cli.ExitFailure
+	}
+
+	for {
+		result, err := run(client, args)
+		if err != nil {
+			log.Print(err)
+		} else if args.Output == cli.OutputJSON {
+			err := cli.PrintJSON(result)
+			if err != nil {
+				log.Print(err)
+			}
+		}
+
+		time.Sleep(args.Interval)
+	}
+}
+
+// result describes what we did.
+type result struct {
+	Hostname   string `json:"hostname"`
+	RecordType string `json:"record_type"`
+	IP         string `json:"ip"`
+	// OldIP is set if we changed the IP.
+	OldIP string `json:"old_ip,omitempty"`
+	// Action is created, updated, or unchanged.
+	Action string `json:"action"`
+	// Stale is set if we decided using records fetched earlier as the API
+	// was unavailable.
+	Stale bool `json:"stale,omitempty"`
+}
+
+func run(client cloudflare.Client, args Args) (result, error) {
+	// Decide which IP to set. Use the CLI arg value if given.
+	ip := args.IP
+	if ip == nil {
+		myIP, err := lookupIP(args.IPProviders, args.Verbose)
+		if err != nil {
+			return result{}, fmt.Errorf("unable to look up IP: %w", err)
+		}
+		if args.Verbose {
+			log.Printf("Found current IP is %s", myIP)
+		}
+		ip = myIP
+	}
+
+	// We may want to make an update.
+
+	// If we want to make it without checking if there is a difference, then do so
+	if !args.OnlyIfDifferent {
+		return updateIP(client, args, ip)
+	}
+
+	// We only want to make an update if there is a difference.
+
+	// We can compare against what the API says the record holds. updateIP()
+	// does that before updating.
+	if args.CheckViaAPI {
+		return updateIP(client, args, ip)
+	}
+
+	// Otherwise to know the current IP, look up its A record.
+	var ips []net.IP
+	var err error
+	if args.CheckAuthoritative {
+		ips, err = dnsLookupHostAuthoritative(args.Resolver, args.Domain,
+			args.Hostname, recordTypeForIP(ip))
+	} else {
+		ips, err = dnsLookupHost(args.Resolver, args.Hostname,
+			recordTypeForIP(ip))
+	}
+	if err != nil {
+		return result{}, err
+	}
+
+	if len(ips) == 0 {
+		if !args.CreateMissing {
+			return result{}, fmt.Errorf("unable to determine current record IP via DNS. No IPs found")
+		}
+
+		// The record may not exist yet. We'll create it if so.
+		return updateIP(client, args, ip)
+	}
+
+	if len(ips) > 1 {
+		return result{}, fmt.Errorf("there are %d %s records. Unable to update", len(ips),
+			recordTypeForIP(ip))
+	}
+
+	currentIP := ips[0]
+	if args.Verbose {
+		log.Printf("Host's current IP is %s", currentIP)
+	}
+
+	if currentIP.Equal(ip) {
+		if args.Verbose {
+			log.Printf("DNS record's IP matches IP provided/found (%s). Not making an update.",
+				ip)
+		}
+		return result{
+			Hostname:   args.Hostname,
+			RecordType: recordTypeForIP(ip),
+			IP:         ip.String(),
+			Action:     "unchanged",
+		}, nil
+	}
+
+	return updateIP(client, args, ip)
+}
+
+func getArgs(fs *flag.FlagSet, arguments []string) (Args, error) {
+	credentialFlags := cli.AddCredentialFlags(fs)
+	domain := fs.String("domain", "", "Domain involved in the update.")
+	hostname := fs.String("hostname", "", "Hostname to update. It may be relative to the domain, e.g. home for home.example.com.")
+	ipString := fs.String("ip", "", "IP to set. If you don't provide this, then we look up your current IP using the IP providers.")
+	ipProviders := fs.String("ip-providers", defaultIPProviders, "Comma separated list of ways to look up your current IP. We try each in turn until one works. Each may be icanhazip, ipify, cloudflare, interface:NAME (an IP on a local interface), or a URL responding with the IP as plain text.")
+	createMissing := fs.Bool("create-missing", false, "If no matching record exists, create one rather than failing.")
+	ttl := fs.Int("ttl", 0, "TTL to set on the record. 1 means automatic. If you don't provide this, we leave the TTL as it is (or use automatic for a new record).")
+	proxied := fs.Bool("proxied", false, "Whether the record is proxied through Cloudflare. If you don't provide this, we leave the setting as it is (or don't proxy a new record).")
+	onlyIfDifferent := fs.Bool("only-if-different", false, "If true, we check the current IP of the host via DNS, and only contact the Cloudflare API if it does not match the IP you provided (or we found as current).")
+	checkViaAPI := fs.Bool("check-via-api", false, "With -only-if-different, compare against the record content the Cloudflare API reports rather than looking up the host via DNS. This avoids updates caused by DNS propagation delay. Implies -only-if-different.")
+	onChangeExec := fs.String("on-change-exec", "", "Command to run (via the shell) when we change the record. It receives the change as JSON on stdin and in CFIPUPDATE_* environment variables.")
+	onChangeWebhook := fs.String("on-change-webhook", "", "URL to POST the change to as JSON (hostname, old_ip, new_ip, timestamp) when we change the record.")
+	interval := fs.Duration("interval", 0, "If set, keep running and check every interval (e.g. 5m) rather than checking once. The zone and record are remembered between checks.")
+	checkAuthoritative := fs.Bool("check-authoritative", false, "With -only-if-different, look up the host by asking the zone's authoritative nameservers directly rather than a recursive resolver, which may have the old IP cached. Implies -only-if-different.")
+	resolver := fs.String("resolver", "", "DNS server to check the host's current IP with for -only-if-different, as host or host:port, or a DNS-over-HTTPS URL. By default we use the system's resolver (from resolv.conf, or the network adapters' nameservers on Windows), falling back to DNS-over-HTTPS to 1.1.1.1 if we can't find or reach it.")
+	output := cli.AddOutputFlag(fs)
+
+	err := fs.Parse(arguments)
+	if err != nil {
+		return Args{}, err
+	}
+
+	creds, err := credentialFlags.Load()
+	if err != nil {
+		return Args{}, err
+	}
+
+	if len(*domain) == 0 {
+		*domain = creds.Domain
+	}
+
+	if len(*domain) == 0 {
+		return Args{}, fmt.Errorf("you must provide a domain")
+	}
+
+	if len(*hostname) == 0 {
+		return Args{}, fmt.Errorf("you must provide a hostname")
+	}
+
+	err = cli.CheckOutput(*output)
+	if err != nil {
+		return Args{}, err
+	}
+
+	if *checkViaAPI && *checkAuthoritative {
+		return Args{}, fmt.Errorf("give only one of -check-via-api and -check-authoritative")
+	}
+
+	if strings.HasPrefix(*resolver, "http://") {
+		return Args{}, fmt.Errorf("DNS-over-HTTPS resolver must be an https:// URL")
+	}
+
+	if *interval < 0 {
+		return Args{}, fmt.Errorf("interval may not be negative")
+	}
+
+	if *ttl != 0 && *ttl != cloudflare.TTLAutomatic &&
+		(*ttl < cloudflare.MinTTL || *ttl > cloudflare.MaxTTL) {
+		return Args{}, fmt.Errorf("TTL must be %d (automatic) or %d to %d",
+			cloudflare.TTLAutomatic, cloudflare.MinTTL, cloudflare.MaxTTL)
+	}
+
+	// Only change whether the record is proxied if asked to.
+	var proxiedSetting *bool
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "proxied" {
+			proxiedSetting = proxied
+		}
+	})
+
+	var ip net.IP
+	if len(*ipString) > 0 {
+		ip = net.ParseIP(*ipString)
+		if ip == nil {
+			return Args{}, fmt.Errorf("invalid IP address")
+		}
+	}
+
+	providers, err := parseIPProviders(*ipProviders)
+	if err != nil {
+		return Args{}, err
+	}
+
+	return Args{
+		Credentials:        creds,
+		Domain:             *domain,
+		Hostname:           cloudflare.QualifyName(*hostname, *domain),
+		IP:                 ip,
+		IPProviders:        providers,
+		OnlyIfDifferent:    *onlyIfDifferent || *checkViaAPI || *checkAuthoritative,
+		CheckViaAPI:        *checkViaAPI,
+		CreateMissing:      *createMissing,
+		OnChangeExec:       *onChangeExec,
+		OnChangeWebhook:    *onChangeWebhook,
+		TTL:                *ttl,
+		Proxied:            proxiedSetting,
+		Interval:           *interval,
+		Resolver:           *resolver,
+		CheckAuthoritative: *checkAuthoritative,
+		Verbose:            creds.Verbose,
+		Output:             *output,
+	}, nil
+}
+
+// The type of record holding the IP: A for IPv4, AAAA for IPv6.
+func recordTypeForIP(ip net.IP) string {
+	if ip.To4() != nil {
+		return cloudflare.RecordTypeA
+	}
+	return cloudflare.RecordTypeAAAA
+}
+
+func updateIP(client cloudflare.Client, args Args, ip net.IP) (result,
+	error) {
+	zoneID, err := client.ZoneIDByName(args.Domain)
+	if err != nil {
+		return result{}, fmt.Errorf("unable to find zone: %w", err)
+	}
+
+	// This program is specifically for updating A (or AAAA) records.
+	recordType := recordTypeForIP(ip)
+
+	outcome, err := recordset.Set(client, zoneID, recordset.Want{
+		Name:          args.Hostname,
+		Type:          recordType,
+		Content:       ip.String(),
+		TTL:           args.TTL,
+		Proxied:       args.Proxied,
+		CreateMissing: args.CreateMissing,
+	}, args.Verbose)
+
+	// When running continuously we may have decided using earlier records.
+	staleSince, stale := client.StaleSince()
+	if stale {
+		log.Printf("API unavailable. Using records fetched at %s",
+			staleSince.Format(time.RFC3339))
+	}
+
+	if err != nil {
+		return result{}, err
+	}
+
+	res := result{
+		Hostname:   args.Hostname,
+		RecordType: recordType,
+		IP:         ip.String(),
+		Action:     outcome.Action,
+		Stale:      stale,
+	}
+
+	switch outcome.Action {
+	case recordset.ActionUnchanged:
+		if args.Verbose || !args.OnlyIfDifferent {
+			log.Printf("Record already has IP [%s]. No update performed.",
+				ip.String())
+		}
+		return res, nil
+	case recordset.ActionCreated:
+		log.Printf("Created %s record [%s] with IP [%s]", recordType,
+			args.Hostname, ip.String())
+	default:
+		log.Printf("Updated %s record of [%s] to IP [%s]", recordType,
+			args.Hostname, ip.String())
+		// We may have only changed the TTL or whether it is proxied.
+		if outcome.OldContent == ip.String() {
+			return res, nil
+		}
+		res.OldIP = outcome.OldContent
+	}
+
+	err = notifyChange(args, ipChange{
+		Hostname:   args.Hostname,
+		RecordType: recordType,
+		OldIP:      outcome.OldContent,
+		NewIP:      ip.String(),
+	})
+	if err != nil {
+		return res, fmt.Errorf("record %s but notification failed: %w",
+			outcome.Action, err)
+	}
+
+	return res, nil
+}
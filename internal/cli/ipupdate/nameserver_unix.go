@@ -0,0 +1,47 @@
+//go:build !windows
+
+package ipupdate
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/miekg/dns"
+)
+
+// Files listing nameservers. systemd-resolved points /etc/resolv.conf at its
+// stub listener, which answers for the local hostname itself (with a local
+// IP). The file it writes for itself lists the real nameservers, so we prefer
+// it.
+var resolvConfPaths = []string{
+	"/run/systemd/resolve/resolv.conf",
+	"/etc/resolv.conf",
+}
+
+// Find the first nameserver the system uses.
+func getNameserver() (string, error) {
+	for _, path := range resolvConfPaths {
+		config, err := dns.ClientConfigFromFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return "", fmt.Errorf("unable to read %s: %w", path, err)
+		}
+
+		for _, server := range config.Servers {
+			if isStubResolver(server) {
+				continue
+			}
+			return net.JoinHostPort(server, config.Port), nil
+		}
+	}
+
+	return "", fmt.Errorf("no resolver found")
+}
+
+// Whether the nameserver is systemd-resolved's stub listener.
+func isStubResolver(server string) bool {
+	return server == "127.0.0.53" || server == "127.0.0.54"
+}
@@ -0,0 +1,82 @@
+package ipupdate
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Find the first nameserver the system uses. There is no resolv.conf on
+// Windows, so we ask for the network adapters' nameservers the way the
+// standard library does.
+func getNameserver() (string, error) {
+	adapters, err := adapterAddresses()
+	if err != nil {
+		return "", err
+	}
+
+	for _, adapter := range adapters {
+		if adapter.OperStatus != windows.IfOperStatusUp {
+			continue
+		}
+
+		for s := adapter.FirstDnsServerAddress; s != nil; s = s.Next {
+			ip := s.Address.IP()
+			if ip == nil || isDeprecatedSiteLocal(ip) {
+				continue
+			}
+			return net.JoinHostPort(ip.String(), "53"), nil
+		}
+	}
+
+	return "", fmt.Errorf("no resolver found")
+}
+
+// Retrieve the network adapters. We grow the buffer until they fit.
+func adapterAddresses() ([]*windows.IpAdapterAddresses, error) {
+	size := uint32(15000)
+	var buf []byte
+	for {
+		buf = make([]byte, size)
+		err := windows.GetAdaptersAddresses(windows.AF_UNSPEC,
+			windows.GAA_FLAG_INCLUDE_PREFIX, 0,
+			(*windows.IpAdapterAddresses)(unsafe.Pointer(&buf[0])), &size)
+		if err == nil {
+			if size == 0 {
+				return nil, nil
+			}
+			break
+		}
+		if err != windows.ERROR_BUFFER_OVERFLOW || size <= uint32(len(buf)) {
+			return nil, os.NewSyscallError("getadaptersaddresses", err)
+		}
+	}
+
+	var adapters []*windows.IpAdapterAddresses
+	for adapter := (*windows.IpAdapterAddresses)(
+		unsafe.Pointer(&buf[0])); adapter != nil; adapter = adapter.Next {
+		adapters = append(adapters, adapter)
+	}
+
+	return adapters, nil
+}
+
+// Windows lists these deprecated site-local addresses as nameservers on
+// adapters without IPv6 nameservers. Nothing answers there.
+var deprecatedSiteLocal = []net.IP{
+	net.ParseIP("fec0:0:0:ffff::1"),
+	net.ParseIP("fec0:0:0:ffff::2"),
+	net.ParseIP("fec0:0:0:ffff::3"),
+}
+
+func isDeprecatedSiteLocal(ip net.IP) bool {
+	for _, deprecated := range deprecatedSiteLocal {
+		if ip.Equal(deprecated) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package ipupdate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+// ipChange describes an update we made to a record.
+type ipChange struct {
+	Hostname   string `json:"hostname"`
+	RecordType string `json:"record_type"`
+	// OldIP is blank if we created the record.
+	OldIP     string `json:"old_ip"`
+	NewIP     string `json:"new_ip"`
+	Timestamp string `json:"timestamp"`
+}
+
+var webhookHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// Tell whoever asked about a change: run the command and/or POST to the
+// webhook.
+func notifyChange(args Args, change ipChange) error {
+	change.Timestamp = time.Now().UTC().Format(time.RFC3339)
+
+	payload, err := json.Marshal(change)
+	if err != nil {
+		return fmt.Errorf("unable to encode to JSON: %w", err)
+	}
+
+	if len(args.OnChangeExec) > 0 {
+		err := runChangeCommand(args.OnChangeExec, change, payload)
+		if err != nil {
+			return fmt.Errorf("on change command failed: %w", err)
+		}
+	}
+
+	if len(args.OnChangeWebhook) > 0 {
+		err := postWebhook(args.OnChangeWebhook, payload)
+		if err != nil {
+			return fmt.Errorf("on change webhook failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Run the command through the shell. It receives the change as JSON on stdin
+// and in environment variables.
+func runChangeCommand(command string, change ipChange, payload []byte) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("/bin/sh", "-c", command)
+	}
+
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"CFIPUPDATE_HOSTNAME="+change.Hostname,
+		"CFIPUPDATE_RECORD_TYPE="+change.RecordType,
+		"CFIPUPDATE_OLD_IP="+change.OldIP,
+		"CFIPUPDATE_NEW_IP="+change.NewIP,
+		"CFIPUPDATE_TIMESTAMP="+change.Timestamp,
+	)
+
+	return cmd.Run()
+}
+
+func postWebhook(url string, payload []byte) error {
+	resp, err := webhookHTTPClient.Post(url, "application/json",
+		bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("request problem: %w", err)
+	}
+
+	_, err = io.Copy(io.Discard, resp.Body)
+	err2 := resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("unable to read body: %w", err)
+	}
+	if err2 != nil {
+		return fmt.Errorf("problem closing body: %w", err2)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	return nil
+}
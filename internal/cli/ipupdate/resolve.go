@@ -0,0 +1,210 @@
+package ipupdate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/horgh/cloudflare"
+	"github.com/miekg/dns"
+)
+
+// We fall back to this DNS-over-HTTPS resolver if we can't find or use the
+// system's. Using the IP means we don't need DNS to reach it.
+const defaultDoHURL = "https://1.1.1.1/dns-query"
+
+var dohHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// I'm using github.com/miekg/dns as using the standard library net package
+// always uses the local resolver. Doing so presents a problem when the host
+// we want to look up is the local server's hostname as that means we will get
+// back 127.0.1.1, at least in Debian/Ubuntu.
+//
+// resolver is a nameserver (host or host:port) or a DNS-over-HTTPS URL. If it
+// is blank we use the system's nameserver, falling back to DNS-over-HTTPS if
+// we can't find it or it fails.
+//
+// recordType is A or AAAA.
+func dnsLookupHost(resolver, host, recordType string) ([]net.IP, error) {
+	qtype := dns.TypeA
+	if recordType == cloudflare.RecordTypeAAAA {
+		qtype = dns.TypeAAAA
+	}
+
+	in, err := query(resolver, host, qtype)
+	if err != nil {
+		return nil, err
+	}
+
+	ips := []net.IP{}
+	for _, record := range in.Answer {
+		switch rr := record.(type) {
+		case *dns.A:
+			ips = append(ips, rr.A)
+		case *dns.AAAA:
+			ips = append(ips, rr.AAAA)
+		}
+	}
+
+	return ips, nil
+}
+
+// Look up the host by asking one of the zone's authoritative nameservers
+// directly. Its answer reflects changes immediately, unlike a recursive
+// resolver that may have the old answer cached.
+//
+// We find the nameservers using resolver (see dnsLookupHost).
+func dnsLookupHostAuthoritative(resolver, domain, host,
+	recordType string) ([]net.IP, error) {
+	in, err := query(resolver, domain, dns.TypeNS)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find nameservers: %w", err)
+	}
+
+	var lastErr error
+	for _, record := range in.Answer {
+		ns, ok := record.(*dns.NS)
+		if !ok {
+			continue
+		}
+
+		addresses, err := dnsLookupHost(resolver, ns.Ns, cloudflare.RecordTypeA)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if len(addresses) == 0 {
+			lastErr = fmt.Errorf("no address found for nameserver %s", ns.Ns)
+			continue
+		}
+
+		ips, err := dnsLookupHost(addresses[0].String(), host, recordType)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return ips, nil
+	}
+
+	if lastErr != nil {
+		return nil, lastErr
+	}
+
+	return nil, fmt.Errorf("no nameservers found for %s", domain)
+}
+
+// Send a query for the name and type to the resolver (see dnsLookupHost).
+func query(resolver, name string, qtype uint16) (*dns.Msg, error) {
+	msg := new(dns.Msg)
+	msg.Id = dns.Id()
+	msg.RecursionDesired = true
+	msg.Question = make([]dns.Question, 1)
+	msg.Question[0] = dns.Question{
+		Name:   dns.Fqdn(name),
+		Qtype:  qtype,
+		Qclass: dns.ClassINET,
+	}
+
+	var in *dns.Msg
+	var err error
+	if len(resolver) > 0 {
+		in, err = exchange(msg, resolver)
+	} else {
+		in, err = exchangeSystem(msg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to perform lookup: %w", err)
+	}
+
+	// A name that does not exist has no records rather than being an error.
+	// That lets -create-missing create it. Failures such as SERVFAIL and
+	// REFUSED are still errors.
+	if in.Rcode != dns.RcodeSuccess && in.Rcode != dns.RcodeNameError {
+		return nil, fmt.Errorf("lookup problem: %s", dns.RcodeToString[in.Rcode])
+	}
+
+	return in, nil
+}
+
+// Send the query to the system's nameserver, or via DNS-over-HTTPS if that
+// doesn't work.
+func exchangeSystem(msg *dns.Msg) (*dns.Msg, error) {
+	nameserver, err := getNameserver()
+	if err == nil {
+		var in *dns.Msg
+		in, err = exchange(msg, nameserver)
+		if err == nil {
+			return in, nil
+		}
+	}
+
+	in, dohErr := exchangeDoH(msg, defaultDoHURL)
+	if dohErr != nil {
+		return nil, fmt.Errorf("system resolver: %s, DNS-over-HTTPS: %s", err,
+			dohErr)
+	}
+
+	return in, nil
+}
+
+// Send the query to a nameserver (host or host:port) or a DNS-over-HTTPS URL.
+func exchange(msg *dns.Msg, resolver string) (*dns.Msg, error) {
+	if strings.HasPrefix(resolver, "https://") {
+		return exchangeDoH(msg, resolver)
+	}
+
+	address := resolver
+	if _, _, err := net.SplitHostPort(resolver); err != nil {
+		address = net.JoinHostPort(resolver, "53")
+	}
+
+	return dns.Exchange(msg, address)
+}
+
+// Send the query using DNS-over-HTTPS (RFC 8484).
+func exchangeDoH(msg *dns.Msg, url string) (*dns.Msg, error) {
+	packed, err := msg.Pack()
+	if err != nil {
+		return nil, fmt.Errorf("unable to pack query: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(packed))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/dns-message")
+	req.Header.Set("Accept", "application/dns-message")
+
+	resp, err := dohHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request problem: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 65535))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	in := new(dns.Msg)
+	err = in.Unpack(body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unpack response: %w", err)
+	}
+
+	if in.Id != msg.Id {
+		return nil, fmt.Errorf("response ID does not match query")
+	}
+
+	return in, nil
+}
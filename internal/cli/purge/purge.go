@@ -0,0 +1,377 @@
+// Package purge provides a way to purge files associated with a Cloudflare
+// domain.
+//
+// By default it purges everything. It can instead purge specific URLs,
+// prefixes, or cache tags.
+//
+// It can purge several domains at once, or every zone on the account.
+//
+// It can also turn development mode on or off first, as it is common to do
+// both when working on a site.
+package purge
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/horgh/cloudflare"
+	"github.com/horgh/cloudflare/internal/cli"
+)
+
+// Args are command line arguments.
+type Args struct {
+	Credentials cli.Credentials
+	Domains     []string
+	AllZones    bool
+	Concurrency int
+	URLs        []string
+	Prefixes    []string
+	Tags        []string
+	DevMode     string
+	DryRun      bool
+	Verbose     bool
+	Output      string
+}
+
+// zoneResult is the outcome of purging a zone.
+type zoneResult struct {
+	Zone     string   `json:"zone"`
+	ZoneID   string   `json:"zone_id"`
+	Success  bool     `json:"success"`
+	Error    string   `json:"error,omitempty"`
+	DryRun   bool     `json:"dry_run"`
+	All      bool     `json:"all,omitempty"`
+	URLs     []string `json:"urls,omitempty"`
+	Prefixes []string `json:"prefixes,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	DevMode  string   `json:"dev_mode,omitempty"`
+}
+
+// Run runs the command. name is how it was invoked and args are its
+// arguments. We return the exit code.
+func Run(name string, arguments []string) int {
+	fs := cli.NewFlagSet(name)
+	if cli.HandleVersion(name, arguments) {
+		return cli.ExitOK
+	}
+
+	args, err := getArgs(fs, arguments)
+	if err != nil {
+		if err == flag.ErrHelp {
+			return cli.ExitOK
+		}
+		return cli.UsageError(fs, err)
+	}
+
+	err = run(args)
+	if err != nil {
+		log.Print(err)
+		return cli.ExitFailure
+	}
+
+	return cli.ExitOK
+}
+
+func run(args Args) error {
+	client, err := args.Credentials.Client()
+	if err != nil {
+		return err
+	}
+
+	// The client logs what it would change rather than changing it.
+	if args.DryRun {
+		client = client.DryRun()
+	}
+
+	zones, err := findZones(client, args)
+	if err != nil {
+		return err
+	}
+
+	results := purgeZones(client, zones, args)
+
+	if args.Output == cli.OutputJSON {
+		err := cli.PrintJSON(results)
+		if err != nil {
+			return err
+		}
+	}
+
+	failures := 0
+	for _, result := range results {
+		if !result.Success {
+			failures++
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("purge failed for %d of %d zones", failures, len(zones))
+	}
+
+	if args.Verbose && !args.DryRun {
+		log.Printf("Purge complete.")
+	}
+
+	return nil
+}
+
+// Look up the zones we are to purge.
+func findZones(client cloudflare.Client, args Args) ([]cloudflare.Zone,
+	error) {
+	if args.AllZones {
+		zones, err := client.ListAllZones("", "")
+		if err != nil {
+			return nil, fmt.Errorf("unable to list zones: %w", err)
+		}
+		return zones, nil
+	}
+
+	var zones []cloudflare.Zone
+	for _, domain := range args.Domains {
+		domainZones, err := client.ListZones(domain, "", -1, -1, "", "", "")
+		if err != nil {
+			return nil, fmt.Errorf("unable to list zones: %w", err)
+		}
+
+		if len(domainZones) != 1 {
+			return nil, fmt.Errorf("zone not found for domain: %s", domain)
+		}
+
+		zones = append(zones, domainZones[0])
+	}
+
+	return zones, nil
+}
+
+// Purge each zone, at most args.Concurrency at a time. Report how each went
+// and return the results in the same order as the zones.
+func purgeZones(client cloudflare.Client, zones []cloudflare.Zone,
+	args Args) []zoneResult {
+	sem := make(chan struct{}, args.Concurrency)
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	results := make([]zoneResult, len(zones))
+	all := len(args.URLs) == 0 && len(args.Prefixes) == 0 &&
+		len(args.Tags) == 0
+
+	for i, zone := range zones {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, zone cloudflare.Zone) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := purge(client, zone, args)
+
+			result := zoneResult{
+				Zone:     zone.Name,
+				ZoneID:   zone.ID,
+				Success:  err == nil,
+				DryRun:   args.DryRun,
+				All:      all,
+				URLs:     args.URLs,
+				Prefixes: args.Prefixes,
+				Tags:     args.Tags,
+				DevMode:  args.DevMode,
+			}
+			results[i] = result
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if err != nil {
+				results[i].Error = err.Error()
+				log.Printf("%s: purge failed: %s", zone.Name, err)
+				return
+			}
+
+			if args.Verbose || len(zones) > 1 {
+				log.Printf("%s: ok", zone.Name)
+			}
+		}(i, zone)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+func getArgs(fs *flag.FlagSet, arguments []string) (Args, error) {
+	credentialFlags := cli.AddCredentialFlags(fs)
+
+	var domains cli.StringList
+	fs.Var(&domains, "domain", "Domain to purge. You may give this multiple times.")
+	allZones := fs.Bool("all-zones", false, "Purge every zone on the account rather than specific domains.")
+	concurrency := fs.Int("concurrency", 4, "How many zones to purge at once.")
+
+	var urls, prefixes, tags cli.StringList
+	fs.Var(&urls, "url", "URL to purge. You may give this multiple times. If you don't give any URLs, prefixes, or tags, we purge everything.")
+	urlFile := fs.String("file", "", "Path to a file containing URLs to purge, one per line.")
+	fs.Var(&prefixes, "prefix", "URL prefix to purge, e.g. www.example.com/images. You may give this multiple times.")
+	fs.Var(&tags, "tag", "Cache tag to purge. You may give this multiple times.")
+	devMode := fs.String("dev-mode", "", "Turn development mode on or off before purging. Give on or off.")
+	dryRun := fs.Bool("dry-run", false, "Print what we would purge rather than purging.")
+	output := cli.AddOutputFlag(fs)
+
+	err := fs.Parse(arguments)
+	if err != nil {
+		return Args{}, err
+	}
+
+	creds, err := credentialFlags.Load()
+	if err != nil {
+		return Args{}, err
+	}
+
+	if len(domains) == 0 && !*allZones && len(creds.Domain) > 0 {
+		domains = append(domains, creds.Domain)
+	}
+
+	if len(domains) == 0 && !*allZones {
+		return Args{}, fmt.Errorf("you must provide a domain")
+	}
+
+	if len(domains) > 0 && *allZones {
+		return Args{}, fmt.Errorf("you may not provide domains with -all-zones")
+	}
+
+	if *devMode != "" && *devMode != "on" && *devMode != "off" {
+		return Args{}, fmt.Errorf("-dev-mode must be on or off")
+	}
+
+	if *concurrency <= 0 {
+		return Args{}, fmt.Errorf("concurrency must be at least 1")
+	}
+
+	err = cli.CheckOutput(*output)
+	if err != nil {
+		return Args{}, err
+	}
+
+	if len(*urlFile) > 0 {
+		fileURLs, err := readURLs(*urlFile)
+		if err != nil {
+			return Args{}, err
+		}
+		urls = append(urls, fileURLs...)
+	}
+
+	return Args{
+		Credentials: creds,
+		Domains:     domains,
+		AllZones:    *allZones,
+		Concurrency: *concurrency,
+		URLs:        urls,
+		Prefixes:    prefixes,
+		Tags:        tags,
+		DevMode:     *devMode,
+		DryRun:      *dryRun,
+		Verbose:     creds.Verbose,
+		Output:      *output,
+	}, nil
+}
+
+// Read URLs from a file, one per line. Blank lines and lines starting with #
+// are skipped.
+func readURLs(file string) ([]string, error) {
+	fh, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := fh.Close()
+		if err != nil {
+			log.Printf("close: %s: %s", file, err)
+		}
+	}()
+
+	var urls []string
+	scanner := bufio.NewScanner(fh)
+
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if len(text) == 0 || text[0] == '#' {
+			continue
+		}
+		urls = append(urls, text)
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("scan error: %w", err)
+	}
+
+	return urls, nil
+}
+
+// Purge what the arguments ask for from the zone. If there is nothing
+// specific to purge, purge everything.
+func purge(client cloudflare.Client, zone cloudflare.Zone, args Args) error {
+	zoneID := zone.ID
+
+	if len(args.DevMode) > 0 {
+		err := setDevMode(client, zone, args)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(args.URLs) == 0 && len(args.Prefixes) == 0 && len(args.Tags) == 0 {
+		return client.PurgeAllFiles(zoneID)
+	}
+
+	if len(args.URLs) > 0 {
+		err := client.PurgeFiles(zoneID, args.URLs)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(args.Prefixes) > 0 {
+		err := client.PurgePrefixes(zoneID, args.Prefixes)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(args.Tags) > 0 {
+		err := client.PurgeTags(zoneID, args.Tags)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Turn development mode on or off as the arguments ask.
+func setDevMode(client cloudflare.Client, zone cloudflare.Zone,
+	args Args) error {
+	if args.DevMode == "off" {
+		err := client.DisableDevelopmentMode(zone.ID)
+		if err != nil {
+			return err
+		}
+		if args.Verbose {
+			log.Printf("%s: development mode off", zone.Name)
+		}
+		return nil
+	}
+
+	mode, err := client.EnableDevelopmentMode(zone.ID)
+	if err != nil {
+		return err
+	}
+
+	if args.Verbose {
+		log.Printf("%s: development mode on for %s", zone.Name, mode.Remaining)
+	}
+
+	return nil
+}
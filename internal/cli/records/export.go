@@ -0,0 +1,255 @@
+package records
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/horgh/cloudflare"
+	"gopkg.in/yaml.v3"
+)
+
+// Formats we can export records in.
+const (
+	// ExportOctoDNS is octoDNS's YAML zone config.
+	ExportOctoDNS = "octodns"
+
+	// ExportExternalDNS is an external-dns DNSEndpoint resource as JSON.
+	ExportExternalDNS = "external-dns"
+)
+
+// octoDNS has no automatic TTL. Its Cloudflare provider treats automatic as
+// 300 seconds, so we do too.
+const autoTTLSeconds = 300
+
+// Check the value of the -export flag.
+func checkExport(format string) error {
+	if format != "" && format != ExportOctoDNS && format != ExportExternalDNS {
+		return fmt.Errorf("export format must be %s or %s", ExportOctoDNS,
+			ExportExternalDNS)
+	}
+	return nil
+}
+
+// Write the records to stdout in the format.
+func export(format, zone string, records []cloudflare.DNSRecord) error {
+	switch format {
+	case ExportOctoDNS:
+		return exportOctoDNS(zone, records)
+	default:
+		return exportExternalDNS(zone, records)
+	}
+}
+
+// Write the records as an octoDNS zone config. This maps each name relative
+// to the zone (” for the zone itself) to its records, one per type.
+func exportOctoDNS(zone string, records []cloudflare.DNSRecord) error {
+	type octoRecord struct {
+		Type    string                 `yaml:"type"`
+		TTL     int                    `yaml:"ttl"`
+		Value   interface{}            `yaml:"value,omitempty"`
+		Values  []interface{}          `yaml:"values,omitempty"`
+		OctoDNS map[string]interface{} `yaml:"octodns,omitempty"`
+	}
+
+	config := map[string][]octoRecord{}
+	for _, group := range groupRecords(records) {
+		first := group[0]
+
+		ttl := first.TTL
+		if ttl == cloudflare.TTLAutomatic {
+			ttl = autoTTLSeconds
+		}
+
+		var values []interface{}
+		for _, record := range group {
+			values = append(values, octoDNSValue(record))
+		}
+
+		record := octoRecord{Type: first.Type, TTL: ttl}
+		if len(values) == 1 {
+			record.Value = values[0]
+		} else {
+			record.Values = values
+		}
+
+		if first.Proxied {
+			record.OctoDNS = map[string]interface{}{
+				"cloudflare": map[string]interface{}{"proxied": true},
+			}
+		}
+
+		name := relativeName(first.Name, zone)
+		config[name] = append(config[name], record)
+	}
+
+	encoder := yaml.NewEncoder(os.Stdout)
+	encoder.SetIndent(2)
+
+	err := encoder.Encode(config)
+	if err != nil {
+		return fmt.Errorf("unable to encode to YAML: %w", err)
+	}
+
+	return encoder.Close()
+}
+
+// A record's value the way octoDNS has it. Hostnames are fully qualified
+// with a trailing dot.
+func octoDNSValue(record cloudflare.DNSRecord) interface{} {
+	switch record.Type {
+	case cloudflare.RecordTypeCNAME, cloudflare.RecordTypeNS,
+		cloudflare.RecordTypePTR:
+		return fqdn(record.Content)
+	case cloudflare.RecordTypeMX:
+		return map[string]interface{}{
+			"preference": priority(record),
+			"exchange":   fqdn(record.Content),
+		}
+	case cloudflare.RecordTypeSRV:
+		// The content is the weight, port, and target.
+		fields := strings.Fields(record.Content)
+		if len(fields) == 3 {
+			weight, _ := strconv.Atoi(fields[0])
+			port, _ := strconv.Atoi(fields[1])
+			return map[string]interface{}{
+				"priority": priority(record),
+				"weight":   weight,
+				"port":     port,
+				"target":   fqdn(fields[2]),
+			}
+		}
+	case cloudflare.RecordTypeCAA:
+		fields := strings.SplitN(record.Content, " ", 3)
+		if len(fields) == 3 {
+			flags, _ := strconv.Atoi(fields[0])
+			return map[string]interface{}{
+				"flags": flags,
+				"tag":   fields[1],
+				"value": strings.Trim(fields[2], `"`),
+			}
+		}
+	case cloudflare.RecordTypeTXT:
+		// octoDNS requires semicolons to be escaped.
+		return strings.ReplaceAll(record.Content, ";", `\;`)
+	}
+
+	return record.Content
+}
+
+// Write the records as an external-dns DNSEndpoint resource.
+func exportExternalDNS(zone string, records []cloudflare.DNSRecord) error {
+	type providerSpecific struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	type endpoint struct {
+		DNSName          string             `json:"dnsName"`
+		Targets          []string           `json:"targets"`
+		RecordType       string             `json:"recordType"`
+		RecordTTL        int                `json:"recordTTL,omitempty"`
+		ProviderSpecific []providerSpecific `json:"providerSpecific,omitempty"`
+	}
+
+	var endpoints []endpoint
+	for _, group := range groupRecords(records) {
+		first := group[0]
+
+		e := endpoint{
+			DNSName:    first.Name,
+			RecordType: first.Type,
+		}
+
+		// external-dns uses 0 to mean the provider's default.
+		if first.TTL != cloudflare.TTLAutomatic {
+			e.RecordTTL = first.TTL
+		}
+
+		for _, record := range group {
+			target := record.Content
+			if record.Priority != nil {
+				target = fmt.Sprintf("%d %s", *record.Priority, record.Content)
+			}
+			e.Targets = append(e.Targets, target)
+		}
+
+		if first.Proxiable {
+			e.ProviderSpecific = append(e.ProviderSpecific, providerSpecific{
+				Name:  "external-dns.alpha.kubernetes.io/cloudflare-proxied",
+				Value: strconv.FormatBool(first.Proxied),
+			})
+		}
+
+		endpoints = append(endpoints, e)
+	}
+
+	resource := map[string]interface{}{
+		"apiVersion": "externaldns.k8s.io/v1alpha1",
+		"kind":       "DNSEndpoint",
+		"metadata": map[string]interface{}{
+			"name": strings.ReplaceAll(zone, ".", "-"),
+		},
+		"spec": map[string]interface{}{
+			"endpoints": endpoints,
+		},
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(resource)
+	if err != nil {
+		return fmt.Errorf("unable to encode to JSON: %w", err)
+	}
+
+	return nil
+}
+
+// Group records with the same name and type, sorted by name and type.
+func groupRecords(records []cloudflare.DNSRecord) [][]cloudflare.DNSRecord {
+	groups := map[string][]cloudflare.DNSRecord{}
+	var keys []string
+	for _, record := range records {
+		key := strings.ToLower(record.Name) + " " + record.Type
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], record)
+	}
+
+	sort.Strings(keys)
+
+	var sorted [][]cloudflare.DNSRecord
+	for _, key := range keys {
+		sorted = append(sorted, groups[key])
+	}
+	return sorted
+}
+
+// The name relative to the zone. The zone itself is ”.
+func relativeName(name, zone string) string {
+	name = strings.ToLower(name)
+	zone = strings.ToLower(zone)
+	if name == zone {
+		return ""
+	}
+	return strings.TrimSuffix(name, "."+zone)
+}
+
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+func priority(record cloudflare.DNSRecord) int {
+	if record.Priority == nil {
+		return 0
+	}
+	return int(*record.Priority)
+}
@@ -0,0 +1,159 @@
+// Package records lists the DNS records of a Cloudflare domain.
+//
+// It can limit the listing to records created or modified recently, which is
+// useful for answering "what changed in the last day?"
+package records
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/horgh/cloudflare"
+	"github.com/horgh/cloudflare/internal/cli"
+)
+
+// Args are command line arguments.
+type Args struct {
+	Credentials   cli.Credentials
+	Domain        string
+	Type          string
+	Name          string
+	ModifiedSince time.Duration
+	CreatedSince  time.Duration
+	Output        string
+
+	// Export is the format to export the records in, if any. See the Export
+	// constants.
+	Export string
+}
+
+// Run runs the command. name is how it was invoked and args are its
+// arguments. We return the exit code.
+func Run(name string, arguments []string) int {
+	fs := cli.NewFlagSet(name)
+	if cli.HandleVersion(name, arguments) {
+		return cli.ExitOK
+	}
+
+	args, err := getArgs(fs, arguments)
+	if err != nil {
+		if err == flag.ErrHelp {
+			return cli.ExitOK
+		}
+		return cli.UsageError(fs, err)
+	}
+
+	err = run(args)
+	if err != nil {
+		log.Print(err)
+		return cli.ExitFailure
+	}
+
+	return cli.ExitOK
+}
+
+func run(args Args) error {
+	client, err := args.Credentials.Client()
+	if err != nil {
+		return err
+	}
+
+	zones, err := client.ListZones(args.Domain, "", -1, -1, "", "", "")
+	if err != nil {
+		return fmt.Errorf("unable to list zones: %w", err)
+	}
+
+	if len(zones) != 1 {
+		return fmt.Errorf("zone not found for domain: %s", args.Domain)
+	}
+
+	records, err := client.ListAllDNSRecords(zones[0].ID, args.Type, args.Name)
+	if err != nil {
+		return fmt.Errorf("unable to list DNS records: %w", err)
+	}
+
+	now := time.Now()
+
+	if args.ModifiedSince > 0 {
+		records, err = cloudflare.FilterDNSRecordsByModified(records,
+			now.Add(-args.ModifiedSince), time.Time{})
+		if err != nil {
+			return fmt.Errorf("unable to filter records: %w", err)
+		}
+	}
+
+	if args.CreatedSince > 0 {
+		records, err = cloudflare.FilterDNSRecordsByCreated(records,
+			now.Add(-args.CreatedSince), time.Time{})
+		if err != nil {
+			return fmt.Errorf("unable to filter records: %w", err)
+		}
+	}
+
+	if len(args.Export) > 0 {
+		return export(args.Export, zones[0].Name, records)
+	}
+
+	if args.Output == cli.OutputJSON {
+		return cli.PrintJSON(records)
+	}
+
+	for _, record := range records {
+		fmt.Printf("%s\t%s\t%s\t%d\t%s\n", record.Name, record.Type,
+			record.Content, record.TTL, record.ModifiedOn)
+	}
+
+	return nil
+}
+
+func getArgs(fs *flag.FlagSet, arguments []string) (Args, error) {
+	credentialFlags := cli.AddCredentialFlags(fs)
+	domain := fs.String("domain", "", "Domain to list records of.")
+	recordType := fs.String("type", "", "Only list records of this type (e.g. A). Blank for all.")
+	name := fs.String("name", "", "Only list records with this name. Blank for all.")
+	modifiedSince := fs.Duration("modified-since", 0, "Only list records modified within this duration (e.g. 24h).")
+	createdSince := fs.Duration("created-since", 0, "Only list records created within this duration (e.g. 24h).")
+	exportFormat := fs.String("export", "", "Write the records as a config for another DNS tool rather than listing them: octodns (octoDNS YAML) or external-dns (a DNSEndpoint resource as JSON).")
+	output := cli.AddOutputFlag(fs)
+
+	err := fs.Parse(arguments)
+	if err != nil {
+		return Args{}, err
+	}
+
+	creds, err := credentialFlags.Load()
+	if err != nil {
+		return Args{}, err
+	}
+
+	if len(*domain) == 0 {
+		*domain = creds.Domain
+	}
+
+	if len(*domain) == 0 {
+		return Args{}, fmt.Errorf("you must provide a domain")
+	}
+
+	err = cli.CheckOutput(*output)
+	if err != nil {
+		return Args{}, err
+	}
+
+	err = checkExport(*exportFormat)
+	if err != nil {
+		return Args{}, err
+	}
+
+	return Args{
+		Credentials:   creds,
+		Domain:        *domain,
+		Type:          *recordType,
+		Name:          *name,
+		ModifiedSince: *modifiedSince,
+		CreatedSince:  *createdSince,
+		Output:        *output,
+		Export:        *exportFormat,
+	}, nil
+}
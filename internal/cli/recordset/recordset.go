@@ -0,0 +1,187 @@
+// Package recordset sets a DNS record of any type to the given content.
+//
+// It finds the record by name and type and updates it if its content differs,
+// optionally creating it. This suits records set by scripts, such as TXT
+// records for ACME DNS-01 challenges. The content may be read from stdin.
+package recordset
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/horgh/cloudflare"
+	"github.com/horgh/cloudflare/internal/cli"
+)
+
+// Args are command line arguments.
+type Args struct {
+	Credentials cli.Credentials
+	Domain      string
+	Want        Want
+	Output      string
+}
+
+// result describes what we did.
+type result struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	// OldContent is set if we changed the content.
+	OldContent string `json:"old_content,omitempty"`
+	// Action is created, updated, or unchanged.
+	Action string `json:"action"`
+}
+
+// Run runs the command. name is how it was invoked and args are its
+// arguments. We return the exit code.
+func Run(name string, arguments []string) int {
+	fs := cli.NewFlagSet(name)
+	if cli.HandleVersion(name, arguments) {
+		return cli.ExitOK
+	}
+
+	args, err := getArgs(fs, arguments, os.Stdin)
+	if err != nil {
+		if err == flag.ErrHelp {
+			return cli.ExitOK
+		}
+		return cli.UsageError(fs, err)
+	}
+
+	err = run(args)
+	if err != nil {
+		log.Print(err)
+		return cli.ExitFailure
+	}
+
+	return cli.ExitOK
+}
+
+func run(args Args) error {
+	client, err := args.Credentials.Client()
+	if err != nil {
+		return err
+	}
+
+	zoneID, err := client.ZoneIDByName(args.Domain)
+	if err != nil {
+		return fmt.Errorf("unable to find zone: %w", err)
+	}
+
+	outcome, err := Set(client, zoneID, args.Want, args.Credentials.Verbose)
+	if err != nil {
+		return err
+	}
+
+	switch outcome.Action {
+	case ActionCreated:
+		log.Printf("Created %s record [%s]", args.Want.Type, args.Want.Name)
+	case ActionUpdated:
+		log.Printf("Updated %s record [%s]", args.Want.Type, args.Want.Name)
+	default:
+		if args.Credentials.Verbose {
+			log.Printf("%s record [%s] is already as wanted. No update performed.",
+				args.Want.Type, args.Want.Name)
+		}
+	}
+
+	if args.Output == cli.OutputJSON {
+		return cli.PrintJSON(result{
+			Name:       args.Want.Name,
+			Type:       args.Want.Type,
+			Content:    outcome.Record.Content,
+			OldContent: outcome.OldContent,
+			Action:     outcome.Action,
+		})
+	}
+
+	return nil
+}
+
+func getArgs(fs *flag.FlagSet, arguments []string,
+	stdin io.Reader) (Args, error) {
+	credentialFlags := cli.AddCredentialFlags(fs)
+	domain := fs.String("domain", "", "Domain the record is in.")
+	name := fs.String("name", "", "Name of the record to set, e.g. _acme-challenge.example.com.")
+	recordType := fs.String("type", "", "Type of the record, e.g. TXT or CNAME.")
+	content := fs.String("content", "", "Content to set. Give - to read it from stdin.")
+	createMissing := fs.Bool("create-missing", false, "If no matching record exists, create one rather than failing.")
+	ttl := fs.Int("ttl", 0, "TTL to set on the record. 1 means automatic. If you don't provide this, we leave the TTL as it is (or use automatic for a new record).")
+	proxied := fs.Bool("proxied", false, "Whether the record is proxied through Cloudflare. If you don't provide this, we leave the setting as it is (or don't proxy a new record).")
+	output := cli.AddOutputFlag(fs)
+
+	err := fs.Parse(arguments)
+	if err != nil {
+		return Args{}, err
+	}
+
+	creds, err := credentialFlags.Load()
+	if err != nil {
+		return Args{}, err
+	}
+
+	if len(*domain) == 0 {
+		*domain = creds.Domain
+	}
+
+	if len(*domain) == 0 {
+		return Args{}, fmt.Errorf("you must provide a domain")
+	}
+
+	if len(*name) == 0 {
+		return Args{}, fmt.Errorf("you must provide a name")
+	}
+
+	if len(*recordType) == 0 {
+		return Args{}, fmt.Errorf("you must provide a type")
+	}
+
+	if *content == "-" {
+		buf, err := io.ReadAll(stdin)
+		if err != nil {
+			return Args{}, fmt.Errorf("unable to read content: %w", err)
+		}
+		*content = strings.TrimSpace(string(buf))
+	}
+
+	if len(*content) == 0 {
+		return Args{}, fmt.Errorf("you must provide content")
+	}
+
+	if *ttl != 0 && *ttl != cloudflare.TTLAutomatic &&
+		(*ttl < cloudflare.MinTTL || *ttl > cloudflare.MaxTTL) {
+		return Args{}, fmt.Errorf("TTL must be %d (automatic) or %d to %d",
+			cloudflare.TTLAutomatic, cloudflare.MinTTL, cloudflare.MaxTTL)
+	}
+
+	err = cli.CheckOutput(*output)
+	if err != nil {
+		return Args{}, err
+	}
+
+	// Only change whether the record is proxied if asked to.
+	var proxiedSetting *bool
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "proxied" {
+			proxiedSetting = proxied
+		}
+	})
+
+	return Args{
+		Credentials: creds,
+		Domain:      *domain,
+		Want: Want{
+			Name:          *name,
+			Type:          strings.ToUpper(*recordType),
+			Content:       *content,
+			TTL:           *ttl,
+			Proxied:       proxiedSetting,
+			CreateMissing: *createMissing,
+		},
+		Output: *output,
+	}, nil
+}
@@ -0,0 +1,168 @@
+package recordset
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/horgh/cloudflare"
+)
+
+// Actions we report taking.
+const (
+	ActionCreated   = "created"
+	ActionUpdated   = "updated"
+	ActionUnchanged = "unchanged"
+)
+
+// Want describes the record we want to exist.
+type Want struct {
+	Name    string
+	Type    string
+	Content string
+
+	// TTL is the TTL to set. 0 means to leave an existing record's TTL alone
+	// and to use automatic for a new record.
+	TTL int
+
+	// Proxied is whether the record should be proxied. nil means to leave an
+	// existing record's setting alone and to not proxy a new record.
+	Proxied *bool
+
+	// CreateMissing means to create the record if there is none. Otherwise we
+	// fail.
+	CreateMissing bool
+}
+
+// Outcome describes what Set did.
+type Outcome struct {
+	Record cloudflare.DNSRecord
+
+	// OldContent is the record's content before we changed it. It is blank if
+	// we created the record.
+	OldContent string
+
+	// Action is one of the Action constants.
+	Action string
+}
+
+// Set makes the record of the type with the name match what we want.
+//
+// We find the record by name and type, and update it if it differs. There
+// must be at most one such record. Names are compared ignoring case and any
+// trailing dot.
+func Set(client cloudflare.Client, zoneID string, want Want,
+	verbose bool) (Outcome, error) {
+	name := cloudflare.NormalizeName(want.Name)
+
+	records, err := client.ListAllDNSRecords(zoneID, want.Type, name)
+	if err != nil {
+		return Outcome{}, fmt.Errorf("unable to list DNS records: %w", err)
+	}
+
+	matchingRecords := []cloudflare.DNSRecord{}
+	for _, record := range records {
+		if verbose {
+			log.Printf("Record: %+v", record)
+		}
+		if cloudflare.NormalizeName(record.Name) == name &&
+			strings.EqualFold(record.Type, want.Type) {
+			matchingRecords = append(matchingRecords, record)
+		}
+	}
+
+	if len(matchingRecords) == 0 {
+		if !want.CreateMissing {
+			return Outcome{}, fmt.Errorf("record not found. No update performed")
+		}
+		return create(client, zoneID, want, verbose)
+	}
+
+	if len(matchingRecords) > 1 {
+		return Outcome{}, fmt.Errorf("multiple matching records found. Unable to perform update")
+	}
+
+	outcome, err := update(client, matchingRecords[0], want, verbose)
+	var conflict *cloudflare.ConflictError
+	if errors.As(err, &conflict) {
+		// Our copy was out of date, such as if it came from the client's
+		// cache. Try once more with the record as it is now.
+		if verbose {
+			log.Printf("Record changed since we retrieved it: %s", err)
+		}
+		return update(client, conflict.Current, want, verbose)
+	}
+
+	return outcome, err
+}
+
+// Update the record to what we want if it differs.
+func update(client cloudflare.Client, record cloudflare.DNSRecord, want Want,
+	verbose bool) (Outcome, error) {
+	ttlChanged := want.TTL != 0 && record.TTL != want.TTL
+	proxiedChanged := want.Proxied != nil && record.Proxied != *want.Proxied
+
+	// Content written differently, such as an IPv6 address in another form,
+	// is the same.
+	contentChanged := !cloudflare.ContentEqual(record, cloudflare.DNSRecord{
+		Type:     record.Type,
+		Content:  want.Content,
+		Priority: record.Priority,
+	})
+
+	if !contentChanged && !ttlChanged && !proxiedChanged {
+		return Outcome{Record: record, Action: ActionUnchanged}, nil
+	}
+
+	oldContent := record.Content
+	record.Content = want.Content
+	if want.TTL != 0 {
+		record.TTL = want.TTL
+	}
+	if want.Proxied != nil {
+		record.Proxied = *want.Proxied
+	}
+
+	if verbose {
+		log.Printf("Updating record to: %+v", record)
+	}
+
+	// Another program may be updating the same record. Don't undo its change.
+	err := client.UpdateDNSRecordIfUnchanged(record)
+	if err != nil {
+		return Outcome{}, fmt.Errorf("unable to update DNS record: %w", err)
+	}
+
+	return Outcome{
+		Record:     record,
+		OldContent: oldContent,
+		Action:     ActionUpdated,
+	}, nil
+}
+
+// Create the record as it does not exist.
+func create(client cloudflare.Client, zoneID string, want Want,
+	verbose bool) (Outcome, error) {
+	record := cloudflare.DNSRecord{
+		ZoneID:  zoneID,
+		Type:    want.Type,
+		Name:    want.Name,
+		Content: want.Content,
+		TTL:     want.TTL,
+	}
+	if want.Proxied != nil {
+		record.Proxied = *want.Proxied
+	}
+
+	if verbose {
+		log.Printf("Creating record: %+v", record)
+	}
+
+	created, err := client.CreateDNSRecord(record)
+	if err != nil {
+		return Outcome{}, fmt.Errorf("unable to create DNS record: %w", err)
+	}
+
+	return Outcome{Record: created, Action: ActionCreated}, nil
+}
@@ -0,0 +1,215 @@
+// Package smoke runs a smoke test against a Cloudflare zone set aside for testing.
+//
+// It exercises the main read and write paths of the API: it creates, updates,
+// and deletes a TXT record, toggles a harmless setting, and purges a URL. It
+// cleans up after itself and reports whether each step passed.
+//
+// This is useful for checking that credentials work and for monitoring the
+// API's health from your own infrastructure.
+//
+// Do not point it at a production zone.
+package smoke
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/horgh/cloudflare"
+	"github.com/horgh/cloudflare/internal/cli"
+)
+
+// Args are command line arguments.
+type Args struct {
+	Credentials cli.Credentials
+	Domain      string
+	Setting     string
+	Output      string
+}
+
+// checkResult is the outcome of a single check.
+type checkResult struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Error  string `json:"error,omitempty"`
+}
+
+// The setting we toggle. It should be one that changing briefly is harmless.
+const defaultSetting = cloudflare.SettingOpportunisticOnion
+
+// Run runs the command. name is how it was invoked and args are its
+// arguments. We return the exit code.
+func Run(name string, arguments []string) int {
+	fs := cli.NewFlagSet(name)
+	if cli.HandleVersion(name, arguments) {
+		return cli.ExitOK
+	}
+
+	args, err := getArgs(fs, arguments)
+	if err != nil {
+		if err == flag.ErrHelp {
+			return cli.ExitOK
+		}
+		return cli.UsageError(fs, err)
+	}
+
+	client, err := args.Credentials.Client()
+	if err != nil {
+		log.Print(err)
+		return cli.ExitFailure
+	}
+
+	results := runChecks(client, args)
+
+	if args.Output == cli.OutputJSON {
+		err := cli.PrintJSON(results)
+		if err != nil {
+			log.Print(err)
+			return cli.ExitFailure
+		}
+	}
+
+	for _, result := range results {
+		if !result.Passed {
+			return cli.ExitFailure
+		}
+	}
+
+	return cli.ExitOK
+}
+
+func getArgs(fs *flag.FlagSet, arguments []string) (Args, error) {
+	credentialFlags := cli.AddCredentialFlags(fs)
+	domain := fs.String("domain", "", "Domain of the zone to test against. It should be one set aside for testing.")
+	setting := fs.String("setting", defaultSetting, "On/off zone setting to toggle and restore.")
+	output := cli.AddOutputFlag(fs)
+
+	err := fs.Parse(arguments)
+	if err != nil {
+		return Args{}, err
+	}
+
+	creds, err := credentialFlags.Load()
+	if err != nil {
+		return Args{}, err
+	}
+
+	if len(*domain) == 0 {
+		*domain = creds.Domain
+	}
+
+	if len(*domain) == 0 {
+		return Args{}, fmt.Errorf("you must provide a domain")
+	}
+
+	err = cli.CheckOutput(*output)
+	if err != nil {
+		return Args{}, err
+	}
+
+	return Args{
+		Credentials: creds,
+		Domain:      *domain,
+		Setting:     *setting,
+		Output:      *output,
+	}, nil
+}
+
+// Run each check and report on it.
+func runChecks(client cloudflare.Client, args Args) []checkResult {
+	var results []checkResult
+	report := func(name string, err error) bool {
+		if err != nil {
+			log.Printf("FAIL %s: %s", name, err)
+			results = append(results, checkResult{Name: name, Error: err.Error()})
+			return false
+		}
+		log.Printf("PASS %s", name)
+		results = append(results, checkResult{Name: name, Passed: true})
+		return true
+	}
+
+	zones, err := client.ListZones(args.Domain, "", -1, -1, "", "", "")
+	if err == nil && len(zones) != 1 {
+		err = fmt.Errorf("zone not found for domain: %s", args.Domain)
+	}
+	if !report("list zones", err) {
+		return results
+	}
+	zone := zones[0]
+
+	checkRecords(client, zone, report)
+
+	checkSetting(client, zone, args.Setting, report)
+
+	purgeURL := fmt.Sprintf("https://%s/cfsmoke-%d", zone.Name,
+		time.Now().Unix())
+	report("purge URL", client.PurgeFiles(zone.ID, []string{purgeURL}))
+
+	return results
+}
+
+// Create, update, look up, and delete a TXT record.
+func checkRecords(client cloudflare.Client, zone cloudflare.Zone,
+	report func(string, error) bool) {
+	name := fmt.Sprintf("_cfsmoke-%d.%s", time.Now().Unix(), zone.Name)
+
+	record, err := client.CreateDNSRecord(cloudflare.DNSRecord{
+		ZoneID:  zone.ID,
+		Type:    cloudflare.RecordTypeTXT,
+		Name:    name,
+		Content: "cfsmoke created",
+		TTL:     120,
+	})
+	if !report("create TXT record", err) {
+		return
+	}
+
+	// Always try to clean up the record.
+	deleted := false
+	defer func() {
+		if deleted {
+			return
+		}
+		err := client.DeleteDNSRecord(record)
+		if err != nil {
+			log.Printf("Unable to clean up record %s: %s", name, err)
+		}
+	}()
+
+	record.Content = "cfsmoke updated"
+	report("update TXT record", client.UpdateDNSRecord(record))
+
+	records, err := client.ListDNSRecords(zone.ID, cloudflare.RecordTypeTXT, name, "", -1, -1, "",
+		"", "")
+	if err == nil && (len(records) != 1 || records[0].Content != record.Content) {
+		err = fmt.Errorf("record lookup returned %+v", records)
+	}
+	report("list TXT record", err)
+
+	err = client.DeleteDNSRecord(record)
+	deleted = report("delete TXT record", err)
+}
+
+// Flip an on/off setting and then restore it.
+func checkSetting(client cloudflare.Client, zone cloudflare.Zone,
+	setting string, report func(string, error) bool) {
+	original, err := client.GetZoneSettingString(zone.ID, setting)
+	if !report("get setting", err) {
+		return
+	}
+
+	toggled := "on"
+	if original == "on" {
+		toggled = "off"
+	}
+
+	_, err = client.UpdateZoneSetting(zone.ID, setting, toggled)
+	if !report("toggle setting", err) {
+		return
+	}
+
+	_, err = client.UpdateZoneSetting(zone.ID, setting, original)
+	report("restore setting", err)
+}
@@ -0,0 +1,197 @@
+// Package stats prints a summary of a domain's recent traffic.
+package stats
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/horgh/cloudflare"
+	"github.com/horgh/cloudflare/internal/cli"
+)
+
+// Args are command line arguments.
+type Args struct {
+	Credentials cli.Credentials
+	Domain      string
+	Since       time.Duration
+	Daily       bool
+	Output      string
+}
+
+// summary is the traffic totals along with the buckets they came from.
+type summary struct {
+	Domain  string                           `json:"domain"`
+	Since   time.Time                        `json:"since"`
+	Until   time.Time                        `json:"until"`
+	Totals  cloudflare.HTTPAnalyticsBucket   `json:"totals"`
+	Buckets []cloudflare.HTTPAnalyticsBucket `json:"buckets"`
+}
+
+// Run runs the command. name is how it was invoked and args are its
+// arguments. We return the exit code.
+func Run(name string, arguments []string) int {
+	fs := cli.NewFlagSet(name)
+	if cli.HandleVersion(name, arguments) {
+		return cli.ExitOK
+	}
+
+	args, err := getArgs(fs, arguments)
+	if err != nil {
+		if err == flag.ErrHelp {
+			return cli.ExitOK
+		}
+		return cli.UsageError(fs, err)
+	}
+
+	err = run(args)
+	if err != nil {
+		log.Print(err)
+		return cli.ExitFailure
+	}
+
+	return cli.ExitOK
+}
+
+func run(args Args) error {
+	client, err := args.Credentials.Client()
+	if err != nil {
+		return err
+	}
+
+	zones, err := client.ListZones(args.Domain, "", -1, -1, "", "", "")
+	if err != nil {
+		return fmt.Errorf("unable to list zones: %w", err)
+	}
+
+	if len(zones) != 1 {
+		return fmt.Errorf("zone not found for domain: %s", args.Domain)
+	}
+
+	dimension := cloudflare.AnalyticsHourly
+	until := time.Now().UTC().Truncate(time.Hour).Add(time.Hour)
+	if args.Daily {
+		dimension = cloudflare.AnalyticsDaily
+		until = time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+	}
+	since := until.Add(-args.Since)
+
+	buckets, err := client.ZoneHTTPAnalytics(zones[0].ID, since, until,
+		dimension)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve analytics: %w", err)
+	}
+
+	s := summary{
+		Domain:  zones[0].Name,
+		Since:   since,
+		Until:   until,
+		Buckets: buckets,
+	}
+
+	for _, bucket := range buckets {
+		s.Totals.Requests += bucket.Requests
+		s.Totals.CachedRequests += bucket.CachedRequests
+		s.Totals.Bytes += bucket.Bytes
+		s.Totals.CachedBytes += bucket.CachedBytes
+		s.Totals.Threats += bucket.Threats
+		s.Totals.PageViews += bucket.PageViews
+		s.Totals.Uniques += bucket.Uniques
+	}
+	s.Totals.Start = since
+
+	if args.Output == cli.OutputJSON {
+		return cli.PrintJSON(s)
+	}
+
+	return printSummary(s)
+}
+
+func getArgs(fs *flag.FlagSet, arguments []string) (Args, error) {
+	credentialFlags := cli.AddCredentialFlags(fs)
+	domain := fs.String("domain", "", "Domain to show traffic of.")
+	since := fs.Duration("since", 24*time.Hour, "How far back to look.")
+	daily := fs.Bool("daily", false, "Show a row per day rather than per hour.")
+	output := cli.AddOutputFlag(fs)
+
+	err := fs.Parse(arguments)
+	if err != nil {
+		return Args{}, err
+	}
+
+	creds, err := credentialFlags.Load()
+	if err != nil {
+		return Args{}, err
+	}
+
+	if len(*domain) == 0 {
+		*domain = creds.Domain
+	}
+
+	if len(*domain) == 0 {
+		return Args{}, fmt.Errorf("you must provide a domain")
+	}
+
+	if *since <= 0 {
+		return Args{}, fmt.Errorf("since must be positive")
+	}
+
+	err = cli.CheckOutput(*output)
+	if err != nil {
+		return Args{}, err
+	}
+
+	return Args{
+		Credentials: creds,
+		Domain:      *domain,
+		Since:       *since,
+		Daily:       *daily,
+		Output:      *output,
+	}, nil
+}
+
+func printSummary(s summary) error {
+	fmt.Printf("Traffic for %s from %s to %s\n\n", s.Domain,
+		s.Since.Format(time.RFC3339), s.Until.Format(time.RFC3339))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	_, err := fmt.Fprintln(w,
+		"START\tREQUESTS\tCACHED\tBYTES\tCACHED BYTES\tTHREATS\tPAGE VIEWS\tUNIQUES\t")
+	if err != nil {
+		return fmt.Errorf("write error: %w", err)
+	}
+
+	for _, bucket := range s.Buckets {
+		err := printBucket(w, bucket.Start.Format("2006-01-02 15:04"), bucket)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = printBucket(w, "TOTAL", s.Totals)
+	if err != nil {
+		return err
+	}
+
+	err = w.Flush()
+	if err != nil {
+		return fmt.Errorf("write error: %w", err)
+	}
+
+	return nil
+}
+
+func printBucket(w *tabwriter.Writer, label string,
+	bucket cloudflare.HTTPAnalyticsBucket) error {
+	_, err := fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t\n", label,
+		bucket.Requests, bucket.CachedRequests, bucket.Bytes, bucket.CachedBytes,
+		bucket.Threats, bucket.PageViews, bucket.Uniques)
+	if err != nil {
+		return fmt.Errorf("write error: %w", err)
+	}
+	return nil
+}
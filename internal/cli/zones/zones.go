@@ -0,0 +1,130 @@
+// Package zones lists the zones on a Cloudflare account.
+//
+// It shows each zone's ID, status, plan, and nameservers, either as a table or
+// as JSON.
+package zones
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/horgh/cloudflare"
+	"github.com/horgh/cloudflare/internal/cli"
+)
+
+// Args are command line arguments.
+type Args struct {
+	Credentials cli.Credentials
+	Name        string
+	Status      string
+	Output      string
+}
+
+// Run runs the command. name is how it was invoked and args are its
+// arguments. We return the exit code.
+func Run(name string, arguments []string) int {
+	fs := cli.NewFlagSet(name)
+	if cli.HandleVersion(name, arguments) {
+		return cli.ExitOK
+	}
+
+	args, err := getArgs(fs, arguments)
+	if err != nil {
+		if err == flag.ErrHelp {
+			return cli.ExitOK
+		}
+		return cli.UsageError(fs, err)
+	}
+
+	err = run(args)
+	if err != nil {
+		log.Print(err)
+		return cli.ExitFailure
+	}
+
+	return cli.ExitOK
+}
+
+func run(args Args) error {
+	client, err := args.Credentials.Client()
+	if err != nil {
+		return err
+	}
+
+	zones, err := client.ListAllZones(args.Name, args.Status)
+	if err != nil {
+		return fmt.Errorf("unable to list zones: %w", err)
+	}
+
+	if args.Output == cli.OutputJSON {
+		return cli.PrintJSON(zones)
+	}
+	return printTable(zones)
+}
+
+func getArgs(fs *flag.FlagSet, arguments []string) (Args, error) {
+	credentialFlags := cli.AddCredentialFlags(fs)
+	name := fs.String("name", "", "Only list the zone with this domain name.")
+	status := fs.String("status", "", "Only list zones with this status (active, pending, initializing, moved). Defaults to active.")
+	output := cli.AddOutputFlag(fs)
+
+	err := fs.Parse(arguments)
+	if err != nil {
+		return Args{}, err
+	}
+
+	creds, err := credentialFlags.Load()
+	if err != nil {
+		return Args{}, err
+	}
+
+	// We used to call text output "table".
+	if *output == "table" {
+		*output = cli.OutputText
+	}
+
+	err = cli.CheckOutput(*output)
+	if err != nil {
+		return Args{}, err
+	}
+
+	return Args{
+		Credentials: creds,
+		Name:        *name,
+		Status:      *status,
+		Output:      *output,
+	}, nil
+}
+
+func printTable(zones []cloudflare.Zone) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	_, err := fmt.Fprintln(w, "NAME\tID\tSTATUS\tPLAN\tNAMESERVERS")
+	if err != nil {
+		return fmt.Errorf("write error: %w", err)
+	}
+
+	for _, zone := range zones {
+		status := zone.Status
+		if zone.Paused {
+			status += " (paused)"
+		}
+
+		_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", zone.Name, zone.ID,
+			status, zone.Plan.Name, strings.Join(zone.NameServers, ","))
+		if err != nil {
+			return fmt.Errorf("write error: %w", err)
+		}
+	}
+
+	err = w.Flush()
+	if err != nil {
+		return fmt.Errorf("write error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,300 @@
+// Package websocket is a minimal WebSocket client.
+//
+// It supports what we need to read events from the API (such as Workers
+// tail): dialing, reading text and binary messages, writing text messages,
+// and answering pings.
+package websocket
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Conn is a WebSocket connection.
+type Conn struct {
+	body   io.ReadWriteCloser
+	reader *bufio.Reader
+
+	writeMutex sync.Mutex
+	closed     bool
+}
+
+// Opcodes.
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+// The largest message we accept.
+const maxMessageSize = 16 * 1024 * 1024
+
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// Dial connects to a ws:// or wss:// URL. header holds extra request headers
+// and protocol, if not blank, is the subprotocol to ask for.
+//
+// We connect using a copy of transport, so its proxy, dialer, and TLS
+// settings apply. It may be nil to use http.DefaultTransport. timeout limits
+// the handshake.
+func Dial(rawURL string, header http.Header, protocol string,
+	transport *http.Transport, timeout time.Duration) (*Conn, error) {
+	switch {
+	case strings.HasPrefix(rawURL, "wss://"):
+		rawURL = "https://" + strings.TrimPrefix(rawURL, "wss://")
+	case strings.HasPrefix(rawURL, "ws://"):
+		rawURL = "http://" + strings.TrimPrefix(rawURL, "ws://")
+	default:
+		return nil, fmt.Errorf("not a WebSocket URL: %s", rawURL)
+	}
+
+	req, err := http.NewRequest("GET", rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %w", err)
+	}
+
+	for k, vs := range header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+
+	nonce := make([]byte, 16)
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate key: %w", err)
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	if len(protocol) > 0 {
+		req.Header.Set("Sec-WebSocket-Protocol", protocol)
+	}
+
+	if transport == nil {
+		transport = http.DefaultTransport.(*http.Transport)
+	}
+
+	// The upgrade only works over HTTP/1.1. We don't set a client timeout as
+	// that would apply to reading the connection afterwards too. timeout
+	// covers the handshake.
+	t := transport.Clone()
+	t.ForceAttemptHTTP2 = false
+	t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	if t.TLSClientConfig != nil {
+		t.TLSClientConfig.NextProtos = nil
+	}
+	t.TLSHandshakeTimeout = timeout
+	t.ResponseHeaderTimeout = timeout
+	if t.DialContext == nil {
+		t.DialContext = (&net.Dialer{Timeout: timeout}).DialContext
+	}
+
+	client := &http.Client{Transport: t}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing request: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status %s: %s", resp.Status,
+			strings.TrimSpace(string(body)))
+	}
+
+	sum := sha1.Sum([]byte(key + acceptGUID))
+	if resp.Header.Get("Sec-WebSocket-Accept") !=
+		base64.StdEncoding.EncodeToString(sum[:]) {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("server sent an invalid Sec-WebSocket-Accept")
+	}
+
+	body, ok := resp.Body.(io.ReadWriteCloser)
+	if !ok {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("connection is not writable")
+	}
+
+	return &Conn{
+		body:   body,
+		reader: bufio.NewReader(body),
+	}, nil
+}
+
+// ReadMessage reads the next text or binary message. It answers pings while
+// waiting. If the server closes the connection we return io.EOF.
+func (c *Conn) ReadMessage() ([]byte, error) {
+	var message []byte
+	inMessage := false
+
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case opPing:
+			err := c.writeFrame(opPong, payload)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		case opPong:
+			continue
+		case opClose:
+			_ = c.writeFrame(opClose, payload)
+			return nil, io.EOF
+		case opText, opBinary:
+			if inMessage {
+				return nil, fmt.Errorf("new message before previous one finished")
+			}
+			inMessage = true
+			message = payload
+		case opContinuation:
+			if !inMessage {
+				return nil, fmt.Errorf("continuation frame without a message")
+			}
+			message = append(message, payload...)
+		default:
+			return nil, fmt.Errorf("unknown opcode: %d", opcode)
+		}
+
+		if len(message) > maxMessageSize {
+			return nil, fmt.Errorf("message is too large")
+		}
+
+		if fin {
+			return message, nil
+		}
+	}
+}
+
+// WriteText sends a text message.
+func (c *Conn) WriteText(message []byte) error {
+	return c.writeFrame(opText, message)
+}
+
+// Close closes the connection, telling the server first if we can.
+func (c *Conn) Close() error {
+	_ = c.writeFrame(opClose, []byte{0x03, 0xe8})
+
+	c.writeMutex.Lock()
+	c.closed = true
+	c.writeMutex.Unlock()
+
+	return c.body.Close()
+}
+
+func (c *Conn) readFrame() (bool, byte, []byte, error) {
+	var header [2]byte
+	_, err := io.ReadFull(c.reader, header[:])
+	if err != nil {
+		return false, 0, nil, err
+	}
+
+	fin := header[0]&0x80 != 0
+	opcode := header[0] & 0x0f
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7f)
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		_, err := io.ReadFull(c.reader, ext[:])
+		if err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		_, err := io.ReadFull(c.reader, ext[:])
+		if err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+
+	if length > maxMessageSize {
+		return false, 0, nil, fmt.Errorf("frame is too large: %d bytes", length)
+	}
+
+	var mask [4]byte
+	if masked {
+		_, err := io.ReadFull(c.reader, mask[:])
+		if err != nil {
+			return false, 0, nil, err
+		}
+	}
+
+	payload := make([]byte, length)
+	_, err = io.ReadFull(c.reader, payload)
+	if err != nil {
+		return false, 0, nil, err
+	}
+
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+
+	return fin, opcode, payload, nil
+}
+
+// Clients must mask every frame they send.
+func (c *Conn) writeFrame(opcode byte, payload []byte) error {
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+
+	if c.closed {
+		return fmt.Errorf("connection is closed")
+	}
+
+	frame := []byte{0x80 | opcode}
+
+	length := len(payload)
+	switch {
+	case length < 126:
+		frame = append(frame, 0x80|byte(length))
+	case length <= 0xffff:
+		frame = append(frame, 0x80|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(length))
+	default:
+		frame = append(frame, 0x80|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(length))
+	}
+
+	var mask [4]byte
+	_, err := rand.Read(mask[:])
+	if err != nil {
+		return fmt.Errorf("unable to generate mask: %w", err)
+	}
+	frame = append(frame, mask[:]...)
+
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+
+	_, err = c.body.Write(frame)
+	return err
+}
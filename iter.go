@@ -0,0 +1,163 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DNSRecordListOptions filters DNS records when iterating over them.
+type DNSRecordListOptions struct {
+	// Type, Name, and Content may be blank to not filter on them.
+	Type    string
+	Name    string
+	Content string
+
+	// Filter adds further conditions, such as names containing a string.
+	Filter DNSRecordFilter
+
+	// PerPage is how many records to request at once. Zero means 100.
+	PerPage int
+}
+
+// ZoneListOptions filters zones when iterating over them.
+type ZoneListOptions struct {
+	Name string
+
+	// Status is blank for active zones.
+	Status string
+
+	// PerPage is how many zones to request at once. Zero means 50.
+	PerPage int
+}
+
+// DNSRecords iterates over the DNS records of a zone.
+//
+// It requests a page at a time as the loop needs it, so it does not hold
+// every record in memory, and stopping the loop early makes no further
+// requests. If a request fails, the loop receives the error and ends.
+//
+//	for record, err := range client.DNSRecords(ctx, zoneID, opts) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (c Client) DNSRecords(ctx context.Context, zoneID string,
+	opts DNSRecordListOptions) iter.Seq2[DNSRecord, error] {
+	values, keep, filterErr := opts.Filter.query()
+	if values == nil {
+		values = url.Values{}
+	}
+
+	// With MatchAny these must narrow the matching records, which the API
+	// can't do, so we check them ourselves.
+	if opts.Filter.matchAny {
+		filterKeep := keep
+		keep = func(r DNSRecord) bool {
+			return filterKeep(r) &&
+				(len(opts.Type) == 0 || strings.EqualFold(r.Type, opts.Type)) &&
+				(len(opts.Name) == 0 ||
+					NormalizeName(r.Name) == NormalizeName(opts.Name)) &&
+				(len(opts.Content) == 0 || r.Content == opts.Content)
+		}
+	} else {
+		if len(opts.Type) > 0 {
+			values.Set("type", opts.Type)
+		}
+		if len(opts.Name) > 0 {
+			values.Set("name", opts.Name)
+		}
+		if len(opts.Content) > 0 {
+			values.Set("content", opts.Content)
+		}
+	}
+
+	perPage := opts.PerPage
+	if perPage <= 0 {
+		perPage = 100
+	}
+
+	return func(yield func(DNSRecord, error) bool) {
+		if len(zoneID) == 0 {
+			yield(DNSRecord{}, fmt.Errorf("you must provide a zone ID"))
+			return
+		}
+
+		if filterErr != nil {
+			yield(DNSRecord{}, filterErr)
+			return
+		}
+
+		paginate(ctx, c, zonePrefix(zoneID)+"/dns_records", values, perPage,
+			"list DNS records", func(record DNSRecord, err error) bool {
+				if err == nil && !keep(record) {
+					return true
+				}
+				return yield(record, err)
+			})
+	}
+}
+
+// Zones iterates over the account's zones. See DNSRecords for how iteration
+// works.
+func (c Client) Zones(ctx context.Context,
+	opts ZoneListOptions) iter.Seq2[Zone, error] {
+	values := url.Values{}
+	if len(opts.Name) > 0 {
+		values.Set("name", opts.Name)
+	}
+	if len(opts.Status) > 0 {
+		values.Set("status", opts.Status)
+	} else {
+		values.Set("status", "active")
+	}
+
+	perPage := opts.PerPage
+	if perPage <= 0 {
+		perPage = 50
+	}
+
+	return func(yield func(Zone, error) bool) {
+		paginate(ctx, c, "zones", values, perPage, "list zones", yield)
+	}
+}
+
+// paginate requests each page of a list in turn, passing each item to yield,
+// until there are no more pages or yield returns false.
+func paginate[T any](ctx context.Context, c Client, path string,
+	values url.Values, perPage int, operation string,
+	yield func(T, error) bool) {
+	var zero T
+
+	for page := 1; ; page++ {
+		pageValues := url.Values{}
+		for k, v := range values {
+			pageValues[k] = v
+		}
+		pageValues.Set("page", strconv.Itoa(page))
+		pageValues.Set("per_page", strconv.Itoa(perPage))
+
+		var items []T
+		info, err := c.apiRequestContext(ctx, "GET", path, pageValues, nil,
+			&items)
+		if err != nil {
+			yield(zero, fmt.Errorf("%s error: %w", operation, err))
+			return
+		}
+
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+
+		if len(items) < perPage ||
+			(info.TotalPages > 0 && page >= info.TotalPages) {
+			return
+		}
+	}
+}
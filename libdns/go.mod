@@ -0,0 +1,11 @@
+module github.com/horgh/cloudflare/libdns
+
+go 1.23.4
+
+require (
+	github.com/horgh/cloudflare v0.0.0-00010101000000-000000000000
+	github.com/libdns/libdns v1.1.1
+)
+
+// Build against the client in this repository.
+replace github.com/horgh/cloudflare => ../
@@ -0,0 +1,401 @@
+// Package libdns provides a libdns provider backed by a Cloudflare client.
+//
+// This lets programs using libdns, such as Caddy, manage Cloudflare DNS
+// with this package:
+//
+//	provider := libdns.New(client)
+//	records, err := provider.GetRecords(ctx, "example.com.")
+//
+// Records are not proxied when we create them. When we change an existing
+// record we leave whether it is proxied alone. Changes are not atomic: if a
+// call fails part way, the changes made before the failure remain.
+package libdns
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/horgh/cloudflare"
+	ld "github.com/libdns/libdns"
+)
+
+// Provider implements the libdns interfaces.
+type Provider struct {
+	client cloudflare.Client
+}
+
+// Interfaces Provider implements.
+var (
+	_ ld.RecordGetter   = (*Provider)(nil)
+	_ ld.RecordAppender = (*Provider)(nil)
+	_ ld.RecordSetter   = (*Provider)(nil)
+	_ ld.RecordDeleter  = (*Provider)(nil)
+	_ ld.ZoneLister     = (*Provider)(nil)
+)
+
+// New creates a Provider using the client.
+//
+// Create the client with cloudflare.WithCache to avoid looking up the zone's
+// ID on every call.
+func New(client cloudflare.Client) *Provider {
+	return &Provider{client: client}
+}
+
+// GetRecords returns all the records in the zone.
+func (p *Provider) GetRecords(ctx context.Context,
+	zone string) ([]ld.Record, error) {
+	_, records, err := p.getRecords(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []ld.Record
+	for _, record := range records {
+		out = append(out, toLibdns(record, zone))
+	}
+
+	return out, nil
+}
+
+// AppendRecords creates the records in the zone.
+func (p *Provider) AppendRecords(ctx context.Context, zone string,
+	recs []ld.Record) ([]ld.Record, error) {
+	zoneID, err := p.zoneID(zone)
+	if err != nil {
+		return nil, err
+	}
+
+	var created []ld.Record
+	for _, rec := range recs {
+		if err := ctx.Err(); err != nil {
+			return created, err
+		}
+
+		record, err := fromLibdns(rec.RR(), zone, zoneID)
+		if err != nil {
+			return created, err
+		}
+
+		record, err = p.client.CreateDNSRecord(record)
+		if err != nil {
+			return created, err
+		}
+
+		created = append(created, toLibdns(record, zone))
+	}
+
+	return created, nil
+}
+
+// SetRecords makes the records with each name and type given be exactly
+// those given.
+//
+// Where we can we update existing records rather than deleting and creating
+// them.
+func (p *Provider) SetRecords(ctx context.Context, zone string,
+	recs []ld.Record) ([]ld.Record, error) {
+	zoneID, existing, err := p.getRecords(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group what we want and what there is by name and type.
+	var keys []string
+	wanted := map[string][]cloudflare.DNSRecord{}
+	for _, rec := range recs {
+		record, err := fromLibdns(rec.RR(), zone, zoneID)
+		if err != nil {
+			return nil, err
+		}
+		key := recordKey(record)
+		if _, ok := wanted[key]; !ok {
+			keys = append(keys, key)
+		}
+		wanted[key] = append(wanted[key], record)
+	}
+
+	have := map[string][]cloudflare.DNSRecord{}
+	for _, record := range existing {
+		key := recordKey(record)
+		if _, ok := wanted[key]; ok {
+			have[key] = append(have[key], record)
+		}
+	}
+
+	var set []ld.Record
+	for _, key := range keys {
+		records, err := p.setRRSet(ctx, wanted[key], have[key])
+		for _, record := range records {
+			set = append(set, toLibdns(record, zone))
+		}
+		if err != nil {
+			return set, err
+		}
+	}
+
+	return set, nil
+}
+
+// Make the records of one name and type be those wanted. have are the
+// existing records. We return the records we set.
+func (p *Provider) setRRSet(ctx context.Context, wanted,
+	have []cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+	var set []cloudflare.DNSRecord
+	var unmatched []cloudflare.DNSRecord
+
+	// Leave alone records that are already as we want them.
+	used := make([]bool, len(have))
+	var remaining []cloudflare.DNSRecord
+	for _, want := range wanted {
+		matched := false
+		for i, record := range have {
+			if !used[i] && sameData(record, want) && record.TTL == want.TTL {
+				set = append(set, record)
+				used[i] = true
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			remaining = append(remaining, want)
+		}
+	}
+
+	for i, record := range have {
+		if !used[i] {
+			unmatched = append(unmatched, record)
+		}
+	}
+
+	// Change records we don't want any more into the ones we do, and create
+	// any more we need.
+	for _, want := range remaining {
+		if err := ctx.Err(); err != nil {
+			return set, err
+		}
+
+		if len(unmatched) > 0 {
+			record := unmatched[0]
+			unmatched = unmatched[1:]
+
+			record.Content = want.Content
+			record.Priority = want.Priority
+			record.TTL = want.TTL
+
+			err := p.client.UpdateDNSRecord(record)
+			if err != nil {
+				return set, err
+			}
+			set = append(set, record)
+			continue
+		}
+
+		record, err := p.client.CreateDNSRecord(want)
+		if err != nil {
+			return set, err
+		}
+		set = append(set, record)
+	}
+
+	for _, record := range unmatched {
+		if err := ctx.Err(); err != nil {
+			return set, err
+		}
+
+		err := p.client.DeleteDNSRecord(record)
+		if err != nil {
+			return set, err
+		}
+	}
+
+	return set, nil
+}
+
+// DeleteRecords deletes the records that match those given. The type, TTL,
+// and data may be left empty to match any.
+func (p *Provider) DeleteRecords(ctx context.Context, zone string,
+	recs []ld.Record) ([]ld.Record, error) {
+	zoneID, existing, err := p.getRecords(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []ld.Record
+	for _, record := range existing {
+		if !matchesAny(record, recs, zone, zoneID) {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+
+		err := p.client.DeleteDNSRecord(record)
+		if err != nil {
+			return deleted, err
+		}
+
+		deleted = append(deleted, toLibdns(record, zone))
+	}
+
+	return deleted, nil
+}
+
+// ListZones lists the zones on the account.
+func (p *Provider) ListZones(ctx context.Context) ([]ld.Zone, error) {
+	var zones []ld.Zone
+	for zone, err := range p.client.Zones(ctx, cloudflare.ZoneListOptions{}) {
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, ld.Zone{Name: zone.Name + "."})
+	}
+
+	return zones, nil
+}
+
+// Find the zone's ID. zone may have a trailing dot.
+func (p *Provider) zoneID(zone string) (string, error) {
+	zoneID, err := p.client.ZoneIDByName(strings.TrimSuffix(zone, "."))
+	if err != nil {
+		return "", fmt.Errorf("unable to find zone: %w", err)
+	}
+	return zoneID, nil
+}
+
+// Find the zone's ID and list its records.
+func (p *Provider) getRecords(ctx context.Context,
+	zone string) (string, []cloudflare.DNSRecord, error) {
+	zoneID, err := p.zoneID(zone)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var records []cloudflare.DNSRecord
+	for record, err := range p.client.DNSRecords(ctx, zoneID,
+		cloudflare.DNSRecordListOptions{}) {
+		if err != nil {
+			return "", nil, err
+		}
+		records = append(records, record)
+	}
+
+	return zoneID, records, nil
+}
+
+// Whether the record matches any of the records given to DeleteRecords.
+func matchesAny(record cloudflare.DNSRecord, recs []ld.Record, zone,
+	zoneID string) bool {
+	for _, rec := range recs {
+		rr := rec.RR()
+		if !strings.EqualFold(absoluteName(rr.Name, zone), record.Name) {
+			continue
+		}
+		if rr.Type != "" && !strings.EqualFold(rr.Type, record.Type) {
+			continue
+		}
+		if rr.TTL != 0 && toTTL(rr.TTL) != record.TTL {
+			continue
+		}
+		if rr.Data != "" {
+			want, err := fromLibdns(rr, zone, zoneID)
+			if err != nil || !sameData(record, want) {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// Convert a record to its libdns form. We return the type specific struct if
+// libdns has one.
+func toLibdns(record cloudflare.DNSRecord, zone string) ld.Record {
+	data := record.Content
+	if record.Priority != nil {
+		data = fmt.Sprintf("%d %s", *record.Priority, record.Content)
+	}
+
+	var ttl time.Duration
+	if record.TTL != cloudflare.TTLAutomatic {
+		ttl = time.Duration(record.TTL) * time.Second
+	}
+
+	rr := ld.RR{
+		Name: ld.RelativeName(record.Name, zone),
+		TTL:  ttl,
+		Type: record.Type,
+		Data: data,
+	}
+
+	parsed, err := rr.Parse()
+	if err != nil {
+		return rr
+	}
+	return parsed
+}
+
+// Convert a libdns record to a record in the zone.
+func fromLibdns(rr ld.RR, zone, zoneID string) (cloudflare.DNSRecord, error) {
+	record := cloudflare.DNSRecord{
+		ZoneID:  zoneID,
+		Type:    strings.ToUpper(rr.Type),
+		Name:    absoluteName(rr.Name, zone),
+		Content: rr.Data,
+		TTL:     toTTL(rr.TTL),
+	}
+
+	// We hold the priority separately.
+	if record.Type == cloudflare.RecordTypeMX ||
+		record.Type == cloudflare.RecordTypeSRV {
+		fields := strings.SplitN(rr.Data, " ", 2)
+		if len(fields) != 2 {
+			return cloudflare.DNSRecord{}, fmt.Errorf("invalid %s data: %s",
+				record.Type, rr.Data)
+		}
+
+		priority, err := strconv.ParseUint(fields[0], 10, 16)
+		if err != nil {
+			return cloudflare.DNSRecord{}, fmt.Errorf("invalid %s priority: %s",
+				record.Type, fields[0])
+		}
+
+		p := uint16(priority)
+		record.Priority = &p
+		record.Content = fields[1]
+	}
+
+	return record, nil
+}
+
+// The record's name without a trailing dot, as the API gives it.
+func absoluteName(name, zone string) string {
+	return strings.ToLower(strings.TrimSuffix(ld.AbsoluteName(name, zone), "."))
+}
+
+// Convert a libdns TTL. 0 means automatic.
+func toTTL(ttl time.Duration) int {
+	if ttl <= 0 {
+		return cloudflare.TTLAutomatic
+	}
+	return int(ttl / time.Second)
+}
+
+// Group records by name and type.
+func recordKey(record cloudflare.DNSRecord) string {
+	return strings.ToLower(record.Name) + " " + record.Type
+}
+
+// Whether the records hold the same data.
+func sameData(a, b cloudflare.DNSRecord) bool {
+	if a.Content != b.Content {
+		return false
+	}
+	if a.Priority == nil || b.Priority == nil {
+		return a.Priority == nil && b.Priority == nil
+	}
+	return *a.Priority == *b.Priority
+}
@@ -0,0 +1,132 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// LogsReceivedOptions selects which logs LogsReceived() retrieves.
+type LogsReceivedOptions struct {
+	// Start and End bound the time range. Start is inclusive and End is
+	// exclusive. End must be at least a minute in the past.
+	Start time.Time
+	End   time.Time
+
+	// Fields lists the fields to include. See LogFields(). If empty, the API
+	// returns a default set.
+	Fields []string
+
+	// Sample is the fraction of logs to return, e.g. 0.1 for 10%. Zero to
+	// return all.
+	Sample float64
+
+	// Count limits how many logs to return. Zero for no limit.
+	Count int
+
+	// Timestamps is the format of timestamp fields: unix, unixnano, or
+	// rfc3339. Blank for the default (unixnano).
+	Timestamps string
+}
+
+// LogsReceived retrieves a zone's HTTP request logs (Logpull).
+//
+// The logs are newline delimited JSON, one log per line. We return a reader
+// of them rather than reading them all in to memory since there can be a
+// great many. The caller must close it. Use bufio.Scanner to read line by
+// line, though note you may need to raise its buffer size.
+//
+// The Client's timeout applies to reading the whole response, so large
+// ranges may need a longer one (see Client.Timeout).
+func (c Client) LogsReceived(zoneID string,
+	opts LogsReceivedOptions) (io.ReadCloser, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	if opts.Start.IsZero() || opts.End.IsZero() {
+		return nil, fmt.Errorf("you must provide a start and end time")
+	}
+
+	if !opts.Start.Before(opts.End) {
+		return nil, fmt.Errorf("start must be before end")
+	}
+
+	values := url.Values{}
+	values.Set("start", opts.Start.UTC().Format(time.RFC3339))
+	values.Set("end", opts.End.UTC().Format(time.RFC3339))
+	if len(opts.Fields) > 0 {
+		values.Set("fields", strings.Join(opts.Fields, ","))
+	}
+	if opts.Sample > 0 {
+		values.Set("sample", fmt.Sprintf("%g", opts.Sample))
+	}
+	if opts.Count > 0 {
+		values.Set("count", fmt.Sprintf("%d", opts.Count))
+	}
+	if len(opts.Timestamps) > 0 {
+		values.Set("timestamps", opts.Timestamps)
+	}
+
+	url := fmt.Sprintf("%szones/%s/logs/received?%s", c.endpoint(),
+		url.QueryEscape(zoneID), values.Encode())
+
+	resp, err := c.send("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("API request failure: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("logs received error: %s", resp.Status)
+		}
+
+		// Errors come in the usual envelope.
+		var response Response
+		err = json.Unmarshal(body, &response)
+		if err != nil || len(response.Errors) == 0 {
+			return nil, fmt.Errorf("logs received error: %s: %s", resp.Status,
+				body)
+		}
+
+		return nil, fmt.Errorf("logs received error: %w",
+			errorsToError(responseMeta{
+				StatusCode: resp.StatusCode,
+				RayID:      resp.Header.Get(rayHeader),
+			}, response.Errors))
+	}
+
+	return resp.Body, nil
+}
+
+// LogFields retrieves the fields available in a zone's HTTP request logs
+// along with their descriptions.
+func (c Client) LogFields(zoneID string) (map[string]string, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	url := fmt.Sprintf("%szones/%s/logs/received/fields", c.endpoint(),
+		url.QueryEscape(zoneID))
+
+	body, err := c.request("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("API request failure: %w", err)
+	}
+
+	// This endpoint responds with the fields directly rather than in the
+	// usual envelope.
+	var fields map[string]string
+	err = json.Unmarshal(body, &fields)
+	if err != nil {
+		return nil, fmt.Errorf("JSON decoding problem: %s: %s", err, body)
+	}
+
+	return fields, nil
+}
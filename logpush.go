@@ -0,0 +1,365 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// LogpushJob holds information about a Logpush job.
+type LogpushJob struct {
+	ID              int    `json:"id,omitempty"`
+	Dataset         string `json:"dataset"`
+	Name            string `json:"name,omitempty"`
+	Enabled         bool   `json:"enabled"`
+	DestinationConf string `json:"destination_conf"`
+	LogpullOptions  string `json:"logpull_options,omitempty"`
+	Frequency       string `json:"frequency,omitempty"`
+	LastComplete    string `json:"last_complete,omitempty"`
+	LastError       string `json:"last_error,omitempty"`
+	ErrorMessage    string `json:"error_message,omitempty"`
+
+	// OwnershipChallenge proves we own the destination when creating a job
+	// for a destination that needs it. See
+	// RequestLogpushOwnershipChallenge.
+	OwnershipChallenge string `json:"ownership_challenge,omitempty"`
+}
+
+// LogpushOwnershipChallenge describes where Cloudflare wrote an ownership
+// challenge.
+type LogpushOwnershipChallenge struct {
+	// Filename is the file in the destination holding the challenge.
+	Filename string `json:"filename"`
+	Valid    bool   `json:"valid"`
+	Message  string `json:"message"`
+}
+
+// Logpush jobs are either for a zone or for an account. If zoneID is given
+// the job is for the zone, otherwise for the account.
+func logpushPath(accountID, zoneID string) (string, error) {
+	if len(zoneID) > 0 {
+		return fmt.Sprintf("zones/%s/logpush", url.QueryEscape(zoneID)), nil
+	}
+
+	if len(accountID) > 0 {
+		return fmt.Sprintf("accounts/%s/logpush", url.QueryEscape(accountID)), nil
+	}
+
+	return "", fmt.Errorf("you must provide a zone ID or an account ID")
+}
+
+// ListLogpushJobs lists Logpush jobs.
+//
+// Give a zone ID for a zone's jobs or leave it blank and give an account ID
+// for the account's jobs.
+func (c Client) ListLogpushJobs(accountID, zoneID string) ([]LogpushJob,
+	error) {
+	path, err := logpushPath(accountID, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []LogpushJob
+	err = c.apiRequest("GET", path+"/jobs", nil, nil, &jobs)
+	if err != nil {
+		return nil, fmt.Errorf("list Logpush jobs error: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// GetLogpushJob retrieves a Logpush job.
+func (c Client) GetLogpushJob(accountID, zoneID string, jobID int) (LogpushJob,
+	error) {
+	path, err := logpushPath(accountID, zoneID)
+	if err != nil {
+		return LogpushJob{}, err
+	}
+
+	var job LogpushJob
+	err = c.apiRequest("GET", fmt.Sprintf("%s/jobs/%d", path, jobID), nil, nil,
+		&job)
+	if err != nil {
+		return LogpushJob{}, fmt.Errorf("get Logpush job error: %w", err)
+	}
+
+	return job, nil
+}
+
+// CreateLogpushJob creates a Logpush job. We return the job as created.
+func (c Client) CreateLogpushJob(accountID, zoneID string,
+	job LogpushJob) (LogpushJob, error) {
+	path, err := logpushPath(accountID, zoneID)
+	if err != nil {
+		return LogpushJob{}, err
+	}
+
+	var created LogpushJob
+	err = c.apiRequest("POST", path+"/jobs", nil, job, &created)
+	if err != nil {
+		return LogpushJob{}, fmt.Errorf("create Logpush job error: %w", err)
+	}
+
+	return created, nil
+}
+
+// DeleteLogpushJob deletes a Logpush job.
+func (c Client) DeleteLogpushJob(accountID, zoneID string, jobID int) error {
+	path, err := logpushPath(accountID, zoneID)
+	if err != nil {
+		return err
+	}
+
+	err = c.apiRequest("DELETE", fmt.Sprintf("%s/jobs/%d", path, jobID), nil,
+		nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete Logpush job error: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateLogpushDestination asks Cloudflare to check that it can write to
+// the destination. If it can't we return an error describing why.
+func (c Client) ValidateLogpushDestination(accountID, zoneID,
+	destinationConf string) error {
+	path, err := logpushPath(accountID, zoneID)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]string{"destination_conf": destinationConf}
+
+	var result struct {
+		Valid   bool   `json:"valid"`
+		Message string `json:"message"`
+	}
+	err = c.apiRequest("POST", path+"/validate/destination", nil, payload,
+		&result)
+	if err != nil {
+		return fmt.Errorf("validate Logpush destination error: %w", err)
+	}
+
+	if !result.Valid {
+		return fmt.Errorf("invalid Logpush destination: %s", result.Message)
+	}
+
+	return nil
+}
+
+// RequestLogpushOwnershipChallenge has Cloudflare write an ownership
+// challenge to the destination. Most destinations (though not R2) need one
+// to create a job: read the file named in the result from the destination
+// and give its contents as the job's OwnershipChallenge.
+func (c Client) RequestLogpushOwnershipChallenge(accountID, zoneID,
+	destinationConf string) (LogpushOwnershipChallenge, error) {
+	path, err := logpushPath(accountID, zoneID)
+	if err != nil {
+		return LogpushOwnershipChallenge{}, err
+	}
+
+	payload := map[string]string{"destination_conf": destinationConf}
+
+	var challenge LogpushOwnershipChallenge
+	err = c.apiRequest("POST", path+"/ownership", nil, payload, &challenge)
+	if err != nil {
+		return LogpushOwnershipChallenge{},
+			fmt.Errorf("request Logpush ownership challenge error: %w", err)
+	}
+
+	if !challenge.Valid {
+		return LogpushOwnershipChallenge{}, fmt.Errorf(
+			"unable to write Logpush ownership challenge: %s", challenge.Message)
+	}
+
+	return challenge, nil
+}
+
+// ValidateLogpushOwnershipChallenge checks an ownership challenge read from
+// the destination before using it to create a job.
+func (c Client) ValidateLogpushOwnershipChallenge(accountID, zoneID,
+	destinationConf, ownershipChallenge string) error {
+	path, err := logpushPath(accountID, zoneID)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]string{
+		"destination_conf":    destinationConf,
+		"ownership_challenge": ownershipChallenge,
+	}
+
+	var result struct {
+		Valid bool `json:"valid"`
+	}
+	err = c.apiRequest("POST", path+"/ownership/validate", nil, payload,
+		&result)
+	if err != nil {
+		return fmt.Errorf("validate Logpush ownership challenge error: %w", err)
+	}
+
+	if !result.Valid {
+		return fmt.Errorf("invalid Logpush ownership challenge")
+	}
+
+	return nil
+}
+
+// LogpushR2Setup holds the parameters for SetupLogpushToR2.
+type LogpushR2Setup struct {
+	// AccountID is the account owning the bucket. If it is blank we use the
+	// client's default account (see DefaultAccountID).
+	AccountID string
+
+	// ZoneID is the zone to push logs for. Leave it blank for an account level
+	// dataset.
+	ZoneID string
+
+	// Dataset is the log dataset, e.g. http_requests.
+	Dataset string
+
+	// Name optionally names the job.
+	Name string
+
+	// Bucket is the R2 bucket to write to. We create it if it doesn't exist.
+	Bucket string
+
+	// Path is the path within the bucket. It may contain {DATE}. If blank we
+	// use {DATE}.
+	Path string
+
+	// AccessKeyID and SecretAccessKey are R2 API credentials with write
+	// access to the bucket. Logpush uses these to write.
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// LogpullOptions selects fields and formatting, e.g.
+	// fields=ClientIP,EdgeStartTimestamp&timestamps=rfc3339. Blank for the
+	// default.
+	LogpullOptions string
+
+	// VerifyTimeout is how long to wait for the first delivery. Zero to not
+	// wait.
+	VerifyTimeout time.Duration
+}
+
+// How often we check whether a Logpush job has delivered.
+const logpushPollInterval = 15 * time.Second
+
+// SetupLogpushToR2 sets up pushing logs to an R2 bucket.
+//
+// It creates the bucket if necessary, builds the destination configuration,
+// has Cloudflare validate that it can write there, and creates an enabled
+// job. If VerifyTimeout is set, it then waits for the job to deliver a batch
+// and fails if it does not in time or reports an error.
+//
+// If a step after creating the job fails we return the job along with the
+// error so the caller can inspect or delete it.
+//
+// R2 destinations carry their credentials, so Logpush does not need an
+// ownership challenge for them and we don't request one. For other
+// destinations use RequestLogpushOwnershipChallenge and CreateLogpushJob.
+func (c Client) SetupLogpushToR2(setup LogpushR2Setup) (LogpushJob, error) {
+	accountID, err := c.account(setup.AccountID)
+	if err != nil {
+		return LogpushJob{}, err
+	}
+	setup.AccountID = accountID
+	if len(setup.AccountID) == 0 {
+		return LogpushJob{}, fmt.Errorf("you must provide an account ID")
+	}
+	if len(setup.Dataset) == 0 {
+		return LogpushJob{}, fmt.Errorf("you must provide a dataset")
+	}
+	if len(setup.Bucket) == 0 {
+		return LogpushJob{}, fmt.Errorf("you must provide a bucket")
+	}
+	if len(setup.AccessKeyID) == 0 || len(setup.SecretAccessKey) == 0 {
+		return LogpushJob{}, fmt.Errorf("you must provide R2 credentials")
+	}
+
+	buckets, err := c.ListR2Buckets(setup.AccountID)
+	if err != nil {
+		return LogpushJob{}, err
+	}
+
+	found := false
+	for _, bucket := range buckets {
+		if bucket.Name == setup.Bucket {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		_, err := c.CreateR2Bucket(setup.AccountID, setup.Bucket)
+		if err != nil {
+			return LogpushJob{}, err
+		}
+	}
+
+	destinationConf := r2DestinationConf(setup)
+
+	err = c.ValidateLogpushDestination(setup.AccountID, setup.ZoneID,
+		destinationConf)
+	if err != nil {
+		return LogpushJob{}, err
+	}
+
+	job, err := c.CreateLogpushJob(setup.AccountID, setup.ZoneID, LogpushJob{
+		Dataset:         setup.Dataset,
+		Name:            setup.Name,
+		Enabled:         true,
+		DestinationConf: destinationConf,
+		LogpullOptions:  setup.LogpullOptions,
+	})
+	if err != nil {
+		return LogpushJob{}, err
+	}
+
+	if setup.VerifyTimeout <= 0 {
+		return job, nil
+	}
+
+	deadline := time.Now().Add(setup.VerifyTimeout)
+
+	for {
+		current, err := c.GetLogpushJob(setup.AccountID, setup.ZoneID, job.ID)
+		if err != nil {
+			return job, err
+		}
+
+		if len(current.ErrorMessage) > 0 {
+			return current, fmt.Errorf("Logpush job failed to deliver: %s",
+				current.ErrorMessage)
+		}
+
+		if len(current.LastComplete) > 0 {
+			return current, nil
+		}
+
+		if time.Now().Add(logpushPollInterval).After(deadline) {
+			return current, fmt.Errorf(
+				"Logpush job did not deliver within %s. It may have had no logs to send",
+				setup.VerifyTimeout)
+		}
+
+		time.Sleep(logpushPollInterval)
+	}
+}
+
+// Build an R2 destination. It looks like:
+// r2://bucket/path?account-id=...&access-key-id=...&secret-access-key=...
+func r2DestinationConf(setup LogpushR2Setup) string {
+	path := setup.Path
+	if len(path) == 0 {
+		path = "{DATE}"
+	}
+
+	values := url.Values{}
+	values.Set("account-id", setup.AccountID)
+	values.Set("access-key-id", setup.AccessKeyID)
+	values.Set("secret-access-key", setup.SecretAccessKey)
+
+	return fmt.Sprintf("r2://%s/%s?%s", setup.Bucket, path, values.Encode())
+}
@@ -0,0 +1,70 @@
+package cloudflare
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Metrics receives measurements of the API requests a Client makes.
+//
+// Set Client.Metrics to instrument a client. The prometheus sub-package
+// provides an implementation exporting Prometheus metrics.
+//
+// Implementations must be safe for concurrent use. They may also implement
+// RetryMetrics to count retries.
+type Metrics interface {
+	// ObserveRequest is called after each API request.
+	//
+	// endpoint is the method and path with identifiers replaced by :id, e.g.
+	// "GET zones/:id/dns_records". status is the HTTP status code, or 0 if
+	// there was no response.
+	ObserveRequest(endpoint string, status int, duration time.Duration)
+}
+
+// Describe a request URL as an endpoint for metrics. We replace identifiers so
+// that there is a small number of distinct endpoints. base is the API's base
+// URL, which we leave out.
+func metricsEndpoint(method, base, rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return method + " unknown"
+	}
+
+	basePath := "/"
+	b, err := url.Parse(base)
+	if err == nil {
+		basePath = b.Path
+	}
+
+	path := strings.TrimPrefix(u.Path, basePath)
+
+	pieces := strings.Split(path, "/")
+	for i, piece := range pieces {
+		if isIdentifier(piece) {
+			pieces[i] = ":id"
+		}
+	}
+
+	return method + " " + strings.Join(pieces, "/")
+}
+
+// Identifiers are either 32 hex characters or numbers.
+func isIdentifier(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+
+	allDigits := true
+	allHex := true
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			allDigits = false
+		}
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			allHex = false
+		}
+	}
+
+	return allDigits || (allHex && len(s) == 32)
+}
@@ -0,0 +1,118 @@
+package cloudflare
+
+import "fmt"
+
+// NetworkSettings holds a zone's protocol related settings.
+type NetworkSettings struct {
+	// Brotli is whether to compress responses with Brotli for clients
+	// supporting it.
+	Brotli bool
+
+	HTTP2 bool
+	HTTP3 bool
+
+	// ZeroRTT is whether to accept TLS 1.3 0-RTT (early data) from clients
+	// resuming a connection.
+	ZeroRTT bool
+
+	WebSockets bool
+
+	// IPGeolocation is whether to add the CF-IPCountry header to requests to
+	// the origin.
+	IPGeolocation bool
+
+	// OpportunisticEncryption is whether to advertise HTTPS to HTTP clients
+	// with the Alt-Svc header.
+	OpportunisticEncryption bool
+}
+
+// NetworkSettingsUpdate holds changes to a zone's network settings. Fields
+// left nil are unchanged.
+type NetworkSettingsUpdate struct {
+	Brotli                  *bool
+	HTTP2                   *bool
+	HTTP3                   *bool
+	ZeroRTT                 *bool
+	WebSockets              *bool
+	IPGeolocation           *bool
+	OpportunisticEncryption *bool
+}
+
+// GetNetworkSettings retrieves a zone's network settings in one request.
+func (c Client) GetNetworkSettings(zoneID string) (NetworkSettings, error) {
+	settings, err := c.ListZoneSettings(zoneID)
+	if err != nil {
+		return NetworkSettings{}, err
+	}
+
+	var ns NetworkSettings
+	fields := ns.fields()
+	for _, setting := range settings {
+		field, ok := fields[setting.ID]
+		if !ok {
+			continue
+		}
+
+		*field, err = onOffValue(setting.Value)
+		if err != nil {
+			return NetworkSettings{}, fmt.Errorf(
+				"invalid value for setting %s: %w", setting.ID, err)
+		}
+	}
+
+	return ns, nil
+}
+
+// UpdateNetworkSettings changes a zone's network settings in one request. We
+// return them as updated.
+//
+// Some settings may not be changed on every plan (e.g. HTTP/2 is always on
+// for Free zones). If any change fails, the API makes none of them.
+func (c Client) UpdateNetworkSettings(zoneID string,
+	update NetworkSettingsUpdate) (NetworkSettings, error) {
+	if len(zoneID) == 0 {
+		return NetworkSettings{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	changes := []struct {
+		id    string
+		value *bool
+	}{
+		{SettingBrotli, update.Brotli},
+		{SettingHTTP2, update.HTTP2},
+		{SettingHTTP3, update.HTTP3},
+		{SettingZeroRTT, update.ZeroRTT},
+		{SettingWebSockets, update.WebSockets},
+		{SettingIPGeolocation, update.IPGeolocation},
+		{SettingOpportunisticEncryption, update.OpportunisticEncryption},
+	}
+
+	items := map[string]interface{}{}
+	for _, change := range changes {
+		if change.value != nil {
+			items[change.id] = onOff(*change.value)
+		}
+	}
+
+	if len(items) > 0 {
+		_, err := c.UpdateZoneSettings(zoneID, items)
+		if err != nil {
+			return NetworkSettings{}, err
+		}
+	}
+
+	return c.GetNetworkSettings(zoneID)
+}
+
+// fields maps each setting's name to the field holding it.
+func (n *NetworkSettings) fields() map[string]*bool {
+	return map[string]*bool{
+		SettingBrotli:                  &n.Brotli,
+		SettingHTTP2:                   &n.HTTP2,
+		SettingHTTP3:                   &n.HTTP3,
+		SettingZeroRTT:                 &n.ZeroRTT,
+		SettingWebSockets:              &n.WebSockets,
+		SettingIPGeolocation:           &n.IPGeolocation,
+		SettingOpportunisticEncryption: &n.OpportunisticEncryption,
+	}
+}
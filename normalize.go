@@ -0,0 +1,104 @@
+package cloudflare
+
+import (
+	"net"
+	"strconv"
+	"strings"
+)
+
+// NormalizeName lowercases a DNS name and removes surrounding space and any
+// trailing dot, which is how the API gives names.
+func NormalizeName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+}
+
+// FQDN normalizes a DNS name and adds a trailing dot, as in zone files.
+func FQDN(name string) string {
+	name = NormalizeName(name)
+	if len(name) == 0 {
+		return "."
+	}
+	return name + "."
+}
+
+// QualifyName turns a name relative to the zone into a fully qualified one,
+// normalized. "@" and "" are the zone itself. Names already in the zone are
+// left as they are:
+//
+//	QualifyName("www", "example.com")             // www.example.com
+//	QualifyName("WWW.Example.com.", "example.com") // www.example.com
+func QualifyName(name, zone string) string {
+	name = NormalizeName(name)
+	zone = NormalizeName(zone)
+
+	if name == "@" || name == "" {
+		return zone
+	}
+	if name == zone || strings.HasSuffix(name, "."+zone) {
+		return name
+	}
+	return name + "." + zone
+}
+
+// NormalizeTTL maps a TTL of 0 (unset) to TTLAutomatic. The API has no TTL
+// 0.
+func NormalizeTTL(ttl int) int {
+	if ttl <= 0 {
+		return TTLAutomatic
+	}
+	return ttl
+}
+
+// FormatTTL describes a TTL for people: "automatic" or a number of seconds.
+func FormatTTL(ttl int) string {
+	if NormalizeTTL(ttl) == TTLAutomatic {
+		return "automatic"
+	}
+	return strconv.Itoa(ttl)
+}
+
+// ContentEqual is whether two records of the same type hold the same data.
+//
+// Content is compared exactly except for types holding hostnames, which we
+// compare as names, and IP addresses, which we compare as addresses (so
+// differently written IPv6 addresses match). Priorities (of MX and SRV
+// records) must match too.
+func ContentEqual(a, b DNSRecord) bool {
+	if recordPriority(a) != recordPriority(b) {
+		return false
+	}
+
+	switch strings.ToUpper(a.Type) {
+	case RecordTypeCNAME, RecordTypeNS, RecordTypePTR, RecordTypeMX:
+		return NormalizeName(a.Content) == NormalizeName(b.Content)
+	case RecordTypeA, RecordTypeAAAA:
+		ipA, ipB := net.ParseIP(a.Content), net.ParseIP(b.Content)
+		if ipA == nil || ipB == nil {
+			return a.Content == b.Content
+		}
+		return ipA.Equal(ipB)
+	default:
+		return a.Content == b.Content
+	}
+}
+
+// RecordsEqual is whether two records are the same apart from how they are
+// written and what the API sets: their names, types, content, TTLs, and
+// whether they are proxied match. IDs, comments, and timestamps are not
+// compared.
+func RecordsEqual(a, b DNSRecord) bool {
+	return NormalizeName(a.Name) == NormalizeName(b.Name) &&
+		strings.EqualFold(a.Type, b.Type) &&
+		ContentEqual(a, b) &&
+		NormalizeTTL(a.TTL) == NormalizeTTL(b.TTL) &&
+		a.Proxied == b.Proxied
+}
+
+// A record's priority. Records without one have priority -1 so they differ
+// from records with priority 0.
+func recordPriority(record DNSRecord) int {
+	if record.Priority == nil {
+		return -1
+	}
+	return int(*record.Priority)
+}
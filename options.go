@@ -0,0 +1,381 @@
+package cloudflare
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Option configures a Client. Pass options to New.
+type Option func(*Client) error
+
+// How long requests may take by default.
+const defaultTimeout = 60 * time.Second
+
+// New creates an API client configured by the options.
+//
+// For example:
+//
+//	client, err := cloudflare.New(
+//		cloudflare.WithToken(token),
+//		cloudflare.WithRetry(3, time.Second),
+//	)
+//
+// Adding options does not change New's signature, so prefer it to
+// NewClient.
+func New(opts ...Option) (Client, error) {
+	c := Client{
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		cache:      newResponseCache(),
+		lastRay:    &rayRecorder{},
+
+		providedTokens: &tokenRecorder{},
+	}
+
+	for _, opt := range opts {
+		err := opt(&c)
+		if err != nil {
+			return Client{}, err
+		}
+	}
+
+	err := c.applyHTTPSettings()
+	if err != nil {
+		return Client{}, err
+	}
+
+	if len(c.Token) > 0 && (len(c.Key) > 0 || len(c.Email) > 0) {
+		return Client{}, fmt.Errorf(
+			"give either an API token or an API key and email, not both")
+	}
+
+	if c.Credentials != nil &&
+		(len(c.Token) > 0 || len(c.Key) > 0 || len(c.Email) > 0) {
+		return Client{}, fmt.Errorf(
+			"WithCredentialsProvider may not be combined with WithToken or " +
+				"WithKeyEmail")
+	}
+
+	return c, nil
+}
+
+// WithToken authenticates using an API token. API tokens may be limited in
+// what they can do, so prefer them to API keys.
+func WithToken(token string) Option {
+	return func(c *Client) error {
+		if len(token) == 0 {
+			return fmt.Errorf("token may not be blank")
+		}
+		c.Token = token
+		return nil
+	}
+}
+
+// WithKeyEmail authenticates using an API key and the email on the account.
+func WithKeyEmail(key, email string) Option {
+	return func(c *Client) error {
+		if len(key) == 0 || len(email) == 0 {
+			return fmt.Errorf("key and email may not be blank")
+		}
+		c.Key = key
+		c.Email = email
+		return nil
+	}
+}
+
+// WithHTTPClient uses the given HTTP client to make requests. Options such as
+// WithTimeout and WithProxy apply to a copy of it, whatever their order.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return fmt.Errorf("HTTP client may not be nil")
+		}
+		c.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithBaseURL sends requests somewhere other than the Cloudflare API, such as
+// to a proxy or a test server. It should end with the API version, e.g.
+// https://api.example.com/client/v4/.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) error {
+		if !strings.HasPrefix(baseURL, "https://") &&
+			!strings.HasPrefix(baseURL, "http://") {
+			return fmt.Errorf("base URL must be an HTTP(S) URL: %s", baseURL)
+		}
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		c.baseURL = baseURL
+		return nil
+	}
+}
+
+// WithTimeout sets how long a request may take, including connecting and
+// reading its response. The default is 60 seconds. 0 means no limit.
+// Client.Timeout overrides this for individual calls.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout < 0 {
+			return fmt.Errorf("timeout may not be negative")
+		}
+
+		c.pendingHTTP().timeout = &timeout
+		return nil
+	}
+}
+
+// WithConnectTimeout sets how long connecting to the API may take, including
+// the TLS handshake. This is separate from WithTimeout, which limits the whole
+// request. By default we use net/http's connect timeouts.
+func WithConnectTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout < 0 {
+			return fmt.Errorf("timeout may not be negative")
+		}
+
+		c.changeTransportLater(func(transport *http.Transport) error {
+			transport.DialContext = (&net.Dialer{
+				Timeout:   timeout,
+				KeepAlive: 30 * time.Second,
+			}).DialContext
+			transport.TLSHandshakeTimeout = timeout
+			return nil
+		})
+		return nil
+	}
+}
+
+// WithProxy sends requests through an HTTP(S) proxy, e.g.
+// http://proxy.example.com:3128. By default we use the proxy given by the
+// HTTPS_PROXY environment variable, if any.
+func WithProxy(proxyURL string) Option {
+	return func(c *Client) error {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5" {
+			return fmt.Errorf("proxy URL must be http, https, or socks5: %s",
+				proxyURL)
+		}
+
+		c.changeTransportLater(func(transport *http.Transport) error {
+			transport.Proxy = http.ProxyURL(u)
+			return nil
+		})
+		return nil
+	}
+}
+
+// WithRootCAs trusts the certificates in the pool rather than the system's
+// when connecting. This is for when a TLS inspecting proxy sits between us and
+// the API.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(c *Client) error {
+		if pool == nil {
+			return fmt.Errorf("certificate pool may not be nil")
+		}
+
+		c.changeTransportLater(func(transport *http.Transport) error {
+			if transport.TLSClientConfig == nil {
+				transport.TLSClientConfig = &tls.Config{}
+			}
+			transport.TLSClientConfig.RootCAs = pool
+			return nil
+		})
+		return nil
+	}
+}
+
+// WithRootCAFile is WithRootCAs with the PEM encoded certificates in the
+// file. We trust them as well as the system's certificates.
+func WithRootCAFile(file string) Option {
+	return func(c *Client) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("unable to read CA file: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(data) {
+			return fmt.Errorf("no certificates found in %s", file)
+		}
+
+		return WithRootCAs(pool)(c)
+	}
+}
+
+// WithTLSConfig uses the TLS configuration when connecting, e.g. to present
+// a client certificate. It replaces any given by WithRootCAs, so give it
+// first if you give both.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(c *Client) error {
+		if config == nil {
+			return fmt.Errorf("TLS config may not be nil")
+		}
+
+		c.changeTransportLater(func(transport *http.Transport) error {
+			transport.TLSClientConfig = config.Clone()
+			return nil
+		})
+		return nil
+	}
+}
+
+// transport returns the client's transport for connections we make
+// ourselves, such as WebSockets. It is nil if the client uses the default
+// transport or a custom one that is not an *http.Transport.
+func (c Client) transport() *http.Transport {
+	if c.httpClient == nil {
+		return nil
+	}
+	t, _ := c.httpClient.Transport.(*http.Transport)
+	return t
+}
+
+// handshakeTimeout is how long setting up a connection we make ourselves
+// may take: the client's timeout, or if it has none, defaultTimeout.
+func (c Client) handshakeTimeout() time.Duration {
+	if c.httpClient == nil || c.httpClient.Timeout <= 0 {
+		return defaultTimeout
+	}
+	return c.httpClient.Timeout
+}
+
+// httpSettings are changes to the HTTP client that options ask for. New
+// makes them after every option has run, so they apply to a client given by
+// WithHTTPClient no matter the order of the options.
+type httpSettings struct {
+	timeout *time.Duration
+
+	// transportChanges are made in the order options gave them.
+	transportChanges []func(*http.Transport) error
+}
+
+func (c *Client) pendingHTTP() *httpSettings {
+	if c.httpSettings == nil {
+		c.httpSettings = &httpSettings{}
+	}
+	return c.httpSettings
+}
+
+func (c *Client) changeTransportLater(change func(*http.Transport) error) {
+	settings := c.pendingHTTP()
+	settings.transportChanges = append(settings.transportChanges, change)
+}
+
+// applyHTTPSettings makes the changes options asked for.
+func (c *Client) applyHTTPSettings() error {
+	settings := c.httpSettings
+	c.httpSettings = nil
+	if settings == nil {
+		return nil
+	}
+
+	for _, change := range settings.transportChanges {
+		err := c.changeTransport(change)
+		if err != nil {
+			return err
+		}
+	}
+
+	if settings.timeout != nil {
+		// Copy so we don't change a client given by WithHTTPClient.
+		httpClient := *c.httpClient
+		httpClient.Timeout = *settings.timeout
+		c.httpClient = &httpClient
+	}
+
+	return nil
+}
+
+// changeTransport changes a copy of the client's transport. We copy so we
+// don't change a client given by WithHTTPClient or http.DefaultTransport.
+func (c *Client) changeTransport(change func(*http.Transport) error) error {
+	var transport *http.Transport
+	switch t := c.httpClient.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		return fmt.Errorf("unable to change a custom HTTP transport")
+	}
+
+	err := change(transport)
+	if err != nil {
+		return err
+	}
+
+	httpClient := *c.httpClient
+	httpClient.Transport = transport
+	c.httpClient = &httpClient
+	return nil
+}
+
+// Timeout returns a copy of the client whose requests may take up to timeout
+// rather than the client's usual timeout. Use it for a call you expect to be
+// slow, such as listing every record of a large zone:
+//
+//	records, err := client.Timeout(10*time.Minute).ListAllDNSRecords(zoneID)
+//
+// A context with a deadline (with the Context variants of calls) also limits
+// a request. Whichever is shorter applies.
+func (c Client) Timeout(timeout time.Duration) Client {
+	httpClient := http.Client{}
+	if c.httpClient != nil {
+		httpClient = *c.httpClient
+	}
+	httpClient.Timeout = timeout
+	c.httpClient = &httpClient
+	return c
+}
+
+// WithRetry retries requests that fail in ways that may be temporary.
+//
+// We retry up to maxRetries times, waiting backoff before the first retry and
+// doubling the wait each time after. If the API says how long to wait (with
+// Retry-After) we wait that long instead.
+//
+// Requests that were rate limited are always retried. Requests that failed
+// with a server error or without a response are retried only if they are
+// safe to repeat (GET, HEAD, PUT, and DELETE).
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) error {
+		if maxRetries < 0 || backoff < 0 {
+			return fmt.Errorf("retries and backoff may not be negative")
+		}
+		c.retry = retryPolicy{maxRetries: maxRetries, backoff: backoff}
+		return nil
+	}
+}
+
+// WithLogger sends debug output to the logger rather than the standard
+// logger.
+func WithLogger(logger *log.Logger) Option {
+	return func(c *Client) error {
+		c.logger = logger
+		return nil
+	}
+}
+
+// WithDebug turns on debug output.
+func WithDebug(debug bool) Option {
+	return func(c *Client) error {
+		c.Debug = debug
+		return nil
+	}
+}
@@ -0,0 +1,158 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// PageShieldSettings holds a zone's Page Shield settings. Page Shield
+// monitors the scripts pages load and the connections they make.
+type PageShieldSettings struct {
+	Enabled bool `json:"enabled"`
+
+	// UseCloudflareReportingEndpoint is whether browsers report to
+	// Cloudflare's endpoint rather than the zone's.
+	UseCloudflareReportingEndpoint bool `json:"use_cloudflare_reporting_endpoint"`
+
+	// UseConnectionURLPath is whether to record the path of connections'
+	// URLs as well as their hosts.
+	UseConnectionURLPath bool `json:"use_connection_url_path"`
+
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// PageShieldResource is a script or connection Page Shield found.
+type PageShieldResource struct {
+	ID           string   `json:"id"`
+	URL          string   `json:"url"`
+	Host         string   `json:"host"`
+	AddedAt      string   `json:"added_at"`
+	FirstSeenAt  string   `json:"first_seen_at"`
+	LastSeenAt   string   `json:"last_seen_at"`
+	FirstPageURL string   `json:"first_page_url"`
+	PageURLs     []string `json:"page_urls"`
+
+	// Status is active or infrequent.
+	Status string `json:"status"`
+
+	// MaliciousDomainCategories and the like are set if Page Shield thinks
+	// the resource is malicious.
+	DomainReportedMalicious   bool     `json:"domain_reported_malicious"`
+	URLReportedMalicious      bool     `json:"url_reported_malicious"`
+	MaliciousDomainCategories []string `json:"malicious_domain_categories"`
+	MaliciousURLCategories    []string `json:"malicious_url_categories"`
+
+	// Hash and JSIntegrityScore are only set for scripts. A lower score is
+	// more likely malicious.
+	Hash             string `json:"hash,omitempty"`
+	JSIntegrityScore int    `json:"js_integrity_score,omitempty"`
+}
+
+// PageShieldListOptions filters the scripts and connections we list.
+type PageShieldListOptions struct {
+	// Status is active or infrequent, or blank for both.
+	Status string
+
+	// Hosts limits the results to these hosts.
+	Hosts []string
+
+	// URLContains limits the results to URLs containing it.
+	URLContains string
+
+	// PageURL limits the results to those found on this page.
+	PageURL string
+}
+
+// GetPageShieldSettings retrieves a zone's Page Shield settings.
+func (c Client) GetPageShieldSettings(zoneID string) (PageShieldSettings,
+	error) {
+	if len(zoneID) == 0 {
+		return PageShieldSettings{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var settings PageShieldSettings
+	err := c.apiRequest("GET", zonePrefix(zoneID)+"/page_shield", nil, nil,
+		&settings)
+	if err != nil {
+		return PageShieldSettings{}, fmt.Errorf(
+			"get Page Shield settings error: %w", err)
+	}
+
+	return settings, nil
+}
+
+// UpdatePageShieldSettings changes a zone's Page Shield settings. We return
+// them as updated.
+func (c Client) UpdatePageShieldSettings(zoneID string,
+	settings PageShieldSettings) (PageShieldSettings, error) {
+	if len(zoneID) == 0 {
+		return PageShieldSettings{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	settings.UpdatedAt = ""
+
+	var updated PageShieldSettings
+	err := c.apiRequest("PUT", zonePrefix(zoneID)+"/page_shield", nil, settings,
+		&updated)
+	if err != nil {
+		return PageShieldSettings{}, fmt.Errorf(
+			"update Page Shield settings error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// ListPageShieldScripts lists the scripts Page Shield found on a zone's
+// pages.
+func (c Client) ListPageShieldScripts(zoneID string,
+	opts PageShieldListOptions) ([]PageShieldResource, error) {
+	return c.listPageShield(zoneID, "scripts", opts)
+}
+
+// ListPageShieldConnections lists the connections Page Shield found a zone's
+// pages making.
+func (c Client) ListPageShieldConnections(zoneID string,
+	opts PageShieldListOptions) ([]PageShieldResource, error) {
+	return c.listPageShield(zoneID, "connections", opts)
+}
+
+func (c Client) listPageShield(zoneID, kind string,
+	opts PageShieldListOptions) ([]PageShieldResource, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	values := url.Values{}
+	if len(opts.Status) > 0 {
+		values.Set("status", opts.Status)
+	}
+	if len(opts.Hosts) > 0 {
+		values.Set("hosts", strings.Join(opts.Hosts, ","))
+	}
+	if len(opts.URLContains) > 0 {
+		values.Set("url_contains", opts.URLContains)
+	}
+	if len(opts.PageURL) > 0 {
+		values.Set("page_url", opts.PageURL)
+	}
+
+	var resources []PageShieldResource
+	var err error
+	paginate(context.Background(), c, zonePrefix(zoneID)+"/page_shield/"+kind,
+		values, 100, "list Page Shield "+kind,
+		func(resource PageShieldResource, e error) bool {
+			if e != nil {
+				err = e
+				return false
+			}
+			resources = append(resources, resource)
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
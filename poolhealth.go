@@ -0,0 +1,183 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PoolHealth holds the health of a load balancer pool as seen from each
+// region Cloudflare checks it from.
+type PoolHealth struct {
+	PoolID string `json:"pool_id"`
+
+	// PopHealth is keyed by region, e.g. "Western North America".
+	PopHealth map[string]RegionPoolHealth `json:"pop_health"`
+}
+
+// RegionPoolHealth is a pool's health as seen from one region.
+type RegionPoolHealth struct {
+	Healthy bool `json:"healthy"`
+
+	// Origins holds each origin's health keyed by its address. The API gives
+	// a list of single entry maps.
+	Origins []map[string]OriginHealth `json:"origins"`
+}
+
+// OriginHealth is an origin's health as seen from one region.
+type OriginHealth struct {
+	Healthy       bool   `json:"healthy"`
+	RTT           string `json:"rtt"`
+	FailureReason string `json:"failure_reason"`
+	ResponseCode  int    `json:"response_code"`
+}
+
+// Healthy reports whether the pool and all of its origins are healthy in
+// every region.
+func (h PoolHealth) Healthy() bool {
+	return len(h.Unhealthy()) == 0 && len(h.PopHealth) > 0
+}
+
+// Unhealthy describes what is unhealthy in the pool, e.g. "origin 192.0.2.1
+// in Western Europe: TCP connection failed". It is empty if all is healthy.
+func (h PoolHealth) Unhealthy() []string {
+	var problems []string
+
+	for region, health := range h.PopHealth {
+		unhealthyOrigin := false
+		for _, origins := range health.Origins {
+			for address, origin := range origins {
+				if origin.Healthy {
+					continue
+				}
+				unhealthyOrigin = true
+				problems = append(problems, fmt.Sprintf("origin %s in %s: %s",
+					address, region, origin.FailureReason))
+			}
+		}
+
+		if !health.Healthy && !unhealthyOrigin {
+			problems = append(problems, fmt.Sprintf("pool in %s", region))
+		}
+	}
+
+	sort.Strings(problems)
+	return problems
+}
+
+// PoolHealthEvent is a change in the health of pools or origins.
+type PoolHealthEvent struct {
+	ID        int                     `json:"id"`
+	Timestamp string                  `json:"timestamp"`
+	Pools     []PoolHealthEventPool   `json:"pool"`
+	Origins   []PoolHealthEventOrigin `json:"origins"`
+}
+
+// PoolHealthEventPool is a pool's state in a PoolHealthEvent.
+type PoolHealthEventPool struct {
+	ID             string                  `json:"id"`
+	Name           string                  `json:"name"`
+	Healthy        bool                    `json:"healthy"`
+	Changed        bool                    `json:"changed"`
+	MinimumOrigins int                     `json:"minimum_origins"`
+	ChangedOrigins []PoolHealthEventOrigin `json:"changed_origins"`
+}
+
+// PoolHealthEventOrigin is an origin's state in a PoolHealthEvent.
+type PoolHealthEventOrigin struct {
+	Name          string `json:"name"`
+	Address       string `json:"address"`
+	Enabled       bool   `json:"enabled"`
+	Healthy       bool   `json:"healthy"`
+	Changed       bool   `json:"changed"`
+	FailureReason string `json:"failure_reason"`
+}
+
+// How often WaitForPoolHealthy checks a pool's health.
+const poolHealthPollInterval = 10 * time.Second
+
+// GetPoolHealth retrieves the latest health checks of a load balancer pool.
+func (c Client) GetPoolHealth(accountID, poolID string) (PoolHealth, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return PoolHealth{}, err
+	}
+	if len(accountID) == 0 || len(poolID) == 0 {
+		return PoolHealth{}, fmt.Errorf("you must provide an account ID and pool ID")
+	}
+
+	var health PoolHealth
+	err = c.apiRequest("GET", accountPrefix(accountID)+
+		"/load_balancers/pools/"+url.QueryEscape(poolID)+"/health", nil, nil,
+		&health)
+	if err != nil {
+		return PoolHealth{}, fmt.Errorf("get pool health error: %w", err)
+	}
+
+	return health, nil
+}
+
+// ListPoolHealthEvents retrieves changes in a load balancer pool's health
+// between since and until, such as origins going down and recovering. Zero
+// times leave that end of the range open.
+func (c Client) ListPoolHealthEvents(poolID string, since,
+	until time.Time) ([]PoolHealthEvent, error) {
+	if len(poolID) == 0 {
+		return nil, fmt.Errorf("you must provide a pool ID")
+	}
+
+	values := url.Values{}
+	values.Set("pool_id", poolID)
+	if !since.IsZero() {
+		values.Set("since", since.UTC().Format(time.RFC3339))
+	}
+	if !until.IsZero() {
+		values.Set("until", until.UTC().Format(time.RFC3339))
+	}
+
+	var events []PoolHealthEvent
+	err := c.apiRequest("GET", "user/load_balancing_analytics/events", values,
+		nil, &events)
+	if err != nil {
+		return nil, fmt.Errorf("list pool health events error: %w", err)
+	}
+
+	return events, nil
+}
+
+// WaitForPoolHealthy checks a load balancer pool's health until it and all
+// of its origins are healthy in every region, or ctx is done. This is useful
+// to hold a deployment until its origins are up.
+//
+// If ctx ends first, the error says what was still unhealthy.
+func (c Client) WaitForPoolHealthy(ctx context.Context, accountID,
+	poolID string) (PoolHealth, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return PoolHealth{}, err
+	}
+	for {
+		health, err := c.GetPoolHealth(accountID, poolID)
+		if err != nil && !IsTemporary(err) {
+			return PoolHealth{}, err
+		}
+
+		if err == nil && health.Healthy() {
+			return health, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return PoolHealth{}, fmt.Errorf("pool %s not healthy: %w", poolID,
+					err)
+			}
+			return health, fmt.Errorf("pool %s not healthy: %s: %w", poolID,
+				strings.Join(health.Unhealthy(), ", "), ctx.Err())
+		case <-time.After(poolHealthPollInterval):
+		}
+	}
+}
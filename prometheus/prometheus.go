@@ -0,0 +1,74 @@
+// Package prometheus exports metrics about Cloudflare API requests to
+// Prometheus.
+//
+// Use it by setting it as a client's Metrics:
+//
+//	metrics, err := prometheus.New(prom.DefaultRegisterer)
+//	...
+//	client.Metrics = metrics
+package prometheus
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics implements cloudflare.Metrics using Prometheus collectors.
+type Metrics struct {
+	requests *prom.CounterVec
+	latency  *prom.HistogramVec
+	retries  *prom.CounterVec
+}
+
+// New creates the collectors and registers them with the registerer.
+func New(registerer prom.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		requests: prom.NewCounterVec(
+			prom.CounterOpts{
+				Name: "cloudflare_api_requests_total",
+				Help: "Cloudflare API requests by endpoint and HTTP status (0 for no response).",
+			},
+			[]string{"endpoint", "status"},
+		),
+		latency: prom.NewHistogramVec(
+			prom.HistogramOpts{
+				Name:    "cloudflare_api_request_duration_seconds",
+				Help:    "Cloudflare API request latency by endpoint.",
+				Buckets: prom.DefBuckets,
+			},
+			[]string{"endpoint"},
+		),
+		retries: prom.NewCounterVec(
+			prom.CounterOpts{
+				Name: "cloudflare_api_retries_total",
+				Help: "Cloudflare API requests retried, by endpoint.",
+			},
+			[]string{"endpoint"},
+		),
+	}
+
+	for _, collector := range []prom.Collector{m.requests, m.latency,
+		m.retries} {
+		err := registerer.Register(collector)
+		if err != nil {
+			return nil, fmt.Errorf("unable to register collector: %w", err)
+		}
+	}
+
+	return m, nil
+}
+
+// ObserveRequest records a request.
+func (m *Metrics) ObserveRequest(endpoint string, status int,
+	duration time.Duration) {
+	m.requests.WithLabelValues(endpoint, strconv.Itoa(status)).Inc()
+	m.latency.WithLabelValues(endpoint).Observe(duration.Seconds())
+}
+
+// ObserveRetry records a retry.
+func (m *Metrics) ObserveRetry(endpoint string, attempt int) {
+	m.retries.WithLabelValues(endpoint).Inc()
+}
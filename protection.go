@@ -0,0 +1,132 @@
+package cloudflare
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Operation names a destructive operation a Protection policy guards.
+type Operation string
+
+// Operations a Protection policy guards.
+const (
+	OperationDeleteZone      Operation = "delete_zone"
+	OperationPurgeEverything Operation = "purge_everything"
+	OperationDeleteDNSRecord Operation = "delete_dns_record"
+)
+
+// ErrProtected means a Protection policy refused an operation. Use
+// Client.Force to make it anyway.
+var ErrProtected = errors.New("refused by protection policy")
+
+// Protection is a safety layer for tools run by many people. It refuses
+// destructive operations unless the caller forces them, and reports each
+// one it sees.
+//
+// This is enforced only by this client. It does not stop anyone using the
+// API another way.
+type Protection struct {
+	// Operations lists the operations to refuse unless forced. If it is
+	// empty we refuse all of them.
+	Operations []Operation
+
+	// Audit, if set, is called with each guarded operation: those we refuse
+	// as well as those we make, whether they succeed or not. It is called
+	// synchronously, so it should be quick.
+	Audit func(AuditEntry)
+}
+
+// AuditEntry records a guarded operation.
+type AuditEntry struct {
+	Time      time.Time
+	Operation Operation
+
+	// Target is what the operation acted on, such as a zone or record ID.
+	Target string
+
+	// Detail describes the target, such as a record's name, type, and
+	// content, if we know it.
+	Detail string
+
+	// Forced is whether the caller used Client.Force.
+	Forced bool
+
+	// Refused is whether the policy stopped the operation. If it did not, Err
+	// is the operation's result.
+	Refused bool
+	Err     error
+}
+
+// WithProtection guards destructive operations with a Protection policy.
+func WithProtection(protection Protection) Option {
+	return func(c *Client) error {
+		c.protection = &protection
+		return nil
+	}
+}
+
+// Protect returns a copy of the client using a different Protection policy.
+// This is useful to guard some calls but not others.
+func (c Client) Protect(protection Protection) Client {
+	c.protection = &protection
+	c.force = false
+	return c
+}
+
+// Force returns a copy of the client that makes operations its Protection
+// policy would otherwise refuse. They are still audited. For example:
+//
+//	err := client.Force().PurgeAllFiles(zoneID)
+func (c Client) Force() Client {
+	c.force = true
+	return c
+}
+
+// guard runs a destructive operation if the client's Protection policy
+// allows it, and audits it.
+func (c Client) guard(op Operation, target, detail string,
+	do func() error) error {
+	if c.protection == nil {
+		return do()
+	}
+
+	entry := AuditEntry{
+		Time:      time.Now(),
+		Operation: op,
+		Target:    target,
+		Detail:    detail,
+		Forced:    c.force,
+	}
+
+	if c.protection.protects(op) && !c.force {
+		entry.Refused = true
+		c.protection.audit(entry)
+		return fmt.Errorf("%s %s: %w", op, target, ErrProtected)
+	}
+
+	err := do()
+	entry.Err = err
+	c.protection.audit(entry)
+	return err
+}
+
+func (p *Protection) protects(op Operation) bool {
+	if len(p.Operations) == 0 {
+		return true
+	}
+
+	for _, o := range p.Operations {
+		if o == op {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *Protection) audit(entry AuditEntry) {
+	if p.Audit != nil {
+		p.Audit(entry)
+	}
+}
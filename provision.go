@@ -0,0 +1,143 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+)
+
+// Steps ProvisionZone reports progress on.
+const (
+	ProvisionStepCreate   = "create"
+	ProvisionStepWait     = "wait"
+	ProvisionStepRecords  = "records"
+	ProvisionStepSettings = "settings"
+)
+
+// ProvisionZoneOptions describes a zone for ProvisionZone to set up.
+type ProvisionZoneOptions struct {
+	AccountID string
+	Name      string
+
+	// Type is one of the ZoneType constants, or blank for a full zone.
+	Type string
+
+	// ZoneFile is a BIND zone file to import records from. ProxyZoneFile is
+	// whether to proxy the records from it that may be proxied.
+	ZoneFile      []byte
+	ProxyZoneFile bool
+
+	// Records are created after importing ZoneFile. Their ZoneID is set for
+	// you.
+	Records []DNSRecord
+
+	// Settings are zone settings to apply, as for UpdateZoneSettings.
+	Settings map[string]interface{}
+
+	// Progress, if set, is called as each step starts with one of the
+	// ProvisionStep constants and a description.
+	Progress func(step, message string)
+}
+
+// ProvisionedZone is the outcome of ProvisionZone.
+type ProvisionedZone struct {
+	Zone Zone
+
+	// RecordsCreated is how many records we imported and created.
+	RecordsCreated int
+
+	// NameServers are the nameservers to set at the zone's registrar.
+	NameServers []string
+}
+
+// ProvisionZone sets up a new zone: it creates the zone, waits for it to be
+// available, imports and creates its records, applies its settings, and
+// returns the nameservers to point the registrar at.
+//
+// If a step fails after the zone is created, we return what was done so far
+// along with the error. The zone is left in place so you can finish or
+// delete it.
+func (c Client) ProvisionZone(ctx context.Context,
+	opts ProvisionZoneOptions) (ProvisionedZone, error) {
+	progress := opts.Progress
+	if progress == nil {
+		progress = func(string, string) {}
+	}
+
+	progress(ProvisionStepCreate, fmt.Sprintf("creating zone %s", opts.Name))
+
+	zone, err := c.CreateZone(opts.AccountID, opts.Name, opts.Type)
+	if err != nil {
+		return ProvisionedZone{}, err
+	}
+
+	result := ProvisionedZone{Zone: zone, NameServers: zone.NameServers}
+
+	progress(ProvisionStepWait, fmt.Sprintf("waiting for zone %s (%s)",
+		zone.Name, zone.ID))
+
+	zone, err = c.waitForZone(ctx, zone.ID)
+	if err != nil {
+		return result, err
+	}
+	result.Zone = zone
+	if len(zone.NameServers) > 0 {
+		result.NameServers = zone.NameServers
+	}
+
+	if len(opts.ZoneFile) > 0 {
+		progress(ProvisionStepRecords, "importing zone file")
+
+		imported, err := c.ImportDNSRecords(zone.ID, opts.ZoneFile,
+			opts.ProxyZoneFile)
+		if err != nil {
+			return result, err
+		}
+		result.RecordsCreated += imported.RecordsAdded
+	}
+
+	for _, record := range opts.Records {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		progress(ProvisionStepRecords, fmt.Sprintf("creating %s record %s",
+			record.Type, record.Name))
+
+		record.ZoneID = zone.ID
+		_, err := c.CreateDNSRecord(record)
+		if err != nil {
+			return result, fmt.Errorf("unable to create %s record %s: %w",
+				record.Type, record.Name, err)
+		}
+		result.RecordsCreated++
+	}
+
+	if len(opts.Settings) > 0 {
+		progress(ProvisionStepSettings, fmt.Sprintf("applying %d settings",
+			len(opts.Settings)))
+
+		_, err := c.UpdateZoneSettings(zone.ID, opts.Settings)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// A new zone may not be found right away, so retry until it is.
+func (c Client) waitForZone(ctx context.Context, zoneID string) (Zone,
+	error) {
+	zone, err := WaitFor(ctx, func() (Zone, bool, error) {
+		zone, err := c.GetZone(zoneID)
+		if IsNotFound(err) {
+			return zone, false, nil
+		}
+		return zone, err == nil, err
+	})
+	if err != nil {
+		return Zone{}, fmt.Errorf("zone %s not available: %w", zoneID, err)
+	}
+
+	return zone, nil
+}
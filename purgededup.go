@@ -0,0 +1,91 @@
+package cloudflare
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// purgeDeduper remembers when we last purged each zone's cache so we can
+// skip purging it again too soon.
+type purgeDeduper struct {
+	window time.Duration
+
+	mutex sync.Mutex
+	last  map[string]*purgeRun
+}
+
+// purgeRun is a purge of a zone, which may still be happening.
+type purgeRun struct {
+	started time.Time
+
+	// finished is closed when the purge is done. err is its error, which may
+	// be read after that.
+	finished chan struct{}
+	err      error
+}
+
+// WithPurgeDedup makes PurgeAllFiles skip purging a zone it purged within
+// the last window. A skipped purge is logged and returns nil. If the zone is
+// being purged, we wait for that purge and return its error.
+//
+// This stops retry loops and repeated deploy steps from purging the cache
+// over and over. It only applies to calls through this client (and copies of
+// it), not to other programs.
+func WithPurgeDedup(window time.Duration) Option {
+	return func(c *Client) error {
+		if window <= 0 {
+			return fmt.Errorf("purge dedup window must be positive")
+		}
+		c.purgeDedup = &purgeDeduper{
+			window: window,
+			last:   map[string]*purgeRun{},
+		}
+		return nil
+	}
+}
+
+// start decides whether to purge the zone now. If so, we return true and a
+// run to pass to done with whether the purge worked. If not, we return the
+// recent or in progress purge to wait for.
+func (d *purgeDeduper) start(zoneID string) (*purgeRun, bool) {
+	run := &purgeRun{started: time.Now(), finished: make(chan struct{})}
+	if d == nil {
+		return run, true
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if last, ok := d.last[zoneID]; ok &&
+		run.started.Sub(last.started) < d.window {
+		return last, false
+	}
+
+	d.last[zoneID] = run
+	return run, true
+}
+
+// done records how a purge went for those waiting on it. We forget a purge
+// that failed so that retrying it is not skipped.
+func (d *purgeDeduper) done(zoneID string, run *purgeRun, err error) {
+	run.err = err
+	close(run.finished)
+
+	if d == nil || err == nil {
+		return
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.last[zoneID] == run {
+		delete(d.last, zoneID)
+	}
+}
+
+// wait waits for the purge to be done and returns its error.
+func (r *purgeRun) wait() error {
+	<-r.finished
+	return r.err
+}
@@ -0,0 +1,80 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// R2Bucket holds information about an R2 storage bucket.
+type R2Bucket struct {
+	Name         string `json:"name"`
+	CreationDate string `json:"creation_date"`
+	Location     string `json:"location"`
+}
+
+// ListR2Buckets lists the R2 buckets in an account.
+func (c Client) ListR2Buckets(accountID string) ([]R2Bucket, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var result struct {
+		Buckets []R2Bucket `json:"buckets"`
+	}
+	err = c.apiRequest("GET", fmt.Sprintf("accounts/%s/r2/buckets",
+		url.QueryEscape(accountID)), nil, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("list R2 buckets error: %w", err)
+	}
+
+	return result.Buckets, nil
+}
+
+// CreateR2Bucket creates an R2 bucket in an account.
+func (c Client) CreateR2Bucket(accountID, name string) (R2Bucket, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return R2Bucket{}, err
+	}
+	if len(accountID) == 0 {
+		return R2Bucket{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	if len(name) == 0 {
+		return R2Bucket{}, fmt.Errorf("you must provide a bucket name")
+	}
+
+	payload := map[string]string{"name": name}
+
+	var bucket R2Bucket
+	err = c.apiRequest("POST", fmt.Sprintf("accounts/%s/r2/buckets",
+		url.QueryEscape(accountID)), nil, payload, &bucket)
+	if err != nil {
+		return R2Bucket{}, fmt.Errorf("create R2 bucket error: %w", err)
+	}
+
+	return bucket, nil
+}
+
+// DeleteR2Bucket deletes an R2 bucket. The bucket must be empty.
+func (c Client) DeleteR2Bucket(accountID, name string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(name) == 0 {
+		return fmt.Errorf("you must provide an account ID and bucket name")
+	}
+
+	err = c.apiRequest("DELETE", fmt.Sprintf("accounts/%s/r2/buckets/%s",
+		url.QueryEscape(accountID), url.QueryEscape(name)), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete R2 bucket error: %w", err)
+	}
+
+	return nil
+}
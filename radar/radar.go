@@ -0,0 +1,221 @@
+// Package radar reads data from Cloudflare Radar, such as Internet traffic
+// trends, attack activity, and domain rankings.
+//
+// It makes requests with a cloudflare.Client, so they are authenticated,
+// rate limited, and retried the same way. The token needs the Radar read
+// permission:
+//
+//	r := radar.New(client)
+//	series, err := r.TrafficTimeSeries(ctx, radar.Options{DateRange: "7d"})
+//
+// Radar data is global and does not belong to an account or zone.
+package radar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/horgh/cloudflare"
+)
+
+// Client reads Radar data.
+type Client struct {
+	client cloudflare.Client
+}
+
+// Options narrows the data a request covers. The zero value covers the last
+// week worldwide.
+type Options struct {
+	// DateRange is a period ending now, e.g. 1d, 7d, or 4w. It is ignored if
+	// DateStart is set.
+	DateRange string
+
+	// DateStart and DateEnd are a fixed period.
+	DateStart time.Time
+	DateEnd   time.Time
+
+	// Location is an alpha-2 country code, e.g. US. Blank for worldwide.
+	Location string
+
+	// ASN limits data to a network, e.g. 13335.
+	ASN int
+
+	// AggInterval is the time series' interval: 15m, 1h, 1d, or 1w. Blank for
+	// the API's default.
+	AggInterval string
+}
+
+// TimeSeries is a series of values over time. Values are usually normalized
+// rather than absolute.
+type TimeSeries struct {
+	Timestamps []time.Time
+	Values     []float64
+}
+
+// RankedDomain is a domain in a popularity ranking.
+type RankedDomain struct {
+	Rank       int    `json:"rank"`
+	Domain     string `json:"domain"`
+	Categories []struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"categories"`
+}
+
+// New creates a Client using the cloudflare client.
+func New(client cloudflare.Client) *Client {
+	return &Client{client: client}
+}
+
+// TrafficTimeSeries retrieves how much traffic Cloudflare's network saw over
+// time.
+func (c *Client) TrafficTimeSeries(ctx context.Context,
+	opts Options) (TimeSeries, error) {
+	return c.timeSeries(ctx, "netflows/timeseries", opts)
+}
+
+// HTTPTimeSeries retrieves how many HTTP requests Cloudflare's network saw
+// over time.
+func (c *Client) HTTPTimeSeries(ctx context.Context,
+	opts Options) (TimeSeries, error) {
+	return c.timeSeries(ctx, "http/timeseries", opts)
+}
+
+// Layer3AttackTimeSeries retrieves network layer (DDoS) attack activity
+// over time.
+func (c *Client) Layer3AttackTimeSeries(ctx context.Context,
+	opts Options) (TimeSeries, error) {
+	return c.timeSeries(ctx, "attacks/layer3/timeseries", opts)
+}
+
+// Layer7AttackTimeSeries retrieves application layer attack activity over
+// time.
+func (c *Client) Layer7AttackTimeSeries(ctx context.Context,
+	opts Options) (TimeSeries, error) {
+	return c.timeSeries(ctx, "attacks/layer7/timeseries", opts)
+}
+
+// Layer7AttackSummary retrieves the share of application layer attacks by a
+// dimension, e.g. http_method, industry, or managed_rules. Shares are
+// percentages.
+func (c *Client) Layer7AttackSummary(ctx context.Context, dimension string,
+	opts Options) (map[string]float64, error) {
+	if len(dimension) == 0 {
+		return nil, fmt.Errorf("you must provide a dimension")
+	}
+
+	var result struct {
+		Summary map[string]json.Number `json:"summary_0"`
+	}
+	err := c.client.DoContext(ctx, "GET",
+		"radar/attacks/layer7/summary/"+url.PathEscape(dimension),
+		opts.values(), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := map[string]float64{}
+	for key, value := range result.Summary {
+		f, err := value.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+		summary[key] = f
+	}
+
+	return summary, nil
+}
+
+// TopDomains retrieves the most popular domains, most popular first. limit
+// may be zero for the API's default.
+func (c *Client) TopDomains(ctx context.Context, limit int,
+	opts Options) ([]RankedDomain, error) {
+	values := opts.values()
+	if limit > 0 {
+		values.Set("limit", strconv.Itoa(limit))
+	}
+
+	var result struct {
+		Top []RankedDomain `json:"top_0"`
+	}
+	err := c.client.DoContext(ctx, "GET", "radar/ranking/top", values, nil,
+		&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Top, nil
+}
+
+func (c *Client) timeSeries(ctx context.Context, path string,
+	opts Options) (TimeSeries, error) {
+	// Radar gives values as strings.
+	var result struct {
+		Serie struct {
+			Timestamps []string      `json:"timestamps"`
+			Values     []json.Number `json:"values"`
+		} `json:"serie_0"`
+	}
+	err := c.client.DoContext(ctx, "GET", "radar/"+path, opts.values(), nil,
+		&result)
+	if err != nil {
+		return TimeSeries{}, err
+	}
+
+	if len(result.Serie.Timestamps) != len(result.Serie.Values) {
+		return TimeSeries{}, fmt.Errorf(
+			"time series has %d timestamps but %d values",
+			len(result.Serie.Timestamps), len(result.Serie.Values))
+	}
+
+	var series TimeSeries
+	for i, timestamp := range result.Serie.Timestamps {
+		t, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			return TimeSeries{}, fmt.Errorf("invalid timestamp: %s", timestamp)
+		}
+
+		value, err := result.Serie.Values[i].Float64()
+		if err != nil {
+			return TimeSeries{}, fmt.Errorf("invalid value: %s",
+				result.Serie.Values[i])
+		}
+
+		series.Timestamps = append(series.Timestamps, t)
+		series.Values = append(series.Values, value)
+	}
+
+	return series, nil
+}
+
+func (o Options) values() url.Values {
+	values := url.Values{}
+	values.Set("format", "json")
+
+	if !o.DateStart.IsZero() {
+		values.Set("dateStart", o.DateStart.UTC().Format(time.RFC3339))
+		end := o.DateEnd
+		if end.IsZero() {
+			end = time.Now()
+		}
+		values.Set("dateEnd", end.UTC().Format(time.RFC3339))
+	} else if len(o.DateRange) > 0 {
+		values.Set("dateRange", o.DateRange)
+	}
+
+	if len(o.Location) > 0 {
+		values.Set("location", o.Location)
+	}
+	if o.ASN > 0 {
+		values.Set("asn", strconv.Itoa(o.ASN))
+	}
+	if len(o.AggInterval) > 0 {
+		values.Set("aggInterval", o.AggInterval)
+	}
+
+	return values
+}
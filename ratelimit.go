@@ -0,0 +1,72 @@
+package cloudflare
+
+import (
+	"sync"
+	"time"
+)
+
+// Cloudflare allows 1200 requests per five minutes per user.
+const (
+	// DefaultRequestsPerSecond stays within Cloudflare's global rate limit.
+	DefaultRequestsPerSecond = 4
+
+	// DefaultBurst is how many requests we allow at once before limiting.
+	DefaultBurst = 10
+)
+
+// RateLimiter limits how often requests are made.
+//
+// Set Client.RateLimiter to limit a client. Clients sharing a RateLimiter are
+// limited together, which is what you want if they use the same credentials.
+// It is safe for concurrent use.
+type RateLimiter struct {
+	mutex    sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+// NewRateLimiter creates a token bucket rate limiter allowing perSecond
+// requests per second on average and up to burst requests at once.
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	if perSecond <= 0 {
+		perSecond = DefaultRequestsPerSecond
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		interval: time.Duration(float64(time.Second) / perSecond),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// Wait blocks until a request may be made.
+func (r *RateLimiter) Wait() {
+	r.mutex.Lock()
+
+	now := time.Now()
+	r.tokens += float64(now.Sub(r.last)) / float64(r.interval)
+	if r.tokens > r.burst {
+		r.tokens = r.burst
+	}
+	r.last = now
+
+	// Take a token even if we have to wait for it. Later callers then wait
+	// behind us.
+	r.tokens--
+	wait := time.Duration(0)
+	if r.tokens < 0 {
+		wait = time.Duration(-r.tokens * float64(r.interval))
+	}
+
+	r.mutex.Unlock()
+
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+}
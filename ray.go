@@ -0,0 +1,40 @@
+package cloudflare
+
+import "sync"
+
+// rayHeader is the response header holding the ray ID. Cloudflare gives each
+// request one.
+const rayHeader = "CF-Ray"
+
+// rayRecorder remembers the most recent ray ID. A nil recorder remembers
+// nothing.
+type rayRecorder struct {
+	mutex sync.Mutex
+	id    string
+}
+
+func (r *rayRecorder) set(id string) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.id = id
+}
+
+func (r *rayRecorder) get() string {
+	if r == nil {
+		return ""
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.id
+}
+
+// LastRayID returns the ray ID of the most recent response. Cloudflare
+// support can use it to find the request. Copies of the client share it.
+//
+// Errors from the API include their ray ID as well (see APIError).
+func (c Client) LastRayID() string {
+	return c.lastRay.get()
+}
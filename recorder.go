@@ -0,0 +1,174 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Recorder is told about each change a Client makes, such as to keep an audit
+// trail or emit change events.
+//
+// Set Client.Recorder to use one. Every request other than GET and HEAD
+// counts as a change, whether it succeeded or not, unless the endpoint only
+// reads (such as GraphQL).
+//
+// Implementations must be safe for concurrent use. RecordMutation is called
+// synchronously, so it should be quick.
+type Recorder interface {
+	RecordMutation(Mutation)
+}
+
+// Mutation describes a change made through the API.
+//
+// With Before and Result a change can be reviewed, and undone by putting
+// Before back.
+type Mutation struct {
+	Time   time.Time
+	Method string
+
+	// Resource is the path of what changed relative to the API, e.g.
+	// zones/<id>/dns_records/<id>.
+	Resource string
+
+	// Before is the resource as it was before the change, for PUT, PATCH, and
+	// DELETE requests. We get it with a GET of the resource first, so
+	// recording these costs an extra request. It is empty if that failed, such
+	// as if the resource can't be retrieved that way.
+	Before json.RawMessage
+
+	// Request is the payload we sent, if there was one and it was JSON.
+	Request json.RawMessage
+
+	// Result is the resource as it was after the change, as the API
+	// responded. It is empty if the API did not include it.
+	Result json.RawMessage
+
+	// StatusCode is the HTTP status, or 0 if there was no response. Err is
+	// set if the request failed.
+	StatusCode int
+	RayID      string
+	Err        error
+
+	// DryRun is set if the client was in dry run mode, so the change was not
+	// actually made.
+	DryRun bool
+}
+
+// RecorderFunc lets a function act as a Recorder.
+type RecorderFunc func(Mutation)
+
+// RecordMutation calls f.
+func (f RecorderFunc) RecordMutation(m Mutation) {
+	f(m)
+}
+
+// readOnlyEndpoints match the paths of endpoints we use other methods than
+// GET for that only read.
+var readOnlyEndpoints = []*regexp.Regexp{
+	regexp.MustCompile(`^graphql$`),
+	regexp.MustCompile(`/logpush/validate/(destination|origin)$`),
+	regexp.MustCompile(`/logpush/ownership/validate$`),
+	regexp.MustCompile(`^accounts/[^/]+/ai/run/`),
+	regexp.MustCompile(`^accounts/[^/]+/workers/scripts/[^/]+/tails$`),
+}
+
+// isMutation decides whether a request changes anything. Requests other
+// than GET and HEAD do unless the endpoint only reads, such as GraphQL
+// queries.
+func (c Client) isMutation(method, rawURL string) bool {
+	if method == "GET" || method == "HEAD" {
+		return false
+	}
+
+	path := c.resourcePath(rawURL)
+	for _, re := range readOnlyEndpoints {
+		if re.MatchString(path) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// resourcePath gives a request URL's path relative to the API.
+func (c Client) resourcePath(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	base, err := url.Parse(c.endpoint())
+	if err != nil {
+		return u.Path
+	}
+
+	return strings.TrimPrefix(u.Path, base.Path)
+}
+
+// recordMutation tells the Recorder about a request. before is the
+// resource's state beforehand, if we have it.
+func (c Client) recordMutation(start time.Time, method, rawURL string,
+	before, request, response []byte, meta responseMeta, err error) {
+	m := Mutation{
+		Time:       start,
+		Method:     method,
+		Resource:   c.resourcePath(rawURL),
+		StatusCode: meta.StatusCode,
+		RayID:      meta.RayID,
+		Err:        err,
+		DryRun:     c.dryRun,
+	}
+
+	// Redacting keeps the JSON valid as we only replace within strings.
+	if len(request) > 0 && json.Valid(request) {
+		m.Request = json.RawMessage(c.redact(string(request)))
+	}
+
+	if result := envelopeResult(response); result != nil {
+		m.Result = json.RawMessage(c.redact(string(result)))
+	}
+	if len(before) > 0 {
+		m.Before = json.RawMessage(c.redact(string(before)))
+	}
+
+	c.Recorder.RecordMutation(m)
+}
+
+// priorState retrieves a resource before we change it, for Mutation.Before.
+// We return nil if the method does not change an existing resource or the
+// resource can't be retrieved.
+func (c Client) priorState(ctx context.Context, method, rawURL string) []byte {
+	if method != "PUT" && method != "PATCH" && method != "DELETE" {
+		return nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+	u.RawQuery = ""
+
+	body, meta, err := c.doRequestMeta(ctx, "GET", u.String(), nil)
+	if err != nil || meta.StatusCode != 200 {
+		return nil
+	}
+
+	return envelopeResult(body)
+}
+
+// envelopeResult gives the result portion of an API response, or nil if
+// there isn't one.
+func envelopeResult(response []byte) json.RawMessage {
+	var envelope struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if json.Unmarshal(response, &envelope) != nil ||
+		string(envelope.Result) == "null" {
+		return nil
+	}
+	return envelope.Result
+}
@@ -0,0 +1,68 @@
+package cloudflare
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// DNSScanResult holds the outcome of scanning for or importing a zone's DNS
+// records.
+type DNSScanResult struct {
+	// RecordsAdded is how many records the scan added to the zone.
+	RecordsAdded int `json:"recs_added"`
+
+	// TotalRecordsParsed is how many records the scan found.
+	TotalRecordsParsed int `json:"total_records_parsed"`
+}
+
+// ScanDNSRecords asks Cloudflare to look up common records (such as www and
+// MX records) for a zone from its current nameservers and add them. This is
+// useful right after adding a zone so you don't have to recreate its records
+// by hand.
+//
+// Check the records afterwards. The scan can't find every record.
+func (c Client) ScanDNSRecords(zoneID string) (DNSScanResult, error) {
+	if len(zoneID) == 0 {
+		return DNSScanResult{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var result DNSScanResult
+	err := c.apiRequest("POST", zonePrefix(zoneID)+"/dns_records/scan", nil,
+		nil, &result)
+	c.cache.forgetPrefix(recordsCacheKey(zoneID))
+	if err != nil {
+		return DNSScanResult{}, fmt.Errorf("scan DNS records error: %w", err)
+	}
+
+	return result, nil
+}
+
+// ImportDNSRecords adds the records in a BIND zone file to a zone. proxied
+// is whether to proxy the records that may be proxied.
+func (c Client) ImportDNSRecords(zoneID string, zoneFile []byte,
+	proxied bool) (DNSScanResult, error) {
+	if len(zoneID) == 0 {
+		return DNSScanResult{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	if len(zoneFile) == 0 {
+		return DNSScanResult{}, fmt.Errorf("you must provide a zone file")
+	}
+
+	body, err := newFileUpload("zone.txt", zoneFile, map[string]string{
+		"proxied": strconv.FormatBool(proxied),
+	})
+	if err != nil {
+		return DNSScanResult{}, err
+	}
+
+	var result DNSScanResult
+	err = c.apiRequest("POST", zonePrefix(zoneID)+"/dns_records/import", nil,
+		body, &result)
+	c.cache.forgetPrefix(recordsCacheKey(zoneID))
+	if err != nil {
+		return DNSScanResult{}, fmt.Errorf("import DNS records error: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,127 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// DNS record types.
+const (
+	RecordTypeA          = "A"
+	RecordTypeAAAA       = "AAAA"
+	RecordTypeCAA        = "CAA"
+	RecordTypeCERT       = "CERT"
+	RecordTypeCNAME      = "CNAME"
+	RecordTypeDNSKEY     = "DNSKEY"
+	RecordTypeDS         = "DS"
+	RecordTypeHTTPS      = "HTTPS"
+	RecordTypeLOC        = "LOC"
+	RecordTypeMX         = "MX"
+	RecordTypeNAPTR      = "NAPTR"
+	RecordTypeNS         = "NS"
+	RecordTypeOPENPGPKEY = "OPENPGPKEY"
+	RecordTypePTR        = "PTR"
+	RecordTypeSMIMEA     = "SMIMEA"
+	RecordTypeSRV        = "SRV"
+	RecordTypeSSHFP      = "SSHFP"
+	RecordTypeSVCB       = "SVCB"
+	RecordTypeTLSA       = "TLSA"
+	RecordTypeTXT        = "TXT"
+	RecordTypeURI        = "URI"
+)
+
+// recordTypePattern matches a plausible record type. We don't insist on one
+// of the constants above, so types the API adds later may still be used.
+var recordTypePattern = regexp.MustCompile(`^[A-Z][A-Z0-9]*$`)
+
+// TTL limits. A TTL of 1 means automatic.
+const (
+	TTLAutomatic = 1
+	MinTTL       = 30
+	MaxTTL       = 86400
+)
+
+// The longest TXT record content the API accepts.
+const maxTXTContentLength = 2048
+
+// IsProxiableType reports whether records of a type may be proxied.
+func IsProxiableType(recordType string) bool {
+	return recordType == RecordTypeA || recordType == RecordTypeAAAA ||
+		recordType == RecordTypeCNAME
+}
+
+// ValidateDNSRecord checks a record before sending it to the API.
+//
+// The API rejects invalid records too, but its errors are often opaque (such
+// as code 9004 or 1004). We check the type, name, TTL, whether it may be
+// proxied, and the content for types where that is simple. Types we don't
+// know are left to the API.
+//
+// A TTL of 0 is accepted and means automatic.
+func ValidateDNSRecord(record DNSRecord) error {
+	if !recordTypePattern.MatchString(record.Type) {
+		return fmt.Errorf("invalid record type: %q", record.Type)
+	}
+
+	if len(record.Name) == 0 {
+		return fmt.Errorf("%s record has no name", record.Type)
+	}
+
+	if record.TTL != 0 && record.TTL != TTLAutomatic &&
+		(record.TTL < MinTTL || record.TTL > MaxTTL) {
+		return fmt.Errorf(
+			"%s record %s has TTL %d. It must be %d (automatic) or between %d and %d",
+			record.Type, record.Name, record.TTL, TTLAutomatic, MinTTL, MaxTTL)
+	}
+
+	if record.Proxied && !IsProxiableType(record.Type) {
+		return fmt.Errorf("%s record %s may not be proxied. Only A, AAAA, and CNAME records may be",
+			record.Type, record.Name)
+	}
+
+	if len(record.Content) == 0 {
+		return fmt.Errorf("%s record %s has no content", record.Type,
+			record.Name)
+	}
+
+	return validateRecordContent(record)
+}
+
+func validateRecordContent(record DNSRecord) error {
+	switch record.Type {
+	case RecordTypeA:
+		ip := net.ParseIP(record.Content)
+		if ip == nil || ip.To4() == nil || strings.Contains(record.Content, ":") {
+			return fmt.Errorf("A record %s content is not an IPv4 address: %s",
+				record.Name, record.Content)
+		}
+	case RecordTypeAAAA:
+		ip := net.ParseIP(record.Content)
+		if ip == nil || !strings.Contains(record.Content, ":") {
+			return fmt.Errorf("AAAA record %s content is not an IPv6 address: %s",
+				record.Name, record.Content)
+		}
+	case RecordTypeMX:
+		// "." is the null MX (RFC 7505), saying the domain accepts no mail.
+		if record.Content != "." &&
+			!isValidHostname(NormalizeName(record.Content)) {
+			return fmt.Errorf("MX record %s content is not a hostname: %s",
+				record.Name, record.Content)
+		}
+	case RecordTypeCNAME, RecordTypeNS, RecordTypePTR:
+		if !isValidHostname(NormalizeName(record.Content)) {
+			return fmt.Errorf("%s record %s content is not a hostname: %s",
+				record.Type, record.Name, record.Content)
+		}
+	case RecordTypeTXT:
+		if len(record.Content) > maxTXTContentLength {
+			return fmt.Errorf(
+				"TXT record %s content is %d characters. The most allowed is %d",
+				record.Name, len(record.Content), maxTXTContentLength)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// RegionalHostname restricts where Cloudflare decrypts and processes a
+// hostname's traffic. It is part of Regional Services, a component of the
+// Data Localization Suite.
+type RegionalHostname struct {
+	Hostname string `json:"hostname"`
+
+	// RegionKey is a region's key, such as eu. See ListRegions.
+	RegionKey string `json:"region_key"`
+	CreatedOn string `json:"created_on,omitempty"`
+}
+
+// Region is a region traffic may be restricted to.
+type Region struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+}
+
+func regionalHostnamesPath(zoneID string) string {
+	return zonePrefix(zoneID) + "/addressing/regional_hostnames"
+}
+
+// ListRegions retrieves the regions available to the account.
+func (c Client) ListRegions(accountID string) ([]Region, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var regions []Region
+	err = c.apiRequest("GET", accountPrefix(accountID)+
+		"/addressing/regional_hostnames/regions", nil, nil, &regions)
+	if err != nil {
+		return nil, fmt.Errorf("list regions error: %w", err)
+	}
+
+	return regions, nil
+}
+
+// ListRegionalHostnames retrieves a zone's regional hostnames.
+func (c Client) ListRegionalHostnames(zoneID string) ([]RegionalHostname,
+	error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var hostnames []RegionalHostname
+	err := c.apiRequest("GET", regionalHostnamesPath(zoneID), nil, nil,
+		&hostnames)
+	if err != nil {
+		return nil, fmt.Errorf("list regional hostnames error: %w", err)
+	}
+
+	return hostnames, nil
+}
+
+// GetRegionalHostname retrieves the region of a hostname.
+func (c Client) GetRegionalHostname(zoneID, hostname string) (
+	RegionalHostname, error) {
+	if len(zoneID) == 0 || len(hostname) == 0 {
+		return RegionalHostname{}, fmt.Errorf(
+			"you must provide a zone ID and hostname")
+	}
+
+	var regional RegionalHostname
+	err := c.apiRequest("GET", regionalHostnamesPath(zoneID)+"/"+
+		url.QueryEscape(hostname), nil, nil, &regional)
+	if err != nil {
+		return RegionalHostname{}, fmt.Errorf(
+			"get regional hostname error: %w", err)
+	}
+
+	return regional, nil
+}
+
+// CreateRegionalHostname restricts a hostname to a region. The hostname may
+// include a wildcard, e.g. *.example.com.
+func (c Client) CreateRegionalHostname(zoneID, hostname,
+	regionKey string) (RegionalHostname, error) {
+	if len(zoneID) == 0 || len(hostname) == 0 || len(regionKey) == 0 {
+		return RegionalHostname{}, fmt.Errorf(
+			"you must provide a zone ID, hostname, and region")
+	}
+
+	payload := RegionalHostname{Hostname: hostname, RegionKey: regionKey}
+
+	var regional RegionalHostname
+	err := c.apiRequest("POST", regionalHostnamesPath(zoneID), nil, payload,
+		&regional)
+	if err != nil {
+		return RegionalHostname{}, fmt.Errorf(
+			"create regional hostname error: %w", err)
+	}
+
+	return regional, nil
+}
+
+// UpdateRegionalHostname changes the region of a hostname.
+func (c Client) UpdateRegionalHostname(zoneID, hostname,
+	regionKey string) (RegionalHostname, error) {
+	if len(zoneID) == 0 || len(hostname) == 0 || len(regionKey) == 0 {
+		return RegionalHostname{}, fmt.Errorf(
+			"you must provide a zone ID, hostname, and region")
+	}
+
+	payload := map[string]string{"region_key": regionKey}
+
+	var regional RegionalHostname
+	err := c.apiRequest("PATCH", regionalHostnamesPath(zoneID)+"/"+
+		url.QueryEscape(hostname), nil, payload, &regional)
+	if err != nil {
+		return RegionalHostname{}, fmt.Errorf(
+			"update regional hostname error: %w", err)
+	}
+
+	return regional, nil
+}
+
+// DeleteRegionalHostname removes the region restriction of a hostname.
+func (c Client) DeleteRegionalHostname(zoneID, hostname string) error {
+	if len(zoneID) == 0 || len(hostname) == 0 {
+		return fmt.Errorf("you must provide a zone ID and hostname")
+	}
+
+	err := c.apiRequest("DELETE", regionalHostnamesPath(zoneID)+"/"+
+		url.QueryEscape(hostname), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete regional hostname error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// RegistrarDomain holds a domain registered with Cloudflare Registrar.
+type RegistrarDomain struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	CurrentRegistrar string `json:"current_registrar"`
+	ExpiresAt        string `json:"expires_at"`
+	CreatedAt        string `json:"created_at"`
+	UpdatedAt        string `json:"updated_at"`
+
+	// Locked is whether the domain is locked against transfers.
+	Locked    bool `json:"locked"`
+	AutoRenew bool `json:"auto_renew"`
+
+	// Privacy is whether WHOIS privacy redacts the registrant.
+	Privacy      bool `json:"privacy"`
+	SupportedTLD bool `json:"supported_tld"`
+}
+
+// RegistrarDomainUpdate holds changes to a registered domain. Fields left nil
+// are unchanged.
+type RegistrarDomainUpdate struct {
+	AutoRenew *bool `json:"auto_renew,omitempty"`
+	Locked    *bool `json:"locked,omitempty"`
+	Privacy   *bool `json:"privacy,omitempty"`
+}
+
+// ExpiresTime parses the domain's ExpiresAt time.
+func (d RegistrarDomain) ExpiresTime() (time.Time, error) {
+	return parseTime(d.ExpiresAt)
+}
+
+// ListRegistrarDomains retrieves the domains registered in an account.
+func (c Client) ListRegistrarDomains(accountID string) ([]RegistrarDomain,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var domains []RegistrarDomain
+	err = c.apiRequest("GET", accountPrefix(accountID)+"/registrar/domains",
+		nil, nil, &domains)
+	if err != nil {
+		return nil, fmt.Errorf("list registrar domains error: %w", err)
+	}
+
+	return domains, nil
+}
+
+// GetRegistrarDomain retrieves a registered domain, such as to check its
+// expiry or lock status.
+func (c Client) GetRegistrarDomain(accountID, name string) (RegistrarDomain,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return RegistrarDomain{}, err
+	}
+	if len(accountID) == 0 || len(name) == 0 {
+		return RegistrarDomain{}, fmt.Errorf(
+			"you must provide an account ID and domain name")
+	}
+
+	var domain RegistrarDomain
+	err = c.apiRequest("GET", accountPrefix(accountID)+"/registrar/domains/"+
+		url.QueryEscape(name), nil, nil, &domain)
+	if err != nil {
+		return RegistrarDomain{}, fmt.Errorf("get registrar domain error: %w",
+			err)
+	}
+
+	return domain, nil
+}
+
+// UpdateRegistrarDomain changes a registered domain's auto-renew, transfer
+// lock, or privacy settings.
+func (c Client) UpdateRegistrarDomain(accountID, name string,
+	update RegistrarDomainUpdate) (RegistrarDomain, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return RegistrarDomain{}, err
+	}
+	if len(accountID) == 0 || len(name) == 0 {
+		return RegistrarDomain{}, fmt.Errorf(
+			"you must provide an account ID and domain name")
+	}
+
+	var domain RegistrarDomain
+	err = c.apiRequest("PUT", accountPrefix(accountID)+"/registrar/domains/"+
+		url.QueryEscape(name), nil, update, &domain)
+	if err != nil {
+		return RegistrarDomain{}, fmt.Errorf(
+			"update registrar domain error: %w", err)
+	}
+
+	return domain, nil
+}
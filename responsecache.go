@@ -0,0 +1,126 @@
+package cloudflare
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// responseCache remembers responses to lookups that are made repeatedly,
+// such as finding a zone's ID. It is shared by copies of a Client.
+//
+// A nil cache remembers nothing.
+type responseCache struct {
+	mutex   sync.Mutex
+	entries map[string]cacheEntry
+
+	// ttl is how long entries last. Zero means forever.
+	ttl time.Duration
+
+	// records is whether to remember DNS record lookups. We only do this if
+	// asked (with WithCache) as records change more often than zones.
+	records bool
+}
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{entries: map[string]cacheEntry{}}
+}
+
+// WithCache remembers the results of zone ID and DNS record lookups for ttl,
+// so repeating them makes no requests. This is useful for programs that
+// check the same records periodically.
+//
+// Changing records through the client forgets what we remembered about the
+// zone's records. Changes made elsewhere go unnoticed until the TTL passes or
+// you call InvalidateCache.
+func WithCache(ttl time.Duration) Option {
+	return func(c *Client) error {
+		if ttl <= 0 {
+			return fmt.Errorf("cache TTL must be positive")
+		}
+		c.cache = newResponseCache()
+		c.cache.ttl = ttl
+		c.cache.records = true
+		return nil
+	}
+}
+
+// InvalidateCache forgets everything the client remembers about earlier
+// lookups.
+func (c Client) InvalidateCache() {
+	if c.cache == nil {
+		return
+	}
+
+	c.cache.mutex.Lock()
+	defer c.cache.mutex.Unlock()
+
+	c.cache.entries = map[string]cacheEntry{}
+}
+
+func (r *responseCache) get(key string) (interface{}, bool) {
+	if r == nil {
+		return nil, false
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry, ok := r.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		delete(r.entries, key)
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+func (r *responseCache) set(key string, value interface{}) {
+	if r == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry := cacheEntry{value: value}
+	if r.ttl > 0 {
+		entry.expires = time.Now().Add(r.ttl)
+	}
+
+	r.entries[key] = entry
+}
+
+// forgetPrefix removes the entries whose keys start with prefix.
+func (r *responseCache) forgetPrefix(prefix string) {
+	if r == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for key := range r.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(r.entries, key)
+		}
+	}
+}
+
+func (r *responseCache) cachesRecords() bool {
+	return r != nil && r.records
+}
+
+func recordsCacheKey(zoneID string) string {
+	return "records:" + zoneID + ":"
+}
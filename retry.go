@@ -0,0 +1,65 @@
+package cloudflare
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryMetrics may optionally be implemented by a Metrics to be told about
+// retries.
+type RetryMetrics interface {
+	// ObserveRetry is called before retrying a request. attempt is 1 for the
+	// first retry.
+	ObserveRetry(endpoint string, attempt int)
+}
+
+// retryPolicy says when to retry requests. The zero value never retries.
+type retryPolicy struct {
+	maxRetries int
+	backoff    time.Duration
+}
+
+// The longest we wait between attempts, unless the API asks for longer.
+const maxRetryBackoff = time.Minute
+
+// shouldRetry decides whether to retry after an attempt, and if so, how long
+// to wait first. attempt is 0 for the first attempt.
+func (p retryPolicy) shouldRetry(method string, resp *http.Response, err error,
+	attempt int) (time.Duration, bool) {
+	if attempt >= p.maxRetries {
+		return 0, false
+	}
+
+	delay := p.backoff << uint(attempt)
+	if delay > maxRetryBackoff || delay < 0 {
+		delay = maxRetryBackoff
+	}
+
+	if err != nil {
+		return delay, isIdempotent(method)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+		if err == nil && seconds > 0 {
+			delay = time.Duration(seconds) * time.Second
+		}
+		return delay, true
+	}
+
+	if resp.StatusCode >= 500 {
+		return delay, isIdempotent(method)
+	}
+
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "PUT", "DELETE":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,125 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Role is a role members of an account may have, such as Administrator.
+type Role struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	// Permissions maps areas, e.g. dns_records, to what the role may do. We
+	// leave it undecoded as its shape varies.
+	Permissions json.RawMessage `json:"permissions"`
+}
+
+// PermissionGroup is a set of permissions that may be granted to an API
+// token, such as "DNS Write".
+type PermissionGroup struct {
+	ID     string   `json:"id"`
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// ListRoles retrieves the roles of an account.
+func (c Client) ListRoles(accountID string) ([]Role, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var roles []Role
+	err = c.apiRequest("GET", accountPrefix(accountID)+"/roles", nil, nil,
+		&roles)
+	if err != nil {
+		return nil, fmt.Errorf("list roles error: %w", err)
+	}
+
+	return roles, nil
+}
+
+// RoleIDByName finds the ID of an account's role by its name. The name is
+// case insensitive.
+func (c Client) RoleIDByName(accountID, name string) (string, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return "", err
+	}
+	roles, err := c.ListRoles(accountID)
+	if err != nil {
+		return "", err
+	}
+
+	for _, role := range roles {
+		if strings.EqualFold(role.Name, name) {
+			return role.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("role not found: %s", name)
+}
+
+// ListPermissionGroups retrieves the permission groups that may be granted to
+// API tokens.
+func (c Client) ListPermissionGroups() ([]PermissionGroup, error) {
+	var groups []PermissionGroup
+	err := c.apiRequest("GET", "user/tokens/permission_groups", nil, nil,
+		&groups)
+	if err != nil {
+		return nil, fmt.Errorf("list permission groups error: %w", err)
+	}
+
+	return groups, nil
+}
+
+// PermissionGroupIDByName finds the ID of a permission group by its name,
+// e.g. "DNS Write". The name is case insensitive.
+//
+// Some names are shared by groups with different scopes (e.g. account and
+// zone). If scope is not blank, only groups with that scope match, e.g.
+// com.cloudflare.api.account.zone.
+func (c Client) PermissionGroupIDByName(name, scope string) (string, error) {
+	groups, err := c.ListPermissionGroups()
+	if err != nil {
+		return "", err
+	}
+
+	var matches []PermissionGroup
+	for _, group := range groups {
+		if !strings.EqualFold(group.Name, name) {
+			continue
+		}
+		if len(scope) > 0 && !containsString(group.Scopes, scope) {
+			continue
+		}
+		matches = append(matches, group)
+	}
+
+	if len(matches) == 0 {
+		return "", fmt.Errorf("permission group not found: %s", name)
+	}
+
+	if len(matches) > 1 {
+		return "", fmt.Errorf(
+			"permission group name matches %d groups. Give a scope: %s",
+			len(matches), name)
+	}
+
+	return matches[0].ID, nil
+}
+
+func containsString(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,414 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Ruleset holds a ruleset. Rulesets hold the rules for a phase of request
+// processing, such as transforms or caching.
+type Ruleset struct {
+	ID          string        `json:"id,omitempty"`
+	Name        string        `json:"name,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Kind        string        `json:"kind,omitempty"`
+	Phase       string        `json:"phase,omitempty"`
+	Version     string        `json:"version,omitempty"`
+	LastUpdated string        `json:"last_updated,omitempty"`
+	Rules       []RulesetRule `json:"rules"`
+}
+
+// RulesetRule holds a single rule of a ruleset.
+type RulesetRule struct {
+	ID               string                   `json:"id,omitempty"`
+	Ref              string                   `json:"ref,omitempty"`
+	Version          string                   `json:"version,omitempty"`
+	Action           string                   `json:"action"`
+	ActionParameters *RulesetActionParameters `json:"action_parameters,omitempty"`
+	Expression       string                   `json:"expression"`
+	Description      string                   `json:"description,omitempty"`
+	Enabled          bool                     `json:"enabled"`
+	LastUpdated      string                   `json:"last_updated,omitempty"`
+}
+
+// RulesetActionParameters holds the parameters of a rule's action. Which
+// fields apply depends on the action.
+type RulesetActionParameters struct {
+	// URI rewrites the URI. Used by the rewrite action in the
+	// http_request_transform phase.
+	URI *RewriteURI `json:"uri,omitempty"`
+
+	// Headers modifies headers by name. Used by the rewrite action in the
+	// header transform phases.
+	Headers map[string]RewriteHeader `json:"headers,omitempty"`
+
+	// Cache, EdgeTTL, BrowserTTL, and CacheKey control caching. Used by the
+	// set_cache_settings action in the http_request_cache_settings phase.
+	Cache      *bool     `json:"cache,omitempty"`
+	EdgeTTL    *CacheTTL `json:"edge_ttl,omitempty"`
+	BrowserTTL *CacheTTL `json:"browser_ttl,omitempty"`
+	CacheKey   *CacheKey `json:"cache_key,omitempty"`
+
+	// ID is the ruleset to run and Overrides adjusts its rules. Used by the
+	// execute action, such as to deploy a managed ruleset.
+	ID        string            `json:"id,omitempty"`
+	Overrides *RulesetOverrides `json:"overrides,omitempty"`
+}
+
+// RulesetOverrides changes the behaviour of a managed ruleset's rules. Fields
+// left empty keep the managed ruleset's defaults.
+type RulesetOverrides struct {
+	// Action and SensitivityLevel apply to every rule.
+	Action           string `json:"action,omitempty"`
+	SensitivityLevel string `json:"sensitivity_level,omitempty"`
+	Enabled          *bool  `json:"enabled,omitempty"`
+
+	// Rules overrides individual rules.
+	Rules []RulesetRuleOverride `json:"rules,omitempty"`
+}
+
+// RulesetRuleOverride changes the behaviour of a single managed rule.
+type RulesetRuleOverride struct {
+	ID               string `json:"id"`
+	Action           string `json:"action,omitempty"`
+	SensitivityLevel string `json:"sensitivity_level,omitempty"`
+	Enabled          *bool  `json:"enabled,omitempty"`
+}
+
+// RewriteURI describes how to rewrite a URI.
+type RewriteURI struct {
+	Path  *RewriteValue `json:"path,omitempty"`
+	Query *RewriteValue `json:"query,omitempty"`
+}
+
+// RewriteValue is either a static value or an expression evaluated per
+// request. Set one of them.
+type RewriteValue struct {
+	Value      string `json:"value,omitempty"`
+	Expression string `json:"expression,omitempty"`
+}
+
+// RewriteHeader describes a header modification.
+type RewriteHeader struct {
+	// Operation is set, add (response headers only), or remove.
+	Operation string `json:"operation"`
+
+	// Value or Expression gives the new value for set and add.
+	Value      string `json:"value,omitempty"`
+	Expression string `json:"expression,omitempty"`
+}
+
+// Ruleset phases.
+const (
+	PhaseHTTPRequestTransform         = "http_request_transform"
+	PhaseHTTPRequestLateTransform     = "http_request_late_transform"
+	PhaseHTTPResponseHeadersTransform = "http_response_headers_transform"
+)
+
+// The most characters a rule expression may have.
+const maxExpressionLength = 4096
+
+// GetZoneEntrypointRuleset retrieves the zone's entry point ruleset for a
+// phase. This holds the zone's rules for the phase.
+func (c Client) GetZoneEntrypointRuleset(zoneID, phase string) (Ruleset,
+	error) {
+	if len(zoneID) == 0 {
+		return Ruleset{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	return c.getEntrypointRuleset(zonePrefix(zoneID), phase)
+}
+
+// UpdateZoneEntrypointRuleset replaces all rules in the zone's entry point
+// ruleset for a phase, creating the ruleset if necessary.
+func (c Client) UpdateZoneEntrypointRuleset(zoneID, phase string,
+	rules []RulesetRule) (Ruleset, error) {
+	if len(zoneID) == 0 {
+		return Ruleset{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	return c.updateEntrypointRuleset(zonePrefix(zoneID), phase, rules)
+}
+
+// AddZonePhaseRule adds a rule to the end of the zone's rules for a phase.
+//
+// If the zone has no rules for the phase yet we create its entry point
+// ruleset. We return the ruleset as updated.
+func (c Client) AddZonePhaseRule(zoneID, phase string,
+	rule RulesetRule) (Ruleset, error) {
+	if len(zoneID) == 0 {
+		return Ruleset{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	return c.addPhaseRule(zonePrefix(zoneID), phase, rule)
+}
+
+// UpdateZoneRulesetRule changes a rule in a zone ruleset. The rule's ID must
+// be set. We return the ruleset as updated.
+func (c Client) UpdateZoneRulesetRule(zoneID, rulesetID string,
+	rule RulesetRule) (Ruleset, error) {
+	if len(zoneID) == 0 {
+		return Ruleset{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	return c.updateRulesetRule(zonePrefix(zoneID), rulesetID, rule)
+}
+
+// DeleteZoneRulesetRule deletes a rule from a zone ruleset.
+func (c Client) DeleteZoneRulesetRule(zoneID, rulesetID, ruleID string) error {
+	if len(zoneID) == 0 {
+		return fmt.Errorf("you must provide a zone ID")
+	}
+
+	return c.deleteRulesetRule(zonePrefix(zoneID), rulesetID, ruleID)
+}
+
+// The following work with rulesets of either zones or accounts. prefix is
+// zones/<id> or accounts/<id>.
+
+func (c Client) getEntrypointRuleset(prefix, phase string) (Ruleset, error) {
+	if len(phase) == 0 {
+		return Ruleset{}, fmt.Errorf("you must provide a phase")
+	}
+
+	var ruleset Ruleset
+	err := c.apiRequest("GET", fmt.Sprintf("%s/rulesets/phases/%s/entrypoint",
+		prefix, url.QueryEscape(phase)), nil, nil, &ruleset)
+	if err != nil {
+		return Ruleset{}, fmt.Errorf("get entrypoint ruleset error: %w", err)
+	}
+
+	return ruleset, nil
+}
+
+func (c Client) updateEntrypointRuleset(prefix, phase string,
+	rules []RulesetRule) (Ruleset, error) {
+	if len(phase) == 0 {
+		return Ruleset{}, fmt.Errorf("you must provide a phase")
+	}
+
+	for _, rule := range rules {
+		err := validateRule(rule)
+		if err != nil {
+			return Ruleset{}, err
+		}
+	}
+
+	if rules == nil {
+		rules = []RulesetRule{}
+	}
+
+	payload := Ruleset{Rules: rules}
+
+	var ruleset Ruleset
+	err := c.apiRequest("PUT", fmt.Sprintf("%s/rulesets/phases/%s/entrypoint",
+		prefix, url.QueryEscape(phase)), nil, payload, &ruleset)
+	if err != nil {
+		return Ruleset{}, fmt.Errorf("update entrypoint ruleset error: %w", err)
+	}
+
+	return ruleset, nil
+}
+
+func (c Client) addPhaseRule(prefix, phase string, rule RulesetRule) (Ruleset,
+	error) {
+	err := validateRule(rule)
+	if err != nil {
+		return Ruleset{}, err
+	}
+
+	ruleset, err := c.getEntrypointRuleset(prefix, phase)
+	if err != nil {
+		if !IsNotFound(err) {
+			return Ruleset{}, err
+		}
+		// There is no entry point yet. Creating it with the rule is the same as
+		// adding the rule.
+		return c.updateEntrypointRuleset(prefix, phase, []RulesetRule{rule})
+	}
+
+	var updated Ruleset
+	err = c.apiRequest("POST", fmt.Sprintf("%s/rulesets/%s/rules", prefix,
+		url.QueryEscape(ruleset.ID)), nil, rule, &updated)
+	if err != nil {
+		return Ruleset{}, fmt.Errorf("create ruleset rule error: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (c Client) updateRulesetRule(prefix, rulesetID string,
+	rule RulesetRule) (Ruleset, error) {
+	if len(rulesetID) == 0 || len(rule.ID) == 0 {
+		return Ruleset{}, fmt.Errorf("you must provide a ruleset ID and rule ID")
+	}
+
+	err := validateRule(rule)
+	if err != nil {
+		return Ruleset{}, err
+	}
+
+	var updated Ruleset
+	err = c.apiRequest("PATCH", fmt.Sprintf("%s/rulesets/%s/rules/%s", prefix,
+		url.QueryEscape(rulesetID), url.QueryEscape(rule.ID)), nil, rule,
+		&updated)
+	if err != nil {
+		return Ruleset{}, fmt.Errorf("update ruleset rule error: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (c Client) deleteRulesetRule(prefix, rulesetID, ruleID string) error {
+	if len(rulesetID) == 0 || len(ruleID) == 0 {
+		return fmt.Errorf("you must provide a ruleset ID and rule ID")
+	}
+
+	err := c.apiRequest("DELETE", fmt.Sprintf("%s/rulesets/%s/rules/%s", prefix,
+		url.QueryEscape(rulesetID), url.QueryEscape(ruleID)), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete ruleset rule error: %w", err)
+	}
+
+	return nil
+}
+
+// Check what we can about a rule before sending it.
+func validateRule(rule RulesetRule) error {
+	if len(rule.Action) == 0 {
+		return fmt.Errorf("rule has no action")
+	}
+
+	if len(rule.Expression) == 0 {
+		return fmt.Errorf("rule has no expression. Use \"true\" to match everything")
+	}
+
+	if len(rule.Expression) > maxExpressionLength {
+		return fmt.Errorf("rule expression is %d characters. The most allowed is %d",
+			len(rule.Expression), maxExpressionLength)
+	}
+
+	return checkExpressionSyntax(rule.Expression)
+}
+
+// Catch simple syntax mistakes in an expression: unterminated strings and
+// unbalanced brackets. The API does the real parsing.
+func checkExpressionSyntax(expr string) error {
+	var stack []rune
+	closers := map[rune]rune{')': '(', '}': '{', ']': '['}
+	inString := false
+	escaped := false
+
+	for _, r := range expr {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch r {
+		case '"':
+			inString = true
+		case '(', '{', '[':
+			stack = append(stack, r)
+		case ')', '}', ']':
+			if len(stack) == 0 || stack[len(stack)-1] != closers[r] {
+				return fmt.Errorf("rule expression has unbalanced %q: %s", r, expr)
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	if inString {
+		return fmt.Errorf("rule expression has an unterminated string: %s", expr)
+	}
+
+	if len(stack) > 0 {
+		return fmt.Errorf("rule expression has unclosed %q: %s", stack[len(stack)-1],
+			expr)
+	}
+
+	return nil
+}
+
+// Fields commonly used in rule expressions.
+const (
+	FieldHost      = "http.host"
+	FieldURI       = "http.request.uri"
+	FieldURIPath   = "http.request.uri.path"
+	FieldURIQuery  = "http.request.uri.query"
+	FieldFullURI   = "http.request.full_uri"
+	FieldMethod    = "http.request.method"
+	FieldUserAgent = "http.user_agent"
+	FieldClientIP  = "ip.src"
+	FieldCountry   = "ip.src.country"
+	FieldExtension = "http.request.uri.path.extension"
+	FieldZoneName  = "cf.zone.name"
+	FieldZonePlan  = "cf.zone.plan"
+)
+
+// ExprQuote quotes a string for use in a rule expression.
+func ExprQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// ExprEquals builds an expression matching when field equals value.
+func ExprEquals(field, value string) string {
+	return fmt.Sprintf("%s eq %s", field, ExprQuote(value))
+}
+
+// ExprStartsWith builds an expression matching when field starts with
+// prefix.
+func ExprStartsWith(field, prefix string) string {
+	return fmt.Sprintf("starts_with(%s, %s)", field, ExprQuote(prefix))
+}
+
+// ExprContains builds an expression matching when field contains value.
+func ExprContains(field, value string) string {
+	return fmt.Sprintf("%s contains %s", field, ExprQuote(value))
+}
+
+// ExprIn builds an expression matching when field is one of the values.
+func ExprIn(field string, values ...string) string {
+	var quoted []string
+	for _, v := range values {
+		quoted = append(quoted, ExprQuote(v))
+	}
+	return fmt.Sprintf("%s in {%s}", field, strings.Join(quoted, " "))
+}
+
+// ExprAnd builds an expression matching when all of the expressions match.
+func ExprAnd(exprs ...string) string {
+	return joinExprs("and", exprs)
+}
+
+// ExprOr builds an expression matching when any of the expressions match.
+func ExprOr(exprs ...string) string {
+	return joinExprs("or", exprs)
+}
+
+// ExprNot negates an expression.
+func ExprNot(expr string) string {
+	return fmt.Sprintf("not (%s)", expr)
+}
+
+func joinExprs(op string, exprs []string) string {
+	if len(exprs) == 1 {
+		return exprs[0]
+	}
+
+	var wrapped []string
+	for _, e := range exprs {
+		wrapped = append(wrapped, "("+e+")")
+	}
+	return strings.Join(wrapped, " "+op+" ")
+}
@@ -0,0 +1,278 @@
+package cloudflare
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PermissionPolicy decides what happens when a key or token file can be read
+// by other users.
+type PermissionPolicy int
+
+// Permission policies.
+const (
+	// PermissionWarn logs a warning and reads the file anyway.
+	PermissionWarn PermissionPolicy = iota
+
+	// PermissionFail refuses to read the file.
+	PermissionFail
+
+	// PermissionIgnore does not check.
+	PermissionIgnore
+)
+
+// ReadOption configures ReadKeyFromFile and ReadTokenFromFile.
+type ReadOption func(*readOptions)
+
+type readOptions struct {
+	permissions PermissionPolicy
+}
+
+// WithPermissionPolicy sets what to do if the file can be read by other
+// users. The default is PermissionWarn.
+func WithPermissionPolicy(p PermissionPolicy) ReadOption {
+	return func(o *readOptions) {
+		o.permissions = p
+	}
+}
+
+// ErrInsecureKeyFile is the error when a key or token file can be read by
+// other users and the policy is PermissionFail.
+var ErrInsecureKeyFile = errors.New("key file is readable by other users")
+
+// ReadKeyFromFile reads an API key from a given file.
+//
+// The file should contain nothing other than the API key.
+//
+// Rather than a path you may give one of:
+//
+//   - "-" to read from standard input
+//   - "fd:N" to read from file descriptor N, e.g. one a parent process
+//     opened for us
+//   - "env:NAME" to read from the environment variable NAME
+//
+// If the file can be read by users other than its owner we warn, or apply
+// the policy given with WithPermissionPolicy.
+func ReadKeyFromFile(keyFile string, opts ...ReadOption) (string, error) {
+	key, err := readSecret(keyFile, opts)
+	if err != nil {
+		return "", err
+	}
+
+	if len(key) == 0 {
+		return "", fmt.Errorf("no key found in file")
+	}
+
+	return key, nil
+}
+
+// ReadTokenFromFile reads an API token the same way as ReadKeyFromFile, and
+// checks that it looks like a token.
+//
+// Tokens are 40 or more letters, digits, dashes, and underscores. We say so
+// if the file looks like it has an API key instead.
+func ReadTokenFromFile(tokenFile string, opts ...ReadOption) (string,
+	error) {
+	token, err := readSecret(tokenFile, opts)
+	if err != nil {
+		return "", err
+	}
+
+	if len(token) == 0 {
+		return "", fmt.Errorf("no token found in file")
+	}
+
+	err = checkTokenFormat(token)
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// readSecret reads and trims a secret from a file or one of the sources
+// ReadKeyFromFile describes.
+func readSecret(source string, opts []ReadOption) (string, error) {
+	var o readOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if name, ok := strings.CutPrefix(source, "env:"); ok {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return strings.TrimSpace(value), nil
+	}
+
+	if source == "-" {
+		return readSecretFrom(os.Stdin, "stdin")
+	}
+
+	if fd, ok := strings.CutPrefix(source, "fd:"); ok {
+		n, err := strconv.ParseUint(fd, 10, 32)
+		if err != nil {
+			return "", fmt.Errorf("invalid file descriptor: %s", fd)
+		}
+
+		fh := os.NewFile(uintptr(n), source)
+		if fh == nil {
+			return "", fmt.Errorf("invalid file descriptor: %s", fd)
+		}
+		defer func() {
+			_ = fh.Close()
+		}()
+
+		return readSecretFrom(fh, source)
+	}
+
+	fh, err := os.Open(source)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		err := fh.Close()
+		if err != nil {
+			log.Printf("close: %s: %s", source, err)
+		}
+	}()
+
+	err = checkPermissions(fh, source, o.permissions)
+	if err != nil {
+		return "", err
+	}
+
+	return readSecretFrom(fh, source)
+}
+
+func readSecretFrom(r io.Reader, name string) (string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("problem reading from %s: %w", name, err)
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+// checkPermissions applies the policy to an open file. Like ssh we object
+// to the file being readable by its group or others. File modes don't mean
+// the same thing on Windows, so we don't check there.
+func checkPermissions(fh *os.File, name string,
+	policy PermissionPolicy) error {
+	if policy == PermissionIgnore || runtime.GOOS == "windows" {
+		return nil
+	}
+
+	fi, err := fh.Stat()
+	if err != nil {
+		return fmt.Errorf("unable to stat %s: %w", name, err)
+	}
+
+	if fi.Mode().Perm()&0o044 == 0 {
+		return nil
+	}
+
+	if policy == PermissionFail {
+		return fmt.Errorf("%w: %s has mode %s", ErrInsecureKeyFile, name,
+			fi.Mode().Perm())
+	}
+
+	log.Printf("warning: %s is readable by other users (mode %s)", name,
+		fi.Mode().Perm())
+	return nil
+}
+
+var (
+	tokenPattern  = regexp.MustCompile(`^[A-Za-z0-9_-]{40,}$`)
+	apiKeyPattern = regexp.MustCompile(`^[0-9a-f]{37}$`)
+)
+
+// checkTokenFormat reports if a token can't be one.
+func checkTokenFormat(token string) error {
+	if apiKeyPattern.MatchString(token) {
+		return fmt.Errorf("token looks like an API key: use it with an email " +
+			"instead")
+	}
+
+	if !tokenPattern.MatchString(token) {
+		return fmt.Errorf("token is not in the expected format: it should be " +
+			"at least 40 letters, digits, dashes, or underscores")
+	}
+
+	return nil
+}
+
+const redacted = "[redacted]"
+
+var (
+	// secretFieldPattern matches JSON string fields whose names say they hold
+	// a secret, such as client_secret or tunnel_token.
+	secretFieldPattern = regexp.MustCompile(
+		`("[A-Za-z_]*(?:secret|password|token|private_key)"\s*:\s*)` +
+			`"(?:[^"\\]|\\.)*"`)
+
+	// secretParamPattern matches query parameters that hold a secret, such as
+	// secret-access-key in a Logpush destination.
+	secretParamPattern = regexp.MustCompile(
+		`([A-Za-z_-]*(?:secret|password|token)[A-Za-z_-]*=)[^&\s"\\]+`)
+)
+
+// minRedactLength is the shortest credential we redact. Anything shorter
+// can't be a real key or token, and replacing it would mangle messages.
+const minRedactLength = 8
+
+// redact removes our credentials and anything that looks like a secret from
+// a message before we log it, return it in an error, or record it. That
+// includes tokens we got from Client.Credentials.
+func (c Client) redact(message string) string {
+	secrets := append([]string{c.Token, c.Key}, c.providedTokens.all()...)
+	for _, secret := range secrets {
+		if len(secret) >= minRedactLength {
+			message = strings.ReplaceAll(message, secret, redacted)
+		}
+	}
+
+	message = secretFieldPattern.ReplaceAllString(message, `$1"`+redacted+`"`)
+	return secretParamPattern.ReplaceAllString(message, "${1}"+redacted)
+}
+
+// tokenRecorder remembers the tokens a CredentialsProvider gave us so we can
+// redact them. A nil recorder remembers nothing.
+type tokenRecorder struct {
+	mutex  sync.Mutex
+	tokens map[string]struct{}
+}
+
+func (r *tokenRecorder) add(token string) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.tokens == nil {
+		r.tokens = map[string]struct{}{}
+	}
+	r.tokens[token] = struct{}{}
+}
+
+func (r *tokenRecorder) all() []string {
+	if r == nil {
+		return nil
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var tokens []string
+	for token := range r.tokens {
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
@@ -0,0 +1,199 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+// ZoneSetting holds a single zone setting.
+//
+// The type of Value depends on the setting. Many are "on" or "off" but some
+// are numbers or objects. Decode it with json.Unmarshal.
+type ZoneSetting struct {
+	ID         string          `json:"id"`
+	Value      json.RawMessage `json:"value"`
+	Editable   bool            `json:"editable"`
+	ModifiedOn string          `json:"modified_on"`
+
+	// TimeRemaining is set for settings that turn themselves off, such as
+	// development_mode. It is in seconds.
+	TimeRemaining int `json:"time_remaining,omitempty"`
+}
+
+// Names of zone settings.
+const (
+	SettingAlwaysOnline            = "always_online"
+	SettingAlwaysUseHTTPS          = "always_use_https"
+	SettingAutomaticHTTPSRewrites  = "automatic_https_rewrites"
+	SettingBrotli                  = "brotli"
+	SettingBrowserCacheTTL         = "browser_cache_ttl"
+	SettingCacheLevel              = "cache_level"
+	SettingDevelopmentMode         = "development_mode"
+	SettingEarlyHints              = "early_hints"
+	SettingHTTP2                   = "http2"
+	SettingHTTP3                   = "http3"
+	SettingIPGeolocation           = "ip_geolocation"
+	SettingIPv6                    = "ipv6"
+	SettingMinTLSVersion           = "min_tls_version"
+	SettingOpportunisticEncryption = "opportunistic_encryption"
+	SettingOpportunisticOnion      = "opportunistic_onion"
+	SettingPseudoIPv4              = "pseudo_ipv4"
+	SettingSecurityHeader          = "security_header"
+	SettingSortQueryStringForCache = "sort_query_string_for_cache"
+	SettingSSL                     = "ssl"
+	SettingTLS13                   = "tls_1_3"
+	SettingWebSockets              = "websockets"
+	SettingZeroRTT                 = "0rtt"
+)
+
+// Settings whose value is "on" or "off".
+var onOffSettings = map[string]struct{}{
+	SettingAlwaysOnline:            {},
+	SettingAlwaysUseHTTPS:          {},
+	SettingAutomaticHTTPSRewrites:  {},
+	SettingBrotli:                  {},
+	SettingDevelopmentMode:         {},
+	SettingEarlyHints:              {},
+	SettingHTTP2:                   {},
+	SettingHTTP3:                   {},
+	SettingIPGeolocation:           {},
+	SettingIPv6:                    {},
+	SettingOpportunisticEncryption: {},
+	SettingOpportunisticOnion:      {},
+	SettingSortQueryStringForCache: {},
+	SettingWebSockets:              {},
+	SettingZeroRTT:                 {},
+}
+
+// ListZoneSettings retrieves all settings of a zone.
+func (c Client) ListZoneSettings(zoneID string) ([]ZoneSetting, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var settings []ZoneSetting
+	err := c.apiRequest("GET", fmt.Sprintf("zones/%s/settings",
+		url.QueryEscape(zoneID)), nil, nil, &settings)
+	if err != nil {
+		return nil, fmt.Errorf("list zone settings error: %w", err)
+	}
+
+	return settings, nil
+}
+
+// GetZoneSetting retrieves a single zone setting, e.g. "always_use_https".
+func (c Client) GetZoneSetting(zoneID, name string) (ZoneSetting, error) {
+	if len(zoneID) == 0 || len(name) == 0 {
+		return ZoneSetting{}, fmt.Errorf(
+			"you must provide a zone ID and setting name")
+	}
+
+	var setting ZoneSetting
+	err := c.apiRequest("GET", fmt.Sprintf("zones/%s/settings/%s",
+		url.QueryEscape(zoneID), url.QueryEscape(name)), nil, nil, &setting)
+	if err != nil {
+		return ZoneSetting{}, fmt.Errorf("get zone setting error: %w", err)
+	}
+
+	return setting, nil
+}
+
+// UpdateZoneSetting changes a single zone setting.
+//
+// value is encoded to JSON, so pass e.g. "on" for an on/off setting, a number
+// for a TTL, or a struct for an object setting. We return the setting as
+// updated.
+func (c Client) UpdateZoneSetting(zoneID, name string,
+	value interface{}) (ZoneSetting, error) {
+	if len(zoneID) == 0 || len(name) == 0 {
+		return ZoneSetting{}, fmt.Errorf(
+			"you must provide a zone ID and setting name")
+	}
+
+	if _, ok := onOffSettings[name]; ok && value != "on" && value != "off" {
+		return ZoneSetting{}, fmt.Errorf(
+			"setting %s must be \"on\" or \"off\", not %v", name, value)
+	}
+
+	payload := map[string]interface{}{"value": value}
+
+	var setting ZoneSetting
+	err := c.apiRequest("PATCH", fmt.Sprintf("zones/%s/settings/%s",
+		url.QueryEscape(zoneID), url.QueryEscape(name)), nil, payload, &setting)
+	if err != nil {
+		return ZoneSetting{}, fmt.Errorf("update zone setting error: %w", err)
+	}
+
+	return setting, nil
+}
+
+// UpdateZoneSettings changes several zone settings in one request. settings
+// maps each setting's name to its value, which is encoded as for
+// UpdateZoneSetting:
+//
+//	_, err := client.UpdateZoneSettings(zoneID, map[string]interface{}{
+//		cloudflare.SettingAlwaysUseHTTPS: "on",
+//		cloudflare.SettingMinTLSVersion:  cloudflare.TLSVersion12,
+//	})
+//
+// If any change fails, the API makes none of them. We return the settings
+// as updated.
+func (c Client) UpdateZoneSettings(zoneID string,
+	settings map[string]interface{}) ([]ZoneSetting, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	if len(settings) == 0 {
+		return nil, fmt.Errorf("you must provide at least one setting")
+	}
+
+	type item struct {
+		ID    string      `json:"id"`
+		Value interface{} `json:"value"`
+	}
+
+	var items []item
+	for name, value := range settings {
+		if _, ok := onOffSettings[name]; ok && value != "on" && value != "off" {
+			return nil, fmt.Errorf("setting %s must be \"on\" or \"off\", not %v",
+				name, value)
+		}
+		items = append(items, item{name, value})
+	}
+
+	// Keep requests the same from run to run.
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+
+	payload := struct {
+		Items []item `json:"items"`
+	}{items}
+
+	var updated []ZoneSetting
+	err := c.apiRequest("PATCH", zonePrefix(zoneID)+"/settings", nil, payload,
+		&updated)
+	if err != nil {
+		return nil, fmt.Errorf("update zone settings error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// GetZoneSettingString retrieves a setting whose value is a string, such as
+// an on/off setting.
+func (c Client) GetZoneSettingString(zoneID, name string) (string, error) {
+	setting, err := c.GetZoneSetting(zoneID, name)
+	if err != nil {
+		return "", err
+	}
+
+	var value string
+	err = json.Unmarshal(setting.Value, &value)
+	if err != nil {
+		return "", fmt.Errorf("setting %s is not a string: %w", name, err)
+	}
+
+	return value, nil
+}
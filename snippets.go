@@ -0,0 +1,236 @@
+package cloudflare
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Snippet describes a Snippet, a small piece of JavaScript run on requests
+// to a zone. Snippet rules decide which requests run it.
+type Snippet struct {
+	Name       string `json:"snippet_name"`
+	CreatedOn  string `json:"created_on"`
+	ModifiedOn string `json:"modified_on"`
+}
+
+// SnippetRule runs a snippet on requests matching its expression.
+type SnippetRule struct {
+	ID          string `json:"id,omitempty"`
+	SnippetName string `json:"snippet_name"`
+	Expression  string `json:"expression"`
+	Description string `json:"description,omitempty"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// Snippet names may only have lowercase letters, digits, and underscores.
+var snippetNameRE = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+func snippetPath(zoneID, name string) string {
+	return zonePrefix(zoneID) + "/snippets/" + url.QueryEscape(name)
+}
+
+// ListSnippets lists a zone's snippets.
+func (c Client) ListSnippets(zoneID string) ([]Snippet, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var snippets []Snippet
+	err := c.apiRequest("GET", zonePrefix(zoneID)+"/snippets", nil, nil,
+		&snippets)
+	if err != nil {
+		return nil, fmt.Errorf("list snippets error: %w", err)
+	}
+
+	return snippets, nil
+}
+
+// GetSnippet retrieves a snippet's details. See GetSnippetContent for its
+// code.
+func (c Client) GetSnippet(zoneID, name string) (Snippet, error) {
+	if len(zoneID) == 0 || len(name) == 0 {
+		return Snippet{}, fmt.Errorf("you must provide a zone ID and snippet name")
+	}
+
+	var snippet Snippet
+	err := c.apiRequest("GET", snippetPath(zoneID, name), nil, nil, &snippet)
+	if err != nil {
+		return Snippet{}, fmt.Errorf("get snippet error: %w", err)
+	}
+
+	return snippet, nil
+}
+
+// GetSnippetContent retrieves a snippet's code.
+func (c Client) GetSnippetContent(zoneID, name string) (string, error) {
+	if len(zoneID) == 0 || len(name) == 0 {
+		return "", fmt.Errorf("you must provide a zone ID and snippet name")
+	}
+
+	resp, err := c.send("GET", c.endpoint()+snippetPath(zoneID, name)+
+		"/content", nil)
+	if err != nil {
+		return "", fmt.Errorf("API request failure: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("unable to read body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var response Response
+		err = json.Unmarshal(body, &response)
+		if err != nil || len(response.Errors) == 0 {
+			return "", fmt.Errorf("get snippet content error: %s: %s", resp.Status,
+				body)
+		}
+		return "", fmt.Errorf("get snippet content error: %w",
+			errorsToError(responseMeta{
+				StatusCode: resp.StatusCode,
+				RayID:      resp.Header.Get(rayHeader),
+			}, response.Errors))
+	}
+
+	// The code comes as a multipart form holding the snippet's files. We
+	// return the first one, the main module.
+	mediaType, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return string(body), nil
+	}
+
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return "", fmt.Errorf("no snippet file in response: %w", err)
+		}
+		if part.FormName() == "metadata" {
+			continue
+		}
+
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return "", fmt.Errorf("unable to read snippet file: %w", err)
+		}
+		return string(content), nil
+	}
+}
+
+// PutSnippet uploads a snippet's code, creating the snippet or replacing it.
+//
+// name may only have lowercase letters, digits, and underscores. code is an
+// ES module whose default export has a fetch handler, as with Workers.
+func (c Client) PutSnippet(zoneID, name, code string) (Snippet, error) {
+	if len(zoneID) == 0 || len(name) == 0 {
+		return Snippet{}, fmt.Errorf("you must provide a zone ID and snippet name")
+	}
+
+	if !snippetNameRE.MatchString(name) {
+		return Snippet{}, fmt.Errorf(
+			"invalid snippet name: %s: use lowercase letters, digits, and underscores",
+			name)
+	}
+
+	if len(strings.TrimSpace(code)) == 0 {
+		return Snippet{}, fmt.Errorf("you must provide the snippet's code")
+	}
+
+	const fileName = "snippet.js"
+
+	body, err := newModuleUpload(map[string]string{"main_module": fileName},
+		fileName, code)
+	if err != nil {
+		return Snippet{}, err
+	}
+
+	var snippet Snippet
+	err = c.apiRequest("PUT", snippetPath(zoneID, name), nil, body, &snippet)
+	if err != nil {
+		return Snippet{}, fmt.Errorf("put snippet error: %w", err)
+	}
+
+	return snippet, nil
+}
+
+// DeleteSnippet deletes a snippet. Remove any rules using it first.
+func (c Client) DeleteSnippet(zoneID, name string) error {
+	if len(zoneID) == 0 || len(name) == 0 {
+		return fmt.Errorf("you must provide a zone ID and snippet name")
+	}
+
+	err := c.apiRequest("DELETE", snippetPath(zoneID, name), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete snippet error: %w", err)
+	}
+
+	return nil
+}
+
+// GetSnippetRules retrieves a zone's snippet rules.
+func (c Client) GetSnippetRules(zoneID string) ([]SnippetRule, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var rules []SnippetRule
+	err := c.apiRequest("GET", zonePrefix(zoneID)+"/snippets/snippet_rules", nil,
+		nil, &rules)
+	if err != nil {
+		return nil, fmt.Errorf("get snippet rules error: %w", err)
+	}
+
+	return rules, nil
+}
+
+// UpdateSnippetRules replaces all of a zone's snippet rules. Rules run in
+// order.
+func (c Client) UpdateSnippetRules(zoneID string,
+	rules []SnippetRule) ([]SnippetRule, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	for _, rule := range rules {
+		if len(rule.SnippetName) == 0 {
+			return nil, fmt.Errorf("snippet rule has no snippet name")
+		}
+
+		// Snippet rules take the same expressions as ruleset rules.
+		err := validateRule(RulesetRule{
+			Action:     "execute",
+			Expression: rule.Expression,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if rules == nil {
+		rules = []SnippetRule{}
+	}
+
+	payload := struct {
+		Rules []SnippetRule `json:"rules"`
+	}{Rules: rules}
+
+	var updated []SnippetRule
+	err := c.apiRequest("PUT", zonePrefix(zoneID)+"/snippets/snippet_rules", nil,
+		payload, &updated)
+	if err != nil {
+		return nil, fmt.Errorf("update snippet rules error: %w", err)
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,112 @@
+package cloudflare
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// staleRecords remembers the last records ListAllDNSRecords fetched so it
+// can return them if the API is briefly unavailable. It is shared by copies
+// of a Client.
+//
+// A nil store remembers nothing.
+type staleRecords struct {
+	mutex    sync.Mutex
+	maxAge   time.Duration
+	snapshot map[string]staleSnapshot
+
+	// since is when the records the last read returned were fetched, if they
+	// were a snapshot. It is zero if they were current.
+	since time.Time
+}
+
+type staleSnapshot struct {
+	records []DNSRecord
+	fetched time.Time
+}
+
+// WithStaleOnError makes ListAllDNSRecords return the records it last
+// fetched if fetching them again fails with a temporary error (see
+// IsTemporary), as long as they are no older than maxAge. This lets
+// programs such as dynamic DNS daemons keep deciding what to do during short
+// API outages.
+//
+// StaleSince says whether the last read returned such records. They don't
+// reflect changes made since they were fetched, including through this
+// client.
+func WithStaleOnError(maxAge time.Duration) Option {
+	return func(c *Client) error {
+		if maxAge <= 0 {
+			return fmt.Errorf("stale records max age must be positive")
+		}
+		c.stale = &staleRecords{
+			maxAge:   maxAge,
+			snapshot: map[string]staleSnapshot{},
+		}
+		return nil
+	}
+}
+
+// StaleSince reports whether the most recent ListAllDNSRecords call returned
+// earlier records because the API was unavailable (see WithStaleOnError).
+// If so it returns when they were fetched.
+func (c Client) StaleSince() (time.Time, bool) {
+	if c.stale == nil {
+		return time.Time{}, false
+	}
+
+	c.stale.mutex.Lock()
+	defer c.stale.mutex.Unlock()
+
+	return c.stale.since, !c.stale.since.IsZero()
+}
+
+// remember records we just fetched.
+func (s *staleRecords) remember(key string, records []DNSRecord) {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.since = time.Time{}
+	s.snapshot[key] = staleSnapshot{
+		records: append([]DNSRecord{}, records...),
+		fetched: time.Now(),
+	}
+}
+
+// markFresh notes that the last read returned current records, such as from
+// the response cache.
+func (s *staleRecords) markFresh() {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.since = time.Time{}
+}
+
+// fallback returns the records we last fetched for key if reading them
+// failed with err and they are recent enough.
+func (s *staleRecords) fallback(key string, err error) ([]DNSRecord,
+	time.Time, bool) {
+	if s == nil || !IsTemporary(err) {
+		return nil, time.Time{}, false
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot, ok := s.snapshot[key]
+	if !ok || time.Since(snapshot.fetched) > s.maxAge {
+		return nil, time.Time{}, false
+	}
+
+	s.since = snapshot.fetched
+	return append([]DNSRecord{}, snapshot.records...), snapshot.fetched, true
+}
@@ -0,0 +1,265 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// StreamVideo holds a Cloudflare Stream video.
+type StreamVideo struct {
+	UID               string            `json:"uid"`
+	Thumbnail         string            `json:"thumbnail"`
+	Preview           string            `json:"preview"`
+	ReadyToStream     bool              `json:"readyToStream"`
+	Status            StreamStatus      `json:"status"`
+	Meta              map[string]string `json:"meta"`
+	Created           string            `json:"created"`
+	Modified          string            `json:"modified"`
+	Uploaded          string            `json:"uploaded"`
+	Size              int64             `json:"size"`
+	Duration          float64           `json:"duration"`
+	RequireSignedURLs bool              `json:"requireSignedURLs"`
+	AllowedOrigins    []string          `json:"allowedOrigins"`
+	Playback          struct {
+		HLS  string `json:"hls"`
+		DASH string `json:"dash"`
+	} `json:"playback"`
+}
+
+// StreamStatus is a video's processing state.
+type StreamStatus struct {
+	// State is e.g. pendingupload, queued, inprogress, ready, or error.
+	State           string `json:"state"`
+	PctComplete     string `json:"pctComplete"`
+	ErrorReasonCode string `json:"errorReasonCode"`
+	ErrorReasonText string `json:"errorReasonText"`
+}
+
+// StreamUploadOptions are settings for a video being added to Stream.
+type StreamUploadOptions struct {
+	// Meta holds arbitrary metadata such as a name.
+	Meta map[string]string `json:"meta,omitempty"`
+
+	RequireSignedURLs bool     `json:"requireSignedURLs,omitempty"`
+	AllowedOrigins    []string `json:"allowedOrigins,omitempty"`
+
+	// MaxDurationSeconds limits the length of a direct upload. It is required
+	// for direct uploads.
+	MaxDurationSeconds int `json:"maxDurationSeconds,omitempty"`
+
+	// Expiry is when a direct upload URL stops working. Zero means the API's
+	// default of 30 minutes.
+	Expiry time.Time `json:"-"`
+}
+
+// StreamDirectUpload is a URL to which a client may upload a video without
+// credentials.
+type StreamDirectUpload struct {
+	UploadURL string `json:"uploadURL"`
+	UID       string `json:"uid"`
+}
+
+// StreamSigningKey is a key for signing Stream URLs. PEM and JWK are only
+// available when the key is created.
+type StreamSigningKey struct {
+	ID      string `json:"id"`
+	PEM     string `json:"pem,omitempty"`
+	JWK     string `json:"jwk,omitempty"`
+	Created string `json:"created"`
+}
+
+func streamPath(accountID string) string {
+	return accountPrefix(accountID) + "/stream"
+}
+
+// ListStreamVideos retrieves the account's videos. search, if not blank,
+// filters on video names.
+func (c Client) ListStreamVideos(accountID, search string) ([]StreamVideo,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	values := url.Values{}
+	if len(search) > 0 {
+		values.Set("search", search)
+	}
+
+	var videos []StreamVideo
+	err = c.apiRequest("GET", streamPath(accountID), values, nil, &videos)
+	if err != nil {
+		return nil, fmt.Errorf("list stream videos error: %w", err)
+	}
+
+	return videos, nil
+}
+
+// GetStreamVideo retrieves a video.
+func (c Client) GetStreamVideo(accountID, uid string) (StreamVideo, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return StreamVideo{}, err
+	}
+	if len(accountID) == 0 || len(uid) == 0 {
+		return StreamVideo{}, fmt.Errorf(
+			"you must provide an account ID and video ID")
+	}
+
+	var video StreamVideo
+	err = c.apiRequest("GET", streamPath(accountID)+"/"+url.QueryEscape(uid),
+		nil, nil, &video)
+	if err != nil {
+		return StreamVideo{}, fmt.Errorf("get stream video error: %w", err)
+	}
+
+	return video, nil
+}
+
+// CreateStreamDirectUpload creates a one time URL to which someone may upload
+// a video, such as from a browser. opts.MaxDurationSeconds is required.
+func (c Client) CreateStreamDirectUpload(accountID string,
+	opts StreamUploadOptions) (StreamDirectUpload, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return StreamDirectUpload{}, err
+	}
+	if len(accountID) == 0 {
+		return StreamDirectUpload{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	if opts.MaxDurationSeconds <= 0 {
+		return StreamDirectUpload{}, fmt.Errorf(
+			"you must provide a maximum duration")
+	}
+
+	payload := struct {
+		StreamUploadOptions
+		Expiry string `json:"expiry,omitempty"`
+	}{StreamUploadOptions: opts}
+	if !opts.Expiry.IsZero() {
+		payload.Expiry = opts.Expiry.UTC().Format(time.RFC3339)
+	}
+
+	var upload StreamDirectUpload
+	err = c.apiRequest("POST", streamPath(accountID)+"/direct_upload", nil,
+		payload, &upload)
+	if err != nil {
+		return StreamDirectUpload{}, fmt.Errorf(
+			"create stream direct upload error: %w", err)
+	}
+
+	return upload, nil
+}
+
+// CopyStreamVideo has Stream fetch a video from a URL.
+func (c Client) CopyStreamVideo(accountID, videoURL string,
+	opts StreamUploadOptions) (StreamVideo, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return StreamVideo{}, err
+	}
+	if len(accountID) == 0 || len(videoURL) == 0 {
+		return StreamVideo{}, fmt.Errorf(
+			"you must provide an account ID and video URL")
+	}
+
+	payload := struct {
+		StreamUploadOptions
+		URL string `json:"url"`
+	}{StreamUploadOptions: opts, URL: videoURL}
+
+	var video StreamVideo
+	err = c.apiRequest("POST", streamPath(accountID)+"/copy", nil, payload,
+		&video)
+	if err != nil {
+		return StreamVideo{}, fmt.Errorf("copy stream video error: %w", err)
+	}
+
+	return video, nil
+}
+
+// DeleteStreamVideo deletes a video.
+func (c Client) DeleteStreamVideo(accountID, uid string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(uid) == 0 {
+		return fmt.Errorf("you must provide an account ID and video ID")
+	}
+
+	err = c.apiRequest("DELETE", streamPath(accountID)+"/"+
+		url.QueryEscape(uid), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete stream video error: %w", err)
+	}
+
+	return nil
+}
+
+// ListStreamSigningKeys retrieves the account's URL signing keys.
+func (c Client) ListStreamSigningKeys(accountID string) ([]StreamSigningKey,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var keys []StreamSigningKey
+	err = c.apiRequest("GET", streamPath(accountID)+"/keys", nil, nil, &keys)
+	if err != nil {
+		return nil, fmt.Errorf("list stream signing keys error: %w", err)
+	}
+
+	return keys, nil
+}
+
+// CreateStreamSigningKey creates a URL signing key. Store the returned key
+// material: it is not available again.
+func (c Client) CreateStreamSigningKey(accountID string) (StreamSigningKey,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return StreamSigningKey{}, err
+	}
+	if len(accountID) == 0 {
+		return StreamSigningKey{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	var key StreamSigningKey
+	err = c.apiRequest("POST", streamPath(accountID)+"/keys", nil, nil, &key)
+	if err != nil {
+		return StreamSigningKey{}, fmt.Errorf(
+			"create stream signing key error: %w", err)
+	}
+
+	return key, nil
+}
+
+// DeleteStreamSigningKey deletes a URL signing key. URLs signed with it stop
+// working.
+func (c Client) DeleteStreamSigningKey(accountID, keyID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(keyID) == 0 {
+		return fmt.Errorf("you must provide an account ID and key ID")
+	}
+
+	err = c.apiRequest("DELETE", streamPath(accountID)+"/keys/"+
+		url.QueryEscape(keyID), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete stream signing key error: %w", err)
+	}
+
+	return nil
+}
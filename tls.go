@@ -0,0 +1,275 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// TotalTLS holds a zone's Total TLS state. With Total TLS, Cloudflare issues
+// certificates for every proxied hostname, including deep subdomains the
+// Universal SSL certificate does not cover.
+type TotalTLS struct {
+	Enabled bool `json:"enabled"`
+
+	// CertificateAuthority is the CA to use, e.g. google or lets_encrypt.
+	// Leave it blank for the default.
+	CertificateAuthority string `json:"certificate_authority,omitempty"`
+
+	// ValidityPeriod is the certificates' validity in days. Read only.
+	ValidityPeriod int `json:"validity_period,omitempty"`
+}
+
+// HSTS holds HTTP Strict Transport Security settings. Cloudflare calls this
+// the security_header setting.
+type HSTS struct {
+	Enabled           bool `json:"enabled"`
+	MaxAge            int  `json:"max_age"`
+	IncludeSubdomains bool `json:"include_subdomains"`
+	Preload           bool `json:"preload"`
+
+	// NoSniff adds the X-Content-Type-Options: nosniff header.
+	NoSniff bool `json:"nosniff"`
+}
+
+// Minimum TLS versions.
+const (
+	TLSVersion10 = "1.0"
+	TLSVersion11 = "1.1"
+	TLSVersion12 = "1.2"
+	TLSVersion13 = "1.3"
+)
+
+// GetTotalTLS retrieves a zone's Total TLS state.
+func (c Client) GetTotalTLS(zoneID string) (TotalTLS, error) {
+	if len(zoneID) == 0 {
+		return TotalTLS{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var totalTLS TotalTLS
+	err := c.apiRequest("GET", zonePrefix(zoneID)+"/acm/total_tls", nil, nil,
+		&totalTLS)
+	if err != nil {
+		return TotalTLS{}, fmt.Errorf("get total TLS error: %w", err)
+	}
+
+	return totalTLS, nil
+}
+
+// SetTotalTLS turns Total TLS on or off. ca may be blank to use the default
+// certificate authority.
+func (c Client) SetTotalTLS(zoneID string, enabled bool, ca string) (TotalTLS,
+	error) {
+	if len(zoneID) == 0 {
+		return TotalTLS{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	payload := TotalTLS{Enabled: enabled, CertificateAuthority: ca}
+
+	var totalTLS TotalTLS
+	err := c.apiRequest("POST", zonePrefix(zoneID)+"/acm/total_tls", nil,
+		payload, &totalTLS)
+	if err != nil {
+		return TotalTLS{}, fmt.Errorf("set total TLS error: %w", err)
+	}
+
+	return totalTLS, nil
+}
+
+// GetMinTLSVersion retrieves the lowest TLS version a zone accepts.
+func (c Client) GetMinTLSVersion(zoneID string) (string, error) {
+	return c.GetZoneSettingString(zoneID, SettingMinTLSVersion)
+}
+
+// SetMinTLSVersion sets the lowest TLS version a zone accepts. version is one
+// of the TLSVersion constants.
+func (c Client) SetMinTLSVersion(zoneID, version string) error {
+	switch version {
+	case TLSVersion10, TLSVersion11, TLSVersion12, TLSVersion13:
+	default:
+		return fmt.Errorf("invalid TLS version: %s", version)
+	}
+
+	_, err := c.UpdateZoneSetting(zoneID, SettingMinTLSVersion, version)
+	return err
+}
+
+// GetTLS13 retrieves whether a zone supports TLS 1.3. The value is on, off,
+// or zrt (on with 0-RTT).
+func (c Client) GetTLS13(zoneID string) (string, error) {
+	return c.GetZoneSettingString(zoneID, SettingTLS13)
+}
+
+// SetTLS13 sets whether a zone supports TLS 1.3. value is on, off, or zrt.
+func (c Client) SetTLS13(zoneID, value string) error {
+	switch value {
+	case "on", "off", "zrt":
+	default:
+		return fmt.Errorf("invalid TLS 1.3 value: %s", value)
+	}
+
+	_, err := c.UpdateZoneSetting(zoneID, SettingTLS13, value)
+	return err
+}
+
+// GetAlwaysUseHTTPS retrieves whether a zone redirects HTTP to HTTPS.
+func (c Client) GetAlwaysUseHTTPS(zoneID string) (bool, error) {
+	return c.getOnOffSetting(zoneID, SettingAlwaysUseHTTPS)
+}
+
+// SetAlwaysUseHTTPS sets whether a zone redirects HTTP to HTTPS.
+func (c Client) SetAlwaysUseHTTPS(zoneID string, enabled bool) error {
+	return c.setOnOffSetting(zoneID, SettingAlwaysUseHTTPS, enabled)
+}
+
+// GetAutomaticHTTPSRewrites retrieves whether a zone rewrites HTTP links in
+// pages to HTTPS.
+func (c Client) GetAutomaticHTTPSRewrites(zoneID string) (bool, error) {
+	return c.getOnOffSetting(zoneID, SettingAutomaticHTTPSRewrites)
+}
+
+// SetAutomaticHTTPSRewrites sets whether a zone rewrites HTTP links in pages
+// to HTTPS.
+func (c Client) SetAutomaticHTTPSRewrites(zoneID string, enabled bool) error {
+	return c.setOnOffSetting(zoneID, SettingAutomaticHTTPSRewrites, enabled)
+}
+
+// GetHSTS retrieves a zone's HSTS settings.
+func (c Client) GetHSTS(zoneID string) (HSTS, error) {
+	setting, err := c.GetZoneSetting(zoneID, SettingSecurityHeader)
+	if err != nil {
+		return HSTS{}, err
+	}
+
+	var value securityHeader
+	err = json.Unmarshal(setting.Value, &value)
+	if err != nil {
+		return HSTS{}, fmt.Errorf("invalid security_header value: %w", err)
+	}
+
+	return value.StrictTransportSecurity, nil
+}
+
+// SetHSTS changes a zone's HSTS settings.
+//
+// Be careful: once browsers see the header they refuse plain HTTP to the
+// zone for MaxAge seconds, and preloading is hard to undo.
+func (c Client) SetHSTS(zoneID string, hsts HSTS) error {
+	if hsts.MaxAge < 0 {
+		return fmt.Errorf("HSTS max age may not be negative")
+	}
+
+	_, err := c.UpdateZoneSetting(zoneID, SettingSecurityHeader,
+		securityHeader{StrictTransportSecurity: hsts})
+	return err
+}
+
+// securityHeader is the value of the security_header setting.
+type securityHeader struct {
+	StrictTransportSecurity HSTS `json:"strict_transport_security"`
+}
+
+func (c Client) getOnOffSetting(zoneID, name string) (bool, error) {
+	value, err := c.GetZoneSettingString(zoneID, name)
+	if err != nil {
+		return false, err
+	}
+	return value == "on", nil
+}
+
+func (c Client) setOnOffSetting(zoneID, name string, enabled bool) error {
+	_, err := c.UpdateZoneSetting(zoneID, name, onOff(enabled))
+	return err
+}
+
+// HostnameTLSSetting is the value of a TLS setting for a single hostname,
+// overriding the zone's value.
+type HostnameTLSSetting struct {
+	Hostname string `json:"hostname"`
+
+	// Value is a string for min_tls_version and http2, and a list of strings
+	// for ciphers.
+	Value     json.RawMessage `json:"value"`
+	Status    string          `json:"status"`
+	CreatedAt string          `json:"created_at"`
+	UpdatedAt string          `json:"updated_at"`
+}
+
+// Settings that may be set per hostname.
+const (
+	HostnameTLSMinVersion = "min_tls_version"
+	HostnameTLSCiphers    = "ciphers"
+	HostnameTLSHTTP2      = "http2"
+)
+
+// ListHostnameTLSSettings retrieves the hostnames with their own value for a
+// TLS setting, along with those values.
+func (c Client) ListHostnameTLSSettings(zoneID, setting string) (
+	[]HostnameTLSSetting, error) {
+	if len(zoneID) == 0 || len(setting) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID and setting")
+	}
+
+	var settings []HostnameTLSSetting
+	err := c.apiRequest("GET", zonePrefix(zoneID)+"/hostnames/settings/"+
+		url.QueryEscape(setting), nil, nil, &settings)
+	if err != nil {
+		return nil, fmt.Errorf("list hostname TLS settings error: %w", err)
+	}
+
+	return settings, nil
+}
+
+// SetHostnameTLSSetting sets a TLS setting for a single hostname, such as a
+// custom hostname. value is e.g. "1.2" for min_tls_version or a []string for
+// ciphers.
+func (c Client) SetHostnameTLSSetting(zoneID, setting, hostname string,
+	value interface{}) (HostnameTLSSetting, error) {
+	if len(zoneID) == 0 || len(setting) == 0 || len(hostname) == 0 {
+		return HostnameTLSSetting{}, fmt.Errorf(
+			"you must provide a zone ID, setting, and hostname")
+	}
+
+	if setting == HostnameTLSMinVersion {
+		switch value {
+		case TLSVersion10, TLSVersion11, TLSVersion12, TLSVersion13:
+		default:
+			return HostnameTLSSetting{}, fmt.Errorf("invalid TLS version: %v",
+				value)
+		}
+	}
+
+	payload := map[string]interface{}{"value": value}
+
+	var updated HostnameTLSSetting
+	err := c.apiRequest("PUT", hostnameTLSSettingPath(zoneID, setting,
+		hostname), nil, payload, &updated)
+	if err != nil {
+		return HostnameTLSSetting{}, fmt.Errorf(
+			"set hostname TLS setting error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteHostnameTLSSetting removes a hostname's value for a TLS setting so
+// the zone's value applies again.
+func (c Client) DeleteHostnameTLSSetting(zoneID, setting,
+	hostname string) error {
+	if len(zoneID) == 0 || len(setting) == 0 || len(hostname) == 0 {
+		return fmt.Errorf("you must provide a zone ID, setting, and hostname")
+	}
+
+	err := c.apiRequest("DELETE", hostnameTLSSettingPath(zoneID, setting,
+		hostname), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete hostname TLS setting error: %w", err)
+	}
+
+	return nil
+}
+
+func hostnameTLSSettingPath(zoneID, setting, hostname string) string {
+	return zonePrefix(zoneID) + "/hostnames/settings/" +
+		url.QueryEscape(setting) + "/" + url.QueryEscape(hostname)
+}
@@ -0,0 +1,133 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// ManagedTransforms holds the state of a zone's managed transforms. These are
+// header modifications Cloudflare provides that you turn on or off, such as
+// adding visitor location headers.
+type ManagedTransforms struct {
+	RequestHeaders  []ManagedTransform `json:"managed_request_headers"`
+	ResponseHeaders []ManagedTransform `json:"managed_response_headers"`
+}
+
+// ManagedTransform is a single managed transform.
+type ManagedTransform struct {
+	ID      string `json:"id"`
+	Enabled bool   `json:"enabled"`
+
+	// HasConflict is set if the transform can't be enabled because of another
+	// enabled transform. ConflictsWith lists those.
+	HasConflict   bool     `json:"has_conflict,omitempty"`
+	ConflictsWith []string `json:"conflicts_with,omitempty"`
+}
+
+// GetManagedTransforms retrieves the zone's managed transforms.
+func (c Client) GetManagedTransforms(zoneID string) (ManagedTransforms, error) {
+	if len(zoneID) == 0 {
+		return ManagedTransforms{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var transforms ManagedTransforms
+	err := c.apiRequest("GET", "zones/"+url.QueryEscape(zoneID)+
+		"/managed_headers", nil, nil, &transforms)
+	if err != nil {
+		return ManagedTransforms{},
+			fmt.Errorf("get managed transforms error: %w", err)
+	}
+
+	return transforms, nil
+}
+
+// UpdateManagedTransforms turns managed transforms on or off. Only the ID and
+// Enabled fields are used, and transforms not given are left alone. We return
+// the state of all managed transforms afterwards.
+func (c Client) UpdateManagedTransforms(zoneID string,
+	transforms ManagedTransforms) (ManagedTransforms, error) {
+	if len(zoneID) == 0 {
+		return ManagedTransforms{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	// The API requires both lists be present.
+	payload := ManagedTransforms{
+		RequestHeaders:  stripManagedTransforms(transforms.RequestHeaders),
+		ResponseHeaders: stripManagedTransforms(transforms.ResponseHeaders),
+	}
+
+	var updated ManagedTransforms
+	err := c.apiRequest("PATCH", "zones/"+url.QueryEscape(zoneID)+
+		"/managed_headers", nil, payload, &updated)
+	if err != nil {
+		return ManagedTransforms{},
+			fmt.Errorf("update managed transforms error: %w", err)
+	}
+
+	return updated, nil
+}
+
+func stripManagedTransforms(transforms []ManagedTransform) []ManagedTransform {
+	stripped := []ManagedTransform{}
+	for _, t := range transforms {
+		stripped = append(stripped, ManagedTransform{ID: t.ID, Enabled: t.Enabled})
+	}
+	return stripped
+}
+
+// Header operations for header modification rules.
+const (
+	HeaderSet    = "set"
+	HeaderAdd    = "add"
+	HeaderRemove = "remove"
+)
+
+// URLRewriteRule builds a rule for the http_request_transform phase that
+// rewrites the path and/or query of matching requests to static values. Leave
+// path or query empty to not change it.
+//
+// For dynamic rewrites, set ActionParameters.URI yourself using Expression.
+func URLRewriteRule(expression, description, path,
+	query string) RulesetRule {
+	uri := &RewriteURI{}
+	if len(path) > 0 {
+		uri.Path = &RewriteValue{Value: path}
+	}
+	if len(query) > 0 {
+		uri.Query = &RewriteValue{Value: query}
+	}
+
+	return RulesetRule{
+		Action:           "rewrite",
+		ActionParameters: &RulesetActionParameters{URI: uri},
+		Expression:       expression,
+		Description:      description,
+		Enabled:          true,
+	}
+}
+
+// HeaderRule builds a header modification rule. Use it in the
+// http_request_late_transform phase for request headers or the
+// http_response_headers_transform phase for response headers.
+//
+// headers maps header names to the modification to make.
+func HeaderRule(expression, description string,
+	headers map[string]RewriteHeader) RulesetRule {
+	return RulesetRule{
+		Action:           "rewrite",
+		ActionParameters: &RulesetActionParameters{Headers: headers},
+		Expression:       expression,
+		Description:      description,
+		Enabled:          true,
+	}
+}
+
+// SetHeader is a header modification setting a header to a static value.
+func SetHeader(value string) RewriteHeader {
+	return RewriteHeader{Operation: HeaderSet, Value: value}
+}
+
+// RemoveHeader is a header modification removing a header.
+func RemoveHeader() RewriteHeader {
+	return RewriteHeader{Operation: HeaderRemove}
+}
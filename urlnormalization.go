@@ -0,0 +1,110 @@
+package cloudflare
+
+import "fmt"
+
+// URL normalization types.
+const (
+	URLNormalizationCloudflare = "cloudflare"
+	URLNormalizationRFC3986    = "rfc3986"
+)
+
+// URL normalization scopes.
+const (
+	// URLNormalizationScopeIncoming normalizes URLs for rules, but sends them
+	// to the origin as they came.
+	URLNormalizationScopeIncoming = "incoming"
+
+	// URLNormalizationScopeBoth also sends normalized URLs to the origin.
+	URLNormalizationScopeBoth = "both"
+
+	// URLNormalizationScopeNone turns normalization off.
+	URLNormalizationScopeNone = "none"
+)
+
+// URLNormalization holds how a zone normalizes request URLs before rules
+// (such as WAF rules) see them.
+type URLNormalization struct {
+	// Type is one of the URLNormalization constants.
+	Type string `json:"type"`
+
+	// Scope is one of the URLNormalizationScope constants.
+	Scope string `json:"scope"`
+}
+
+// Pseudo IPv4 values.
+const (
+	PseudoIPv4Off             = "off"
+	PseudoIPv4AddHeader       = "add_header"
+	PseudoIPv4OverwriteHeader = "overwrite_header"
+)
+
+// GetURLNormalization retrieves a zone's URL normalization settings.
+func (c Client) GetURLNormalization(zoneID string) (URLNormalization, error) {
+	if len(zoneID) == 0 {
+		return URLNormalization{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var normalization URLNormalization
+	err := c.apiRequest("GET", zonePrefix(zoneID)+"/url_normalization", nil,
+		nil, &normalization)
+	if err != nil {
+		return URLNormalization{}, fmt.Errorf(
+			"get URL normalization error: %w", err)
+	}
+
+	return normalization, nil
+}
+
+// UpdateURLNormalization changes a zone's URL normalization settings. We
+// return them as updated.
+func (c Client) UpdateURLNormalization(zoneID string,
+	normalization URLNormalization) (URLNormalization, error) {
+	if len(zoneID) == 0 {
+		return URLNormalization{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	switch normalization.Type {
+	case URLNormalizationCloudflare, URLNormalizationRFC3986:
+	default:
+		return URLNormalization{}, fmt.Errorf(
+			"invalid URL normalization type: %q", normalization.Type)
+	}
+
+	switch normalization.Scope {
+	case URLNormalizationScopeIncoming, URLNormalizationScopeBoth,
+		URLNormalizationScopeNone:
+	default:
+		return URLNormalization{}, fmt.Errorf(
+			"invalid URL normalization scope: %q", normalization.Scope)
+	}
+
+	var updated URLNormalization
+	err := c.apiRequest("PUT", zonePrefix(zoneID)+"/url_normalization", nil,
+		normalization, &updated)
+	if err != nil {
+		return URLNormalization{}, fmt.Errorf(
+			"update URL normalization error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// GetPseudoIPv4 retrieves a zone's Pseudo IPv4 setting, one of the
+// PseudoIPv4 constants. With Pseudo IPv4, Cloudflare gives IPv6 clients an
+// IPv4 address in a header for origins that only understand IPv4.
+func (c Client) GetPseudoIPv4(zoneID string) (string, error) {
+	return c.GetZoneSettingString(zoneID, SettingPseudoIPv4)
+}
+
+// SetPseudoIPv4 changes a zone's Pseudo IPv4 setting. value is one of the
+// PseudoIPv4 constants.
+func (c Client) SetPseudoIPv4(zoneID, value string) error {
+	switch value {
+	case PseudoIPv4Off, PseudoIPv4AddHeader, PseudoIPv4OverwriteHeader:
+	default:
+		return fmt.Errorf("invalid Pseudo IPv4 value: %q", value)
+	}
+
+	_, err := c.UpdateZoneSetting(zoneID, SettingPseudoIPv4, value)
+	return err
+}
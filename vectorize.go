@@ -0,0 +1,242 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Vectorize distance metrics.
+const (
+	VectorizeCosine     = "cosine"
+	VectorizeEuclidean  = "euclidean"
+	VectorizeDotProduct = "dot-product"
+)
+
+// Vectorize metadata index types.
+const (
+	VectorizeMetadataString  = "string"
+	VectorizeMetadataNumber  = "number"
+	VectorizeMetadataBoolean = "boolean"
+)
+
+// VectorizeIndex is a Vectorize vector database.
+type VectorizeIndex struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description,omitempty"`
+	Config      VectorizeIndexConfig `json:"config"`
+	CreatedOn   string               `json:"created_on,omitempty"`
+	ModifiedOn  string               `json:"modified_on,omitempty"`
+}
+
+// VectorizeIndexConfig is the shape of an index's vectors. Give either
+// Dimensions and Metric, or Preset to match an embedding model, e.g.
+// @cf/baai/bge-small-en-v1.5.
+type VectorizeIndexConfig struct {
+	Dimensions int    `json:"dimensions,omitempty"`
+	Metric     string `json:"metric,omitempty"`
+	Preset     string `json:"preset,omitempty"`
+}
+
+// VectorizeMetadataIndex lets queries filter on a metadata property.
+type VectorizeMetadataIndex struct {
+	PropertyName string `json:"propertyName"`
+
+	// IndexType is one of the VectorizeMetadata constants.
+	IndexType string `json:"indexType"`
+}
+
+func vectorizePath(accountID string) string {
+	return accountPrefix(accountID) + "/vectorize/v2/indexes"
+}
+
+func vectorizeIndexPath(accountID, name string) string {
+	return vectorizePath(accountID) + "/" + url.QueryEscape(name)
+}
+
+// ListVectorizeIndexes lists an account's Vectorize indexes.
+func (c Client) ListVectorizeIndexes(accountID string) ([]VectorizeIndex,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var indexes []VectorizeIndex
+	err = c.apiRequest("GET", vectorizePath(accountID), nil, nil, &indexes)
+	if err != nil {
+		return nil, fmt.Errorf("list Vectorize indexes error: %w", err)
+	}
+
+	return indexes, nil
+}
+
+// GetVectorizeIndex retrieves a Vectorize index.
+func (c Client) GetVectorizeIndex(accountID, name string) (VectorizeIndex,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return VectorizeIndex{}, err
+	}
+	if len(accountID) == 0 || len(name) == 0 {
+		return VectorizeIndex{}, fmt.Errorf(
+			"you must provide an account ID and index name")
+	}
+
+	var index VectorizeIndex
+	err = c.apiRequest("GET", vectorizeIndexPath(accountID, name), nil, nil,
+		&index)
+	if err != nil {
+		return VectorizeIndex{}, fmt.Errorf("get Vectorize index error: %w", err)
+	}
+
+	return index, nil
+}
+
+// CreateVectorizeIndex creates a Vectorize index. Its config cannot be
+// changed afterwards.
+func (c Client) CreateVectorizeIndex(accountID string,
+	index VectorizeIndex) (VectorizeIndex, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return VectorizeIndex{}, err
+	}
+	if len(accountID) == 0 || len(index.Name) == 0 {
+		return VectorizeIndex{}, fmt.Errorf(
+			"you must provide an account ID and index name")
+	}
+
+	config := index.Config
+	if len(config.Preset) > 0 {
+		if config.Dimensions != 0 || len(config.Metric) > 0 {
+			return VectorizeIndex{}, fmt.Errorf(
+				"give either a preset or dimensions and metric, not both")
+		}
+	} else {
+		if config.Dimensions <= 0 {
+			return VectorizeIndex{}, fmt.Errorf(
+				"you must provide the number of dimensions or a preset")
+		}
+		switch config.Metric {
+		case VectorizeCosine, VectorizeEuclidean, VectorizeDotProduct:
+		default:
+			return VectorizeIndex{}, fmt.Errorf("invalid metric: %q",
+				config.Metric)
+		}
+	}
+
+	index.CreatedOn = ""
+	index.ModifiedOn = ""
+
+	var created VectorizeIndex
+	err = c.apiRequest("POST", vectorizePath(accountID), nil, index, &created)
+	if err != nil {
+		return VectorizeIndex{}, fmt.Errorf(
+			"create Vectorize index error: %w", err)
+	}
+
+	return created, nil
+}
+
+// DeleteVectorizeIndex deletes a Vectorize index and its vectors.
+func (c Client) DeleteVectorizeIndex(accountID, name string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(name) == 0 {
+		return fmt.Errorf("you must provide an account ID and index name")
+	}
+
+	err = c.apiRequest("DELETE", vectorizeIndexPath(accountID, name), nil,
+		nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete Vectorize index error: %w", err)
+	}
+
+	return nil
+}
+
+// ListVectorizeMetadataIndexes lists the metadata properties an index's
+// queries may filter on.
+func (c Client) ListVectorizeMetadataIndexes(accountID,
+	name string) ([]VectorizeMetadataIndex, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 || len(name) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID and index name")
+	}
+
+	var result struct {
+		MetadataIndexes []VectorizeMetadataIndex `json:"metadataIndexes"`
+	}
+	err = c.apiRequest("GET", vectorizeIndexPath(accountID, name)+
+		"/metadata_index/list", nil, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("list Vectorize metadata indexes error: %w", err)
+	}
+
+	return result.MetadataIndexes, nil
+}
+
+// CreateVectorizeMetadataIndex lets an index's queries filter on a metadata
+// property. Only vectors inserted afterwards are indexed.
+func (c Client) CreateVectorizeMetadataIndex(accountID, name string,
+	metadataIndex VectorizeMetadataIndex) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(name) == 0 {
+		return fmt.Errorf("you must provide an account ID and index name")
+	}
+
+	if len(metadataIndex.PropertyName) == 0 {
+		return fmt.Errorf("you must provide a property name")
+	}
+
+	switch metadataIndex.IndexType {
+	case VectorizeMetadataString, VectorizeMetadataNumber,
+		VectorizeMetadataBoolean:
+	default:
+		return fmt.Errorf("invalid metadata index type: %q",
+			metadataIndex.IndexType)
+	}
+
+	err = c.apiRequest("POST", vectorizeIndexPath(accountID, name)+
+		"/metadata_index/create", nil, metadataIndex, nil)
+	if err != nil {
+		return fmt.Errorf("create Vectorize metadata index error: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteVectorizeMetadataIndex stops indexing a metadata property.
+func (c Client) DeleteVectorizeMetadataIndex(accountID, name,
+	propertyName string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(name) == 0 || len(propertyName) == 0 {
+		return fmt.Errorf(
+			"you must provide an account ID, index name, and property name")
+	}
+
+	payload := struct {
+		PropertyName string `json:"propertyName"`
+	}{propertyName}
+
+	err = c.apiRequest("POST", vectorizeIndexPath(accountID, name)+
+		"/metadata_index/delete", nil, payload, nil)
+	if err != nil {
+		return fmt.Errorf("delete Vectorize metadata index error: %w", err)
+	}
+
+	return nil
+}
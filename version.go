@@ -0,0 +1,60 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+)
+
+// Version is the version of this package.
+const Version = "0.1.0"
+
+// DefaultUserAgent is the User-Agent header we send unless WithUserAgent
+// gives another.
+const DefaultUserAgent = "horgh-cloudflare/" + Version
+
+// WithUserAgent sets the User-Agent header we send, e.g. to identify your
+// program to Cloudflare. We add our own after it.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		if len(userAgent) == 0 {
+			return fmt.Errorf("user agent may not be blank")
+		}
+		c.userAgent = userAgent + " " + DefaultUserAgent
+		return nil
+	}
+}
+
+// Ping makes a cheap authenticated request to check we can reach the API
+// and that our credentials work. For an API token it also checks the token
+// is active.
+//
+// A rejected key or token gives an error for which IsAuthError is true.
+func (c Client) Ping() error {
+	return c.PingContext(context.Background())
+}
+
+// PingContext is Ping with a context.
+func (c Client) PingContext(ctx context.Context) error {
+	if !c.usesToken() {
+		_, err := c.apiRequestContext(ctx, "GET", "user", nil, nil, nil)
+		if err != nil {
+			return fmt.Errorf("ping error: %w", err)
+		}
+		return nil
+	}
+
+	var result struct {
+		Status string `json:"status"`
+	}
+	_, err := c.apiRequestContext(ctx, "GET", "user/tokens/verify", nil, nil,
+		&result)
+	if err != nil {
+		return fmt.Errorf("ping error: %w", err)
+	}
+
+	if result.Status != "active" {
+		return fmt.Errorf("token is %s: %w", result.Status, ErrAuth)
+	}
+
+	return nil
+}
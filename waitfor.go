@@ -0,0 +1,141 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// How long WaitFor waits between checks. It starts at the minimum and
+// doubles each time up to the maximum.
+const (
+	waitMinInterval = 2 * time.Second
+	waitMaxInterval = 30 * time.Second
+)
+
+// WaitFor calls check until it reports the resource is ready, returns an
+// error, or ctx is done. Use it for resources that change state in the
+// background, such as certificates being issued:
+//
+//	cert, err := cloudflare.WaitFor(ctx,
+//		func() (cloudflare.CustomCertificate, bool, error) {
+//			cert, err := client.GetCustomCertificate(zoneID, certID)
+//			return cert, err == nil && cert.Status == "active", err
+//		})
+//
+// Checks back off from 2 to 30 seconds apart. Temporary errors (see
+// IsTemporary) are retried rather than returned. Set a deadline on ctx to
+// bound the wait. If it ends first, we return the last value checked.
+func WaitFor[T any](ctx context.Context,
+	check func() (T, bool, error)) (T, error) {
+	interval := waitMinInterval
+
+	for {
+		value, ready, err := check()
+		if err != nil && !IsTemporary(err) {
+			return value, err
+		}
+
+		if err == nil && ready {
+			return value, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return value, fmt.Errorf("%w (last error: %s)", ctx.Err(), err)
+			}
+			return value, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		interval = min(interval*2, waitMaxInterval)
+	}
+}
+
+// WaitForZoneActive waits for a zone to become active, i.e. for Cloudflare
+// to see its registrar pointing at our nameservers. This can take hours.
+func (c Client) WaitForZoneActive(ctx context.Context, zoneID string) (Zone,
+	error) {
+	zone, err := WaitFor(ctx, func() (Zone, bool, error) {
+		zone, err := c.GetZone(zoneID)
+		if err != nil {
+			return zone, false, err
+		}
+
+		if zone.Status == "moved" || zone.Status == "deleted" {
+			return zone, false, fmt.Errorf("zone %s is %s", zoneID, zone.Status)
+		}
+
+		return zone, zone.Status == "active", nil
+	})
+	if err != nil {
+		return zone, fmt.Errorf("zone %s not active: %w", zoneID, err)
+	}
+
+	return zone, nil
+}
+
+// WaitForCertificateActive waits for a custom certificate to be deployed to
+// Cloudflare's edge.
+func (c Client) WaitForCertificateActive(ctx context.Context, zoneID,
+	certificateID string) (CustomCertificate, error) {
+	cert, err := WaitFor(ctx, func() (CustomCertificate, bool, error) {
+		cert, err := c.GetCustomCertificate(zoneID, certificateID)
+		if err != nil {
+			return cert, false, err
+		}
+
+		if cert.Status == "expired" || cert.Status == "deleted" {
+			return cert, false, fmt.Errorf("certificate %s is %s", certificateID,
+				cert.Status)
+		}
+
+		return cert, cert.Status == "active", nil
+	})
+	if err != nil {
+		return cert, fmt.Errorf("certificate %s not active: %w", certificateID,
+			err)
+	}
+
+	return cert, nil
+}
+
+// WaitForCustomHostnameValidated waits for a custom hostname and its
+// certificate to be validated and active. Validation waits on the customer
+// adding the validation records (see the hostname's OwnershipVerification
+// and SSL.ValidationRecords).
+func (c Client) WaitForCustomHostnameValidated(ctx context.Context, zoneID,
+	hostnameID string) (CustomHostname, error) {
+	hostname, err := WaitFor(ctx, func() (CustomHostname, bool, error) {
+		hostname, err := c.GetCustomHostname(zoneID, hostnameID)
+		if err != nil {
+			return hostname, false, err
+		}
+
+		switch {
+		case hostname.Status == "moved" || hostname.Status == "deleted":
+			return hostname, false, fmt.Errorf("custom hostname %s is %s",
+				hostname.Hostname, hostname.Status)
+		case hostname.SSL.Status == "validation_timed_out" ||
+			hostname.SSL.Status == "deleted":
+			var errs []string
+			for _, e := range hostname.SSL.ValidationErrors {
+				errs = append(errs, e.Message)
+			}
+			return hostname, false, fmt.Errorf(
+				"custom hostname %s certificate is %s: %s", hostname.Hostname,
+				hostname.SSL.Status, strings.Join(errs, "; "))
+		}
+
+		return hostname, hostname.Status == "active" &&
+			hostname.SSL.Status == "active", nil
+	})
+	if err != nil {
+		return hostname, fmt.Errorf("custom hostname %s not validated: %w",
+			hostnameID, err)
+	}
+
+	return hostname, nil
+}
@@ -0,0 +1,135 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Web3Hostname holds a hostname serving content from an Ethereum or IPFS
+// gateway.
+type Web3Hostname struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Status      string `json:"status,omitempty"`
+
+	// Target is one of the Web3Target constants.
+	Target string `json:"target"`
+
+	// DNSLink is the DNSLink value, e.g. /ipns/onboarding.ipfs.cloudflare.com.
+	// Only for IPFS.
+	DNSLink string `json:"dnslink,omitempty"`
+
+	CreatedOn  string `json:"created_on,omitempty"`
+	ModifiedOn string `json:"modified_on,omitempty"`
+}
+
+// Web3 hostname targets.
+const (
+	Web3TargetEthereum          = "ethereum"
+	Web3TargetIPFS              = "ipfs"
+	Web3TargetIPFSUniversalPath = "ipfs_universal_path"
+)
+
+func web3Path(zoneID string) string {
+	return zonePrefix(zoneID) + "/web3/hostnames"
+}
+
+// ListWeb3Hostnames retrieves a zone's Web3 hostnames.
+func (c Client) ListWeb3Hostnames(zoneID string) ([]Web3Hostname, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var hostnames []Web3Hostname
+	err := c.apiRequest("GET", web3Path(zoneID), nil, nil, &hostnames)
+	if err != nil {
+		return nil, fmt.Errorf("list web3 hostnames error: %w", err)
+	}
+
+	return hostnames, nil
+}
+
+// GetWeb3Hostname retrieves a Web3 hostname.
+func (c Client) GetWeb3Hostname(zoneID, id string) (Web3Hostname, error) {
+	if len(zoneID) == 0 || len(id) == 0 {
+		return Web3Hostname{}, fmt.Errorf(
+			"you must provide a zone ID and hostname ID")
+	}
+
+	var hostname Web3Hostname
+	err := c.apiRequest("GET", web3Path(zoneID)+"/"+url.QueryEscape(id), nil,
+		nil, &hostname)
+	if err != nil {
+		return Web3Hostname{}, fmt.Errorf("get web3 hostname error: %w", err)
+	}
+
+	return hostname, nil
+}
+
+// CreateWeb3Hostname creates a Web3 hostname. Name and Target are required.
+func (c Client) CreateWeb3Hostname(zoneID string,
+	hostname Web3Hostname) (Web3Hostname, error) {
+	if len(zoneID) == 0 {
+		return Web3Hostname{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	if len(hostname.Name) == 0 || len(hostname.Target) == 0 {
+		return Web3Hostname{}, fmt.Errorf(
+			"you must provide a hostname and target")
+	}
+
+	payload := Web3Hostname{
+		Name:        hostname.Name,
+		Target:      hostname.Target,
+		Description: hostname.Description,
+		DNSLink:     hostname.DNSLink,
+	}
+
+	var created Web3Hostname
+	err := c.apiRequest("POST", web3Path(zoneID), nil, payload, &created)
+	if err != nil {
+		return Web3Hostname{}, fmt.Errorf("create web3 hostname error: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateWeb3Hostname changes a Web3 hostname's description and DNSLink. The
+// other fields may not be changed.
+func (c Client) UpdateWeb3Hostname(zoneID string,
+	hostname Web3Hostname) (Web3Hostname, error) {
+	if len(zoneID) == 0 || len(hostname.ID) == 0 {
+		return Web3Hostname{}, fmt.Errorf(
+			"you must provide a zone ID and hostname ID")
+	}
+
+	payload := struct {
+		Description string `json:"description"`
+		DNSLink     string `json:"dnslink,omitempty"`
+	}{hostname.Description, hostname.DNSLink}
+
+	var updated Web3Hostname
+	err := c.apiRequest("PATCH", web3Path(zoneID)+"/"+
+		url.QueryEscape(hostname.ID), nil, payload, &updated)
+	if err != nil {
+		return Web3Hostname{}, fmt.Errorf("update web3 hostname error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteWeb3Hostname deletes a Web3 hostname.
+func (c Client) DeleteWeb3Hostname(zoneID, id string) error {
+	if len(zoneID) == 0 || len(id) == 0 {
+		return fmt.Errorf("you must provide a zone ID and hostname ID")
+	}
+
+	err := c.apiRequest("DELETE", web3Path(zoneID)+"/"+url.QueryEscape(id),
+		nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete web3 hostname error: %w", err)
+	}
+
+	return nil
+}
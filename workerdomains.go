@@ -0,0 +1,214 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// WorkerDomain attaches a hostname to a Worker. Cloudflare creates the DNS
+// record and certificate for it.
+type WorkerDomain struct {
+	ID          string `json:"id,omitempty"`
+	Hostname    string `json:"hostname"`
+	Service     string `json:"service"`
+	Environment string `json:"environment"`
+	ZoneID      string `json:"zone_id"`
+	ZoneName    string `json:"zone_name,omitempty"`
+}
+
+// WorkerDomainListOptions filters the domains ListWorkerDomains lists. Fields
+// may be blank to not filter on them.
+type WorkerDomainListOptions struct {
+	Hostname string
+	Service  string
+	ZoneID   string
+}
+
+func workerDomainsPath(accountID string) string {
+	return accountPrefix(accountID) + "/workers/domains"
+}
+
+// ListWorkerDomains lists the custom domains attached to an account's
+// Workers.
+func (c Client) ListWorkerDomains(accountID string,
+	opts WorkerDomainListOptions) ([]WorkerDomain, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	values := url.Values{}
+	if len(opts.Hostname) > 0 {
+		values.Set("hostname", opts.Hostname)
+	}
+	if len(opts.Service) > 0 {
+		values.Set("service", opts.Service)
+	}
+	if len(opts.ZoneID) > 0 {
+		values.Set("zone_id", opts.ZoneID)
+	}
+
+	var domains []WorkerDomain
+	err = c.apiRequest("GET", workerDomainsPath(accountID), values, nil,
+		&domains)
+	if err != nil {
+		return nil, fmt.Errorf("list Worker domains error: %w", err)
+	}
+
+	return domains, nil
+}
+
+// AttachWorkerDomain attaches a custom domain to a Worker. Hostname, Service
+// (the script name), and ZoneID are required. Environment defaults to
+// production. If the hostname is attached to another Worker, it moves to this
+// one.
+func (c Client) AttachWorkerDomain(accountID string,
+	domain WorkerDomain) (WorkerDomain, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return WorkerDomain{}, err
+	}
+	if len(accountID) == 0 {
+		return WorkerDomain{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	if len(domain.Hostname) == 0 || len(domain.Service) == 0 ||
+		len(domain.ZoneID) == 0 {
+		return WorkerDomain{}, fmt.Errorf(
+			"you must provide a hostname, service, and zone ID")
+	}
+
+	if len(domain.Environment) == 0 {
+		domain.Environment = "production"
+	}
+	domain.ID = ""
+	domain.ZoneName = ""
+
+	var attached WorkerDomain
+	err = c.apiRequest("PUT", workerDomainsPath(accountID), nil, domain,
+		&attached)
+	if err != nil {
+		return WorkerDomain{}, fmt.Errorf("attach Worker domain error: %w", err)
+	}
+
+	return attached, nil
+}
+
+// DetachWorkerDomain detaches a custom domain from its Worker.
+func (c Client) DetachWorkerDomain(accountID, domainID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(domainID) == 0 {
+		return fmt.Errorf("you must provide an account ID and domain ID")
+	}
+
+	err = c.apiRequest("DELETE", workerDomainsPath(accountID)+"/"+
+		url.QueryEscape(domainID), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("detach Worker domain error: %w", err)
+	}
+
+	return nil
+}
+
+// GetWorkersSubdomain retrieves the account's workers.dev subdomain. Workers
+// are reachable at <script>.<subdomain>.workers.dev.
+func (c Client) GetWorkersSubdomain(accountID string) (string, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return "", err
+	}
+	if len(accountID) == 0 {
+		return "", fmt.Errorf("you must provide an account ID")
+	}
+
+	var result struct {
+		Subdomain string `json:"subdomain"`
+	}
+	err = c.apiRequest("GET", accountPrefix(accountID)+"/workers/subdomain",
+		nil, nil, &result)
+	if err != nil {
+		return "", fmt.Errorf("get Workers subdomain error: %w", err)
+	}
+
+	return result.Subdomain, nil
+}
+
+// SetWorkersSubdomain creates or changes the account's workers.dev
+// subdomain.
+func (c Client) SetWorkersSubdomain(accountID, subdomain string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(subdomain) == 0 {
+		return fmt.Errorf("you must provide an account ID and subdomain")
+	}
+
+	payload := struct {
+		Subdomain string `json:"subdomain"`
+	}{subdomain}
+
+	err = c.apiRequest("PUT", accountPrefix(accountID)+"/workers/subdomain",
+		nil, payload, nil)
+	if err != nil {
+		return fmt.Errorf("set Workers subdomain error: %w", err)
+	}
+
+	return nil
+}
+
+// GetWorkerSubdomainEnabled retrieves whether a Worker is reachable on the
+// account's workers.dev subdomain.
+func (c Client) GetWorkerSubdomainEnabled(accountID, script string) (bool,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return false, err
+	}
+	if len(accountID) == 0 || len(script) == 0 {
+		return false, fmt.Errorf("you must provide an account ID and script name")
+	}
+
+	var result struct {
+		Enabled bool `json:"enabled"`
+	}
+	err = c.apiRequest("GET", workerScriptPath(accountID, script)+"/subdomain",
+		nil, nil, &result)
+	if err != nil {
+		return false, fmt.Errorf("get Worker subdomain error: %w", err)
+	}
+
+	return result.Enabled, nil
+}
+
+// SetWorkerSubdomainEnabled sets whether a Worker is reachable on the
+// account's workers.dev subdomain. Turning this off is common once a Worker
+// has a custom domain.
+func (c Client) SetWorkerSubdomainEnabled(accountID, script string,
+	enabled bool) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(script) == 0 {
+		return fmt.Errorf("you must provide an account ID and script name")
+	}
+
+	payload := struct {
+		Enabled bool `json:"enabled"`
+	}{enabled}
+
+	err = c.apiRequest("POST", workerScriptPath(accountID, script)+"/subdomain",
+		nil, payload, nil)
+	if err != nil {
+		return fmt.Errorf("set Worker subdomain error: %w", err)
+	}
+
+	return nil
+}
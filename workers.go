@@ -0,0 +1,228 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/horgh/cloudflare/internal/websocket"
+)
+
+// WorkerCronTrigger is a schedule on which a Worker runs.
+type WorkerCronTrigger struct {
+	// Cron is a cron expression, e.g. "*/30 * * * *".
+	Cron       string `json:"cron"`
+	CreatedOn  string `json:"created_on,omitempty"`
+	ModifiedOn string `json:"modified_on,omitempty"`
+}
+
+// WorkerTail is a session streaming a Worker's live events. Read events with
+// Next and call Close when done.
+type WorkerTail struct {
+	ID        string `json:"id"`
+	URL       string `json:"url"`
+	ExpiresAt string `json:"expires_at"`
+
+	client    Client
+	accountID string
+	script    string
+	conn      *websocket.Conn
+}
+
+// WorkerTailEvent is an invocation of a Worker seen by a tail session.
+type WorkerTailEvent struct {
+	ScriptName string `json:"scriptName"`
+
+	// Outcome is e.g. ok, exception, exceededCpu, or canceled.
+	Outcome string `json:"outcome"`
+
+	// EventTimestamp is in milliseconds since the epoch.
+	EventTimestamp int64 `json:"eventTimestamp"`
+
+	Event      WorkerTailTrigger   `json:"event"`
+	Logs       []WorkerTailLog     `json:"logs"`
+	Exceptions []WorkerTailFailure `json:"exceptions"`
+}
+
+// WorkerTailTrigger describes what triggered an invocation. Request is set
+// for HTTP requests, and Cron for scheduled runs.
+type WorkerTailTrigger struct {
+	Request *struct {
+		URL     string            `json:"url"`
+		Method  string            `json:"method"`
+		Headers map[string]string `json:"headers"`
+	} `json:"request,omitempty"`
+
+	Response *struct {
+		Status int `json:"status"`
+	} `json:"response,omitempty"`
+
+	Cron          string `json:"cron,omitempty"`
+	ScheduledTime int64  `json:"scheduledTime,omitempty"`
+}
+
+// WorkerTailLog is a console message the Worker logged.
+type WorkerTailLog struct {
+	// Level is e.g. log, info, warn, or error.
+	Level string `json:"level"`
+
+	// Message holds the arguments passed to the console call.
+	Message []json.RawMessage `json:"message"`
+
+	// Timestamp is in milliseconds since the epoch.
+	Timestamp int64 `json:"timestamp"`
+}
+
+// WorkerTailFailure is an exception the Worker raised.
+type WorkerTailFailure struct {
+	Name      string `json:"name"`
+	Message   string `json:"message"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// Time converts the event's timestamp.
+func (e WorkerTailEvent) Time() time.Time {
+	return time.UnixMilli(e.EventTimestamp)
+}
+
+func workerScriptPath(accountID, script string) string {
+	return accountPrefix(accountID) + "/workers/scripts/" +
+		url.QueryEscape(script)
+}
+
+// GetWorkerCronTriggers retrieves the schedules a Worker runs on.
+func (c Client) GetWorkerCronTriggers(accountID, script string) (
+	[]WorkerCronTrigger, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 || len(script) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID and script name")
+	}
+
+	var result struct {
+		Schedules []WorkerCronTrigger `json:"schedules"`
+	}
+	err = c.apiRequest("GET", workerScriptPath(accountID, script)+"/schedules",
+		nil, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("get worker cron triggers error: %w", err)
+	}
+
+	return result.Schedules, nil
+}
+
+// UpdateWorkerCronTriggers replaces the schedules a Worker runs on. Give no
+// crons to remove them all.
+func (c Client) UpdateWorkerCronTriggers(accountID, script string,
+	crons []string) ([]WorkerCronTrigger, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 || len(script) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID and script name")
+	}
+
+	payload := []WorkerCronTrigger{}
+	for _, cron := range crons {
+		payload = append(payload, WorkerCronTrigger{Cron: cron})
+	}
+
+	var result struct {
+		Schedules []WorkerCronTrigger `json:"schedules"`
+	}
+	err = c.apiRequest("PUT", workerScriptPath(accountID, script)+"/schedules",
+		nil, payload, &result)
+	if err != nil {
+		return nil, fmt.Errorf("update worker cron triggers error: %w", err)
+	}
+
+	return result.Schedules, nil
+}
+
+// StartWorkerTail starts streaming a Worker's live events, such as its
+// requests, logs, and exceptions.
+//
+// Cloudflare samples events when a Worker is busy. The session expires after
+// a few hours.
+func (c Client) StartWorkerTail(accountID, script string) (*WorkerTail,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 || len(script) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID and script name")
+	}
+
+	var tail WorkerTail
+	err = c.apiRequest("POST", workerScriptPath(accountID, script)+"/tails",
+		nil, nil, &tail)
+	if err != nil {
+		return nil, fmt.Errorf("create worker tail error: %w", err)
+	}
+
+	tail.client = c
+	tail.accountID = accountID
+	tail.script = script
+
+	conn, err := websocket.Dial(tail.URL, nil, "trace-v1", c.transport(),
+		c.handshakeTimeout())
+	if err != nil {
+		_ = tail.deleteSession()
+		return nil, fmt.Errorf("unable to connect to tail: %w", err)
+	}
+	tail.conn = conn
+
+	// Without filters we get every event.
+	err = conn.WriteText([]byte(`{"filters":[],"debug":false}`))
+	if err != nil {
+		_ = tail.Close()
+		return nil, fmt.Errorf("unable to configure tail: %w", err)
+	}
+
+	return &tail, nil
+}
+
+// Next waits for the next event. It returns io.EOF when the session ends.
+func (t *WorkerTail) Next() (WorkerTailEvent, error) {
+	message, err := t.conn.ReadMessage()
+	if err != nil {
+		return WorkerTailEvent{}, err
+	}
+
+	if t.client.Debug {
+		t.client.logf("tail event: %s", message)
+	}
+
+	var event WorkerTailEvent
+	err = json.Unmarshal(message, &event)
+	if err != nil {
+		return WorkerTailEvent{}, fmt.Errorf("unable to decode tail event: %w",
+			err)
+	}
+
+	return event, nil
+}
+
+// Close stops the session.
+func (t *WorkerTail) Close() error {
+	err := t.conn.Close()
+	err2 := t.deleteSession()
+	if err2 != nil {
+		return err2
+	}
+	return err
+}
+
+func (t *WorkerTail) deleteSession() error {
+	err := t.client.apiRequest("DELETE", workerScriptPath(t.accountID,
+		t.script)+"/tails/"+url.QueryEscape(t.ID), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete worker tail error: %w", err)
+	}
+	return nil
+}
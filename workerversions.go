@@ -0,0 +1,273 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Worker versions let a Worker's code change without affecting traffic until
+// a deployment sends traffic to the new version. A deployment may split
+// traffic between two versions, such as for a gradual (canary) rollout.
+
+// WorkerVersion is an uploaded version of a Worker's code and configuration.
+type WorkerVersion struct {
+	ID          string                `json:"id"`
+	Number      int                   `json:"number"`
+	Metadata    WorkerVersionMetadata `json:"metadata"`
+	Annotations WorkerAnnotations     `json:"annotations"`
+}
+
+// WorkerVersionMetadata describes how a version was made.
+type WorkerVersionMetadata struct {
+	AuthorEmail string `json:"author_email"`
+	AuthorID    string `json:"author_id"`
+	CreatedOn   string `json:"created_on"`
+	ModifiedOn  string `json:"modified_on"`
+	Source      string `json:"source"`
+}
+
+// WorkerAnnotations are notes on a version or deployment.
+type WorkerAnnotations struct {
+	// Message describes the change, like a commit message.
+	Message string `json:"workers/message,omitempty"`
+
+	// Tag is a label, such as a release version or commit hash.
+	Tag string `json:"workers/tag,omitempty"`
+
+	// TriggeredBy is set by the API, e.g. upload or rollback.
+	TriggeredBy string `json:"workers/triggered_by,omitempty"`
+}
+
+// WorkerVersionUpload is the code and configuration of a new version.
+type WorkerVersionUpload struct {
+	// Code is an ES module whose default export has the Worker's handlers.
+	Code string
+
+	// CompatibilityDate picks the Workers runtime behaviour, e.g.
+	// 2024-09-23.
+	CompatibilityDate  string
+	CompatibilityFlags []string
+
+	// Bindings are passed as is, e.g. {"type": "plain_text", "name": "ENV",
+	// "text": "staging"}.
+	Bindings []map[string]interface{}
+
+	Annotations WorkerAnnotations
+}
+
+// WorkerDeployment sends a Worker's traffic to one or two versions.
+type WorkerDeployment struct {
+	ID          string                    `json:"id,omitempty"`
+	Source      string                    `json:"source,omitempty"`
+	Strategy    string                    `json:"strategy"`
+	AuthorEmail string                    `json:"author_email,omitempty"`
+	CreatedOn   string                    `json:"created_on,omitempty"`
+	Versions    []WorkerDeploymentVersion `json:"versions"`
+	Annotations WorkerAnnotations         `json:"annotations"`
+}
+
+// WorkerDeploymentVersion is a version in a deployment and the percentage
+// of traffic it gets.
+type WorkerDeploymentVersion struct {
+	VersionID  string  `json:"version_id"`
+	Percentage float64 `json:"percentage"`
+}
+
+// CreatedTime parses the deployment's CreatedOn time.
+func (d WorkerDeployment) CreatedTime() (time.Time, error) {
+	return parseTime(d.CreatedOn)
+}
+
+// UploadWorkerVersion uploads a new version of a Worker. It gets no traffic
+// until a deployment includes it.
+func (c Client) UploadWorkerVersion(accountID, script string,
+	upload WorkerVersionUpload) (WorkerVersion, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return WorkerVersion{}, err
+	}
+	if len(accountID) == 0 || len(script) == 0 {
+		return WorkerVersion{}, fmt.Errorf(
+			"you must provide an account ID and script name")
+	}
+
+	if len(upload.Code) == 0 {
+		return WorkerVersion{}, fmt.Errorf("you must provide the Worker's code")
+	}
+
+	if len(upload.CompatibilityDate) == 0 {
+		return WorkerVersion{}, fmt.Errorf("you must provide a compatibility date")
+	}
+
+	const fileName = "worker.js"
+
+	metadata := struct {
+		MainModule         string                   `json:"main_module"`
+		CompatibilityDate  string                   `json:"compatibility_date"`
+		CompatibilityFlags []string                 `json:"compatibility_flags,omitempty"`
+		Bindings           []map[string]interface{} `json:"bindings,omitempty"`
+		Annotations        WorkerAnnotations        `json:"annotations"`
+	}{
+		MainModule:         fileName,
+		CompatibilityDate:  upload.CompatibilityDate,
+		CompatibilityFlags: upload.CompatibilityFlags,
+		Bindings:           upload.Bindings,
+		Annotations:        upload.Annotations,
+	}
+
+	body, err := newModuleUpload(metadata, fileName, upload.Code)
+	if err != nil {
+		return WorkerVersion{}, err
+	}
+
+	var version WorkerVersion
+	err = c.apiRequest("POST", workerScriptPath(accountID, script)+"/versions",
+		nil, body, &version)
+	if err != nil {
+		return WorkerVersion{}, fmt.Errorf("upload Worker version error: %w", err)
+	}
+
+	return version, nil
+}
+
+// ListWorkerVersions lists a Worker's versions, newest first.
+func (c Client) ListWorkerVersions(accountID, script string) ([]WorkerVersion,
+	error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 || len(script) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID and script name")
+	}
+
+	var result struct {
+		Items []WorkerVersion `json:"items"`
+	}
+	err = c.apiRequest("GET", workerScriptPath(accountID, script)+"/versions",
+		nil, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("list Worker versions error: %w", err)
+	}
+
+	return result.Items, nil
+}
+
+// GetWorkerVersion retrieves a version of a Worker.
+func (c Client) GetWorkerVersion(accountID, script,
+	versionID string) (WorkerVersion, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return WorkerVersion{}, err
+	}
+	if len(accountID) == 0 || len(script) == 0 || len(versionID) == 0 {
+		return WorkerVersion{}, fmt.Errorf(
+			"you must provide an account ID, script name, and version ID")
+	}
+
+	var version WorkerVersion
+	err = c.apiRequest("GET", workerScriptPath(accountID, script)+
+		"/versions/"+url.QueryEscape(versionID), nil, nil, &version)
+	if err != nil {
+		return WorkerVersion{}, fmt.Errorf("get Worker version error: %w", err)
+	}
+
+	return version, nil
+}
+
+// ListWorkerDeployments lists a Worker's deployments, newest first. The
+// first is the one serving traffic.
+func (c Client) ListWorkerDeployments(accountID,
+	script string) ([]WorkerDeployment, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 || len(script) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID and script name")
+	}
+
+	var result struct {
+		Deployments []WorkerDeployment `json:"deployments"`
+	}
+	err = c.apiRequest("GET", workerScriptPath(accountID, script)+
+		"/deployments", nil, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("list Worker deployments error: %w", err)
+	}
+
+	return result.Deployments, nil
+}
+
+// CreateWorkerDeployment sends a Worker's traffic to the given versions. The
+// percentages must add up to 100. For example, to send 10% of traffic to a
+// new version:
+//
+//	client.CreateWorkerDeployment(accountID, script,
+//		[]cloudflare.WorkerDeploymentVersion{
+//			{VersionID: newID, Percentage: 10},
+//			{VersionID: oldID, Percentage: 90},
+//		}, cloudflare.WorkerAnnotations{Message: "Canary"})
+func (c Client) CreateWorkerDeployment(accountID, script string,
+	versions []WorkerDeploymentVersion,
+	annotations WorkerAnnotations) (WorkerDeployment, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return WorkerDeployment{}, err
+	}
+	if len(accountID) == 0 || len(script) == 0 {
+		return WorkerDeployment{}, fmt.Errorf(
+			"you must provide an account ID and script name")
+	}
+
+	if len(versions) == 0 || len(versions) > 2 {
+		return WorkerDeployment{}, fmt.Errorf(
+			"a deployment must have one or two versions")
+	}
+
+	total := 0.0
+	for _, v := range versions {
+		if len(v.VersionID) == 0 {
+			return WorkerDeployment{}, fmt.Errorf("deployment version has no ID")
+		}
+		if v.Percentage <= 0 {
+			return WorkerDeployment{}, fmt.Errorf(
+				"version %s must have a positive percentage", v.VersionID)
+		}
+		total += v.Percentage
+	}
+	if total < 99.99 || total > 100.01 {
+		return WorkerDeployment{}, fmt.Errorf(
+			"deployment percentages add up to %g, not 100", total)
+	}
+
+	payload := WorkerDeployment{
+		Strategy:    "percentage",
+		Versions:    versions,
+		Annotations: annotations,
+	}
+
+	var deployment WorkerDeployment
+	err = c.apiRequest("POST", workerScriptPath(accountID, script)+
+		"/deployments", nil, payload, &deployment)
+	if err != nil {
+		return WorkerDeployment{}, fmt.Errorf(
+			"create Worker deployment error: %w", err)
+	}
+
+	return deployment, nil
+}
+
+// RollbackWorker sends all of a Worker's traffic to a version, such as the
+// one that served it before a bad deployment. message says why.
+func (c Client) RollbackWorker(accountID, script, versionID,
+	message string) (WorkerDeployment, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return WorkerDeployment{}, err
+	}
+	return c.CreateWorkerDeployment(accountID, script,
+		[]WorkerDeploymentVersion{{VersionID: versionID, Percentage: 100}},
+		WorkerAnnotations{Message: message})
+}
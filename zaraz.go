@@ -0,0 +1,146 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ZarazHistoryEntry is a past version of a zone's Zaraz configuration.
+type ZarazHistoryEntry struct {
+	ID          int    `json:"id"`
+	Description string `json:"description"`
+	UserID      string `json:"userId"`
+	CreatedAt   string `json:"createdAt"`
+	UpdatedAt   string `json:"updatedAt"`
+}
+
+func zarazPath(zoneID string) string {
+	return zonePrefix(zoneID) + "/settings/zaraz"
+}
+
+// GetZarazConfig retrieves a zone's Zaraz configuration.
+//
+// The configuration is large and changes often, so we return it as JSON
+// rather than decoding it. This also makes it simple to keep in version
+// control.
+func (c Client) GetZarazConfig(zoneID string) (json.RawMessage, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var config json.RawMessage
+	err := c.apiRequest("GET", zarazPath(zoneID)+"/config", nil, nil, &config)
+	if err != nil {
+		return nil, fmt.Errorf("get zaraz config error: %w", err)
+	}
+
+	return config, nil
+}
+
+// UpdateZarazConfig replaces a zone's Zaraz configuration. config is the full
+// configuration as returned by GetZarazConfig.
+//
+// Whether this takes effect immediately depends on the zone's Zaraz workflow.
+// If it uses preview mode, call PublishZarazConfig afterwards.
+func (c Client) UpdateZarazConfig(zoneID string,
+	config json.RawMessage) (json.RawMessage, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	if !json.Valid(config) {
+		return nil, fmt.Errorf("zaraz config is not valid JSON")
+	}
+
+	var updated json.RawMessage
+	err := c.apiRequest("PUT", zarazPath(zoneID)+"/config", nil, config,
+		&updated)
+	if err != nil {
+		return nil, fmt.Errorf("update zaraz config error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// PublishZarazConfig publishes a zone's Zaraz configuration. description
+// describes the change and shows in the history.
+func (c Client) PublishZarazConfig(zoneID, description string) error {
+	if len(zoneID) == 0 {
+		return fmt.Errorf("you must provide a zone ID")
+	}
+
+	err := c.apiRequest("POST", zarazPath(zoneID)+"/publish", nil,
+		description, nil)
+	if err != nil {
+		return fmt.Errorf("publish zaraz config error: %w", err)
+	}
+
+	return nil
+}
+
+// ListZarazHistory retrieves the history of a zone's Zaraz configuration,
+// most recent first. offset and limit page through it. limit may be 0 for
+// the API's default.
+func (c Client) ListZarazHistory(zoneID string, offset,
+	limit int) ([]ZarazHistoryEntry, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	values := url.Values{}
+	values.Set("sortField", "updated_at")
+	values.Set("sortOrder", "DESC")
+	if offset > 0 {
+		values.Set("offset", strconv.Itoa(offset))
+	}
+	if limit > 0 {
+		values.Set("limit", strconv.Itoa(limit))
+	}
+
+	var entries []ZarazHistoryEntry
+	err := c.apiRequest("GET", zarazPath(zoneID)+"/history", values, nil,
+		&entries)
+	if err != nil {
+		return nil, fmt.Errorf("list zaraz history error: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetZarazHistoryConfigs retrieves past Zaraz configurations by their history
+// IDs. We return a map of ID to configuration.
+func (c Client) GetZarazHistoryConfigs(zoneID string,
+	ids []int) (map[int]json.RawMessage, error) {
+	if len(zoneID) == 0 || len(ids) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID and history IDs")
+	}
+
+	var idStrings []string
+	for _, id := range ids {
+		idStrings = append(idStrings, strconv.Itoa(id))
+	}
+
+	values := url.Values{}
+	values.Set("ids", strings.Join(idStrings, ","))
+
+	var result map[string]json.RawMessage
+	err := c.apiRequest("GET", zarazPath(zoneID)+"/history/configs", values,
+		nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("get zaraz history configs error: %w", err)
+	}
+
+	configs := map[int]json.RawMessage{}
+	for k, v := range result {
+		id, err := strconv.Atoi(k)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected history ID: %s", k)
+		}
+		configs[id] = v
+	}
+
+	return configs, nil
+}
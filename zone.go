@@ -0,0 +1,276 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ZoneHold holds the state of a zone's hold. While a zone is on hold, no one
+// else can add it (or, optionally, its subdomains) to Cloudflare. SaaS
+// providers use this to prevent domain takeover.
+type ZoneHold struct {
+	Hold              bool   `json:"hold"`
+	IncludeSubdomains bool   `json:"include_subdomains"`
+	HoldAfter         string `json:"hold_after,omitempty"`
+}
+
+// ZoneSubscription holds a zone's plan subscription.
+type ZoneSubscription struct {
+	ID               string   `json:"id,omitempty"`
+	RatePlan         RatePlan `json:"rate_plan"`
+	Frequency        string   `json:"frequency,omitempty"`
+	Price            float64  `json:"price,omitempty"`
+	Currency         string   `json:"currency,omitempty"`
+	State            string   `json:"state,omitempty"`
+	CurrentPeriodEnd string   `json:"current_period_end,omitempty"`
+}
+
+// RatePlan identifies a plan.
+type RatePlan struct {
+	ID                string `json:"id"`
+	PublicName        string `json:"public_name,omitempty"`
+	Currency          string `json:"currency,omitempty"`
+	Scope             string `json:"scope,omitempty"`
+	ExternallyManaged bool   `json:"externally_managed,omitempty"`
+}
+
+// AvailablePlan is a plan a zone may subscribe to.
+type AvailablePlan struct {
+	ID           string  `json:"id"`
+	Name         string  `json:"name"`
+	Price        float64 `json:"price"`
+	Currency     string  `json:"currency"`
+	Frequency    string  `json:"frequency"`
+	LegacyID     string  `json:"legacy_id"`
+	CanSubscribe bool    `json:"can_subscribe"`
+	IsSubscribed bool    `json:"is_subscribed"`
+}
+
+// GetZoneHold retrieves the state of a zone's hold.
+func (c Client) GetZoneHold(zoneID string) (ZoneHold, error) {
+	if len(zoneID) == 0 {
+		return ZoneHold{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var hold ZoneHold
+	err := c.apiRequest("GET", zonePrefix(zoneID)+"/hold", nil, nil, &hold)
+	if err != nil {
+		return ZoneHold{}, fmt.Errorf("get zone hold error: %w", err)
+	}
+
+	return hold, nil
+}
+
+// SetZoneHold puts a zone on hold. If includeSubdomains is set, the hold also
+// prevents adding subdomains of the zone as zones.
+func (c Client) SetZoneHold(zoneID string, includeSubdomains bool) (ZoneHold,
+	error) {
+	if len(zoneID) == 0 {
+		return ZoneHold{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	values := url.Values{}
+	if includeSubdomains {
+		values.Set("include_subdomains", "true")
+	}
+
+	var hold ZoneHold
+	err := c.apiRequest("POST", zonePrefix(zoneID)+"/hold", values, nil, &hold)
+	if err != nil {
+		return ZoneHold{}, fmt.Errorf("set zone hold error: %w", err)
+	}
+
+	return hold, nil
+}
+
+// RemoveZoneHold takes a zone off hold.
+//
+// If holdAfter is non-zero the hold is released only until that time, after
+// which it comes back into effect. This allows a brief window to add the zone
+// elsewhere.
+func (c Client) RemoveZoneHold(zoneID string, holdAfter time.Time) (ZoneHold,
+	error) {
+	if len(zoneID) == 0 {
+		return ZoneHold{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	values := url.Values{}
+	if !holdAfter.IsZero() {
+		values.Set("hold_after", holdAfter.UTC().Format(time.RFC3339))
+	}
+
+	var hold ZoneHold
+	err := c.apiRequest("DELETE", zonePrefix(zoneID)+"/hold", values, nil,
+		&hold)
+	if err != nil {
+		return ZoneHold{}, fmt.Errorf("remove zone hold error: %w", err)
+	}
+
+	return hold, nil
+}
+
+// PauseZone pauses Cloudflare on a zone. Traffic goes directly to the origin
+// and Cloudflare only serves DNS.
+func (c Client) PauseZone(zoneID string) (Zone, error) {
+	return c.setZonePaused(zoneID, true)
+}
+
+// UnpauseZone undoes PauseZone.
+func (c Client) UnpauseZone(zoneID string) (Zone, error) {
+	return c.setZonePaused(zoneID, false)
+}
+
+func (c Client) setZonePaused(zoneID string, paused bool) (Zone, error) {
+	if len(zoneID) == 0 {
+		return Zone{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	payload := struct {
+		Paused bool `json:"paused"`
+	}{paused}
+
+	var zone Zone
+	err := c.apiRequest("PATCH", zonePrefix(zoneID), nil, payload, &zone)
+	if err != nil {
+		return Zone{}, fmt.Errorf("update zone paused error: %w", err)
+	}
+
+	return zone, nil
+}
+
+// ListAvailablePlans retrieves the plans a zone may subscribe to.
+func (c Client) ListAvailablePlans(zoneID string) ([]AvailablePlan, error) {
+	if len(zoneID) == 0 {
+		return nil, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var plans []AvailablePlan
+	err := c.apiRequest("GET", zonePrefix(zoneID)+"/available_plans", nil, nil,
+		&plans)
+	if err != nil {
+		return nil, fmt.Errorf("list available plans error: %w", err)
+	}
+
+	return plans, nil
+}
+
+// GetZoneSubscription retrieves a zone's plan subscription.
+func (c Client) GetZoneSubscription(zoneID string) (ZoneSubscription, error) {
+	if len(zoneID) == 0 {
+		return ZoneSubscription{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var sub ZoneSubscription
+	err := c.apiRequest("GET", zonePrefix(zoneID)+"/subscription", nil, nil,
+		&sub)
+	if err != nil {
+		return ZoneSubscription{}, fmt.Errorf("get zone subscription error: %w",
+			err)
+	}
+
+	return sub, nil
+}
+
+// ChangeZonePlan changes the zone's plan. planID is the ID of a rate plan
+// (see ListAvailablePlans), such as "free" or "pro".
+//
+// Changing to a paid plan bills the account.
+func (c Client) ChangeZonePlan(zoneID, planID string) (ZoneSubscription,
+	error) {
+	if len(zoneID) == 0 || len(planID) == 0 {
+		return ZoneSubscription{}, fmt.Errorf(
+			"you must provide a zone ID and plan ID")
+	}
+
+	payload := ZoneSubscription{RatePlan: RatePlan{ID: planID}}
+
+	var sub ZoneSubscription
+	err := c.apiRequest("PUT", zonePrefix(zoneID)+"/subscription", nil, payload,
+		&sub)
+	if err != nil {
+		return ZoneSubscription{}, fmt.Errorf("change zone plan error: %w", err)
+	}
+
+	return sub, nil
+}
+
+// DeleteZone removes a zone from Cloudflare, along with its DNS records and
+// settings. This cannot be undone.
+//
+// A Protection policy refuses this unless forced.
+func (c Client) DeleteZone(zoneID string) error {
+	if len(zoneID) == 0 {
+		return fmt.Errorf("you must provide a zone ID")
+	}
+
+	return c.guard(OperationDeleteZone, zoneID, "", func() error {
+		err := c.apiRequest("DELETE", zonePrefix(zoneID), nil, nil, nil)
+		if err != nil {
+			return fmt.Errorf("delete zone error: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Zone types.
+const (
+	// ZoneTypeFull zones use Cloudflare's nameservers.
+	ZoneTypeFull = "full"
+
+	// ZoneTypePartial zones keep their nameservers and CNAME hostnames to
+	// Cloudflare.
+	ZoneTypePartial = "partial"
+)
+
+// CreateZone adds a zone to an account. zoneType is one of the ZoneType
+// constants, or blank for a full zone.
+//
+// The zone is pending until its registrar points at the nameservers in the
+// returned zone's NameServers.
+func (c Client) CreateZone(accountID, name, zoneType string) (Zone, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return Zone{}, err
+	}
+	if len(accountID) == 0 || len(name) == 0 {
+		return Zone{}, fmt.Errorf("you must provide an account ID and zone name")
+	}
+
+	if len(zoneType) == 0 {
+		zoneType = ZoneTypeFull
+	}
+
+	payload := struct {
+		Name    string `json:"name"`
+		Type    string `json:"type"`
+		Account struct {
+			ID string `json:"id"`
+		} `json:"account"`
+	}{Name: name, Type: zoneType}
+	payload.Account.ID = accountID
+
+	var zone Zone
+	err = c.apiRequest("POST", "zones", nil, payload, &zone)
+	if err != nil {
+		return Zone{}, fmt.Errorf("create zone error: %w", err)
+	}
+
+	return zone, nil
+}
+
+// GetZone retrieves a zone.
+func (c Client) GetZone(zoneID string) (Zone, error) {
+	if len(zoneID) == 0 {
+		return Zone{}, fmt.Errorf("you must provide a zone ID")
+	}
+
+	var zone Zone
+	err := c.apiRequest("GET", zonePrefix(zoneID), nil, nil, &zone)
+	if err != nil {
+		return Zone{}, fmt.Errorf("get zone error: %w", err)
+	}
+
+	return zone, nil
+}
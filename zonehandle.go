@@ -0,0 +1,135 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// ZoneHandle makes requests about a single zone. Get one with Client.Zone.
+//
+// It saves passing the zone ID to every call:
+//
+//	z := client.Zone(zoneID)
+//	err := z.PurgeAll()
+type ZoneHandle struct {
+	client Client
+	id     string
+}
+
+// Zone returns a handle for making requests about the zone with the given
+// ID. It makes no request itself.
+func (c Client) Zone(zoneID string) ZoneHandle {
+	return ZoneHandle{client: c, id: zoneID}
+}
+
+// ID returns the zone's ID.
+func (z ZoneHandle) ID() string {
+	return z.id
+}
+
+// DNSRecords iterates over the zone's DNS records. See Client.DNSRecords.
+func (z ZoneHandle) DNSRecords(ctx context.Context,
+	opts DNSRecordListOptions) iter.Seq2[DNSRecord, error] {
+	return z.client.DNSRecords(ctx, z.id, opts)
+}
+
+// ListDNSRecords retrieves all of the zone's DNS records. recordType and
+// name may be blank to not filter on them.
+func (z ZoneHandle) ListDNSRecords(recordType, name string) ([]DNSRecord,
+	error) {
+	return z.client.ListAllDNSRecords(z.id, recordType, name)
+}
+
+// CreateDNSRecord creates a DNS record in the zone.
+func (z ZoneHandle) CreateDNSRecord(record DNSRecord) (DNSRecord, error) {
+	record.ZoneID = z.id
+	return z.client.CreateDNSRecord(record)
+}
+
+// UpdateDNSRecord updates a DNS record in the zone.
+func (z ZoneHandle) UpdateDNSRecord(record DNSRecord) error {
+	record.ZoneID = z.id
+	return z.client.UpdateDNSRecord(record)
+}
+
+// DeleteDNSRecord deletes a DNS record from the zone.
+func (z ZoneHandle) DeleteDNSRecord(record DNSRecord) error {
+	record.ZoneID = z.id
+	return z.client.DeleteDNSRecord(record)
+}
+
+// ScanDNSRecords asks Cloudflare to find and add the zone's common records.
+func (z ZoneHandle) ScanDNSRecords() (DNSScanResult, error) {
+	return z.client.ScanDNSRecords(z.id)
+}
+
+// PurgeAll purges everything from the zone's cache.
+func (z ZoneHandle) PurgeAll() error {
+	return z.client.PurgeAllFiles(z.id)
+}
+
+// PurgeFiles purges URLs from the zone's cache.
+func (z ZoneHandle) PurgeFiles(urls []string) error {
+	return z.client.PurgeFiles(z.id, urls)
+}
+
+// Settings retrieves all of the zone's settings.
+func (z ZoneHandle) Settings() ([]ZoneSetting, error) {
+	return z.client.ListZoneSettings(z.id)
+}
+
+// Setting retrieves one of the zone's settings.
+func (z ZoneHandle) Setting(name string) (ZoneSetting, error) {
+	return z.client.GetZoneSetting(z.id, name)
+}
+
+// UpdateSetting changes one of the zone's settings. See
+// Client.UpdateZoneSetting.
+func (z ZoneHandle) UpdateSetting(name string,
+	value interface{}) (ZoneSetting, error) {
+	return z.client.UpdateZoneSetting(z.id, name, value)
+}
+
+// DevelopmentMode retrieves the state of the zone's development mode.
+func (z ZoneHandle) DevelopmentMode() (DevelopmentMode, error) {
+	return z.client.GetDevelopmentMode(z.id)
+}
+
+// Rules retrieves the zone's rules for a phase. See
+// Client.GetZoneEntrypointRuleset.
+func (z ZoneHandle) Rules(phase string) (Ruleset, error) {
+	return z.client.GetZoneEntrypointRuleset(z.id, phase)
+}
+
+// ZoneIDByName finds the ID of the active zone with the given name.
+//
+// We remember the IDs we look up, so asking again for the same name makes no
+// request. Zone IDs only change if a zone is deleted and added again, so
+// without WithCache we remember them for the life of the client. With
+// WithCache we remember them for its TTL.
+func (c Client) ZoneIDByName(name string) (string, error) {
+	name = NormalizeName(name)
+	if len(name) == 0 {
+		return "", fmt.Errorf("you must provide a zone name")
+	}
+
+	key := "zone:" + name
+
+	if id, ok := c.cache.get(key); ok {
+		return id.(string), nil
+	}
+
+	zones, err := c.ListZones(name, "", -1, -1, "", "", "")
+	if err != nil {
+		return "", err
+	}
+
+	if len(zones) != 1 {
+		return "", fmt.Errorf("zone not found: %s", name)
+	}
+
+	c.cache.set(key, zones[0].ID)
+
+	return zones[0].ID, nil
+}
@@ -0,0 +1,319 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+)
+
+// SecondaryDNSACL allows an IP range to take part in zone transfers with the
+// account's zones: to send NOTIFYs for secondary zones, and to request
+// transfers (AXFR/IXFR) of primary zones.
+type SecondaryDNSACL struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+
+	// IPRange is a CIDR, at most a /24 for IPv4 or a /64 for IPv6.
+	IPRange string `json:"ip_range"`
+}
+
+// SecondaryDNSPeer is a nameserver the account's zones transfer to or from.
+type SecondaryDNSPeer struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+
+	// IP and Port are where to reach the peer. Port 0 means 53.
+	IP   string `json:"ip,omitempty"`
+	Port int    `json:"port,omitempty"`
+
+	// IXFREnable is whether to use incremental transfers with the peer.
+	IXFREnable bool `json:"ixfr_enable"`
+
+	// TSIGID is the TSIG key that signs transfers with the peer. Blank for
+	// none.
+	TSIGID string `json:"tsig_id,omitempty"`
+}
+
+func secondaryDNSPath(accountID, kind string) string {
+	return accountPrefix(accountID) + "/secondary_dns/" + kind
+}
+
+// ListSecondaryDNSACLs lists the account's zone transfer ACLs.
+func (c Client) ListSecondaryDNSACLs(
+	accountID string) ([]SecondaryDNSACL, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var acls []SecondaryDNSACL
+	err = c.apiRequest("GET", secondaryDNSPath(accountID, "acls"), nil, nil,
+		&acls)
+	if err != nil {
+		return nil, fmt.Errorf("list secondary DNS ACLs error: %w", err)
+	}
+
+	return acls, nil
+}
+
+// GetSecondaryDNSACL retrieves a zone transfer ACL.
+func (c Client) GetSecondaryDNSACL(accountID,
+	aclID string) (SecondaryDNSACL, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return SecondaryDNSACL{}, err
+	}
+	if len(accountID) == 0 || len(aclID) == 0 {
+		return SecondaryDNSACL{}, fmt.Errorf(
+			"you must provide an account ID and ACL ID")
+	}
+
+	var acl SecondaryDNSACL
+	err = c.apiRequest("GET", secondaryDNSPath(accountID, "acls")+"/"+
+		url.QueryEscape(aclID), nil, nil, &acl)
+	if err != nil {
+		return SecondaryDNSACL{}, fmt.Errorf(
+			"get secondary DNS ACL error: %w", err)
+	}
+
+	return acl, nil
+}
+
+// CreateSecondaryDNSACL creates a zone transfer ACL. Name and IPRange are
+// required.
+func (c Client) CreateSecondaryDNSACL(accountID string,
+	acl SecondaryDNSACL) (SecondaryDNSACL, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return SecondaryDNSACL{}, err
+	}
+	if len(accountID) == 0 {
+		return SecondaryDNSACL{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	err = validateSecondaryDNSACL(acl)
+	if err != nil {
+		return SecondaryDNSACL{}, err
+	}
+
+	acl.ID = ""
+
+	var created SecondaryDNSACL
+	err = c.apiRequest("POST", secondaryDNSPath(accountID, "acls"), nil, acl,
+		&created)
+	if err != nil {
+		return SecondaryDNSACL{}, fmt.Errorf(
+			"create secondary DNS ACL error: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateSecondaryDNSACL replaces a zone transfer ACL. Its ID must be set.
+func (c Client) UpdateSecondaryDNSACL(accountID string,
+	acl SecondaryDNSACL) (SecondaryDNSACL, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return SecondaryDNSACL{}, err
+	}
+	if len(accountID) == 0 || len(acl.ID) == 0 {
+		return SecondaryDNSACL{}, fmt.Errorf(
+			"you must provide an account ID and ACL ID")
+	}
+
+	err = validateSecondaryDNSACL(acl)
+	if err != nil {
+		return SecondaryDNSACL{}, err
+	}
+
+	var updated SecondaryDNSACL
+	err = c.apiRequest("PUT", secondaryDNSPath(accountID, "acls")+"/"+
+		url.QueryEscape(acl.ID), nil, acl, &updated)
+	if err != nil {
+		return SecondaryDNSACL{}, fmt.Errorf(
+			"update secondary DNS ACL error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteSecondaryDNSACL deletes a zone transfer ACL.
+func (c Client) DeleteSecondaryDNSACL(accountID, aclID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(aclID) == 0 {
+		return fmt.Errorf("you must provide an account ID and ACL ID")
+	}
+
+	err = c.apiRequest("DELETE", secondaryDNSPath(accountID, "acls")+"/"+
+		url.QueryEscape(aclID), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete secondary DNS ACL error: %w", err)
+	}
+
+	return nil
+}
+
+func validateSecondaryDNSACL(acl SecondaryDNSACL) error {
+	if len(acl.Name) == 0 {
+		return fmt.Errorf("you must provide an ACL name")
+	}
+
+	_, ipNet, err := net.ParseCIDR(acl.IPRange)
+	if err != nil {
+		return fmt.Errorf("invalid IP range: %s", acl.IPRange)
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	if bits == 32 && ones < 24 {
+		return fmt.Errorf("IP range %s is too large. The most allowed is a /24",
+			acl.IPRange)
+	}
+	if bits == 128 && ones < 64 {
+		return fmt.Errorf("IP range %s is too large. The most allowed is a /64",
+			acl.IPRange)
+	}
+
+	return nil
+}
+
+// ListSecondaryDNSPeers lists the account's zone transfer peers.
+func (c Client) ListSecondaryDNSPeers(
+	accountID string) ([]SecondaryDNSPeer, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountID) == 0 {
+		return nil, fmt.Errorf("you must provide an account ID")
+	}
+
+	var peers []SecondaryDNSPeer
+	err = c.apiRequest("GET", secondaryDNSPath(accountID, "peers"), nil, nil,
+		&peers)
+	if err != nil {
+		return nil, fmt.Errorf("list secondary DNS peers error: %w", err)
+	}
+
+	return peers, nil
+}
+
+// GetSecondaryDNSPeer retrieves a zone transfer peer.
+func (c Client) GetSecondaryDNSPeer(accountID,
+	peerID string) (SecondaryDNSPeer, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return SecondaryDNSPeer{}, err
+	}
+	if len(accountID) == 0 || len(peerID) == 0 {
+		return SecondaryDNSPeer{}, fmt.Errorf(
+			"you must provide an account ID and peer ID")
+	}
+
+	var peer SecondaryDNSPeer
+	err = c.apiRequest("GET", secondaryDNSPath(accountID, "peers")+"/"+
+		url.QueryEscape(peerID), nil, nil, &peer)
+	if err != nil {
+		return SecondaryDNSPeer{}, fmt.Errorf(
+			"get secondary DNS peer error: %w", err)
+	}
+
+	return peer, nil
+}
+
+// CreateSecondaryDNSPeer creates a zone transfer peer. Name is required.
+func (c Client) CreateSecondaryDNSPeer(accountID string,
+	peer SecondaryDNSPeer) (SecondaryDNSPeer, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return SecondaryDNSPeer{}, err
+	}
+	if len(accountID) == 0 {
+		return SecondaryDNSPeer{}, fmt.Errorf("you must provide an account ID")
+	}
+
+	err = validateSecondaryDNSPeer(peer)
+	if err != nil {
+		return SecondaryDNSPeer{}, err
+	}
+
+	peer.ID = ""
+
+	var created SecondaryDNSPeer
+	err = c.apiRequest("POST", secondaryDNSPath(accountID, "peers"), nil, peer,
+		&created)
+	if err != nil {
+		return SecondaryDNSPeer{}, fmt.Errorf(
+			"create secondary DNS peer error: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateSecondaryDNSPeer replaces a zone transfer peer. Its ID must be set.
+func (c Client) UpdateSecondaryDNSPeer(accountID string,
+	peer SecondaryDNSPeer) (SecondaryDNSPeer, error) {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return SecondaryDNSPeer{}, err
+	}
+	if len(accountID) == 0 || len(peer.ID) == 0 {
+		return SecondaryDNSPeer{}, fmt.Errorf(
+			"you must provide an account ID and peer ID")
+	}
+
+	err = validateSecondaryDNSPeer(peer)
+	if err != nil {
+		return SecondaryDNSPeer{}, err
+	}
+
+	var updated SecondaryDNSPeer
+	err = c.apiRequest("PUT", secondaryDNSPath(accountID, "peers")+"/"+
+		url.QueryEscape(peer.ID), nil, peer, &updated)
+	if err != nil {
+		return SecondaryDNSPeer{}, fmt.Errorf(
+			"update secondary DNS peer error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteSecondaryDNSPeer deletes a zone transfer peer.
+func (c Client) DeleteSecondaryDNSPeer(accountID, peerID string) error {
+	accountID, err := c.account(accountID)
+	if err != nil {
+		return err
+	}
+	if len(accountID) == 0 || len(peerID) == 0 {
+		return fmt.Errorf("you must provide an account ID and peer ID")
+	}
+
+	err = c.apiRequest("DELETE", secondaryDNSPath(accountID, "peers")+"/"+
+		url.QueryEscape(peerID), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete secondary DNS peer error: %w", err)
+	}
+
+	return nil
+}
+
+func validateSecondaryDNSPeer(peer SecondaryDNSPeer) error {
+	if len(peer.Name) == 0 {
+		return fmt.Errorf("you must provide a peer name")
+	}
+
+	if len(peer.IP) > 0 && net.ParseIP(peer.IP) == nil {
+		return fmt.Errorf("invalid peer IP: %s", peer.IP)
+	}
+
+	if peer.Port < 0 || peer.Port > 65535 {
+		return fmt.Errorf("invalid peer port: %d", peer.Port)
+	}
+
+	return nil
+}